@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/infrastructure/dataaccess"
+	"Trip-Trove-API/utils"
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"gorm.io/gorm"
+	"io"
+	"os"
+	"strings"
+)
+
+// CreateAdmin runs the `create-admin` subcommand, which makes the first Admin of a fresh
+// database. An existing user with the email is promoted; otherwise a user is created with
+// the password from ADMIN_PASSWORD, or from the first line of stdin when that is unset. It
+// refuses once any Admin exists; from then on roles are granted through the API.
+func CreateAdmin(db *gorm.DB, args []string, stdin io.Reader, out io.Writer) error {
+	var admin entities.User
+
+	flags := flag.NewFlagSet("create-admin", flag.ContinueOnError)
+	flags.SetOutput(out)
+	flags.StringVar(&admin.Email, "email", "", "email of the admin; an existing user with it is promoted")
+	flags.StringVar(&admin.Username, "username", "", "username of a new admin")
+	flags.StringVar(&admin.FirstName, "first-name", "", "first name of a new admin")
+	flags.StringVar(&admin.LastName, "last-name", "", "last name of a new admin")
+	flags.StringVar(&admin.PhoneNumber, "phone", "", "phone number of a new admin, in E.164 format")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if admin.Email == "" || flags.NArg() != 0 {
+		return errors.New("usage: create-admin -email <email> [-username <name> -first-name <name> -last-name <name> -phone <number>]")
+	}
+
+	repos := dataaccess.NewGormRepositories(db)
+	_, err := repos.Users.UserByEmail(admin.Email)
+	switch {
+	case apperrors.KindOf(err) == apperrors.KindNotFound:
+		if err := completeNewAdmin(&admin, stdin); err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	}
+
+	userService := services.UserService{Repo: repos.Users, TokenRepo: repos.Tokens, UnitOfWork: dataaccess.NewGormUnitOfWork(db)}
+	user, created, err := userService.BootstrapAdmin(admin)
+	if err != nil {
+		return err
+	}
+
+	if created {
+		fmt.Fprintf(out, "created admin %s (id %d)\n", user.Email, user.ID)
+	} else {
+		fmt.Fprintf(out, "promoted %s (id %d) to admin\n", user.Email, user.ID)
+	}
+	return nil
+}
+
+// completeNewAdmin checks the flags a new user needs and reads its password.
+func completeNewAdmin(admin *entities.User, stdin io.Reader) error {
+	var missing []string
+	for _, required := range []struct{ flag, value string }{
+		{"-username", admin.Username},
+		{"-first-name", admin.FirstName},
+		{"-last-name", admin.LastName},
+		{"-phone", admin.PhoneNumber},
+	} {
+		if required.value == "" {
+			missing = append(missing, required.flag)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("no user has email %s; creating one needs %s", admin.Email, strings.Join(missing, ", "))
+	}
+
+	password := os.Getenv("ADMIN_PASSWORD")
+	if password == "" {
+		line, err := bufio.NewReader(stdin).ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		password = strings.TrimRight(line, "\r\n")
+	}
+	if !utils.IsComplexPassword(password) {
+		return errors.New("the password needs 8 to 40 characters with upper and lower case letters, a digit and one of @$!%*?&")
+	}
+	admin.Password = password
+	return nil
+}
@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/repositories"
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/infrastructure/dataaccess"
+	"errors"
+	"flag"
+	"fmt"
+	"gorm.io/gorm"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Import runs the `import` subcommand: it imports destinations from a CSV or JSON Lines file,
+// or from stdin when the file is "-", on behalf of an existing manager or admin. The events
+// it produces are appended to the event log, so reconnecting stream clients see them, but
+// running instances do not push them live.
+func Import(db *gorm.DB, args []string, stdin io.Reader, out io.Writer) error {
+	var formatName string
+	var dryRun bool
+	var userID uint
+
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	flags.SetOutput(out)
+	flags.StringVar(&formatName, "format", "", "csv or jsonl; guessed from the file extension when empty")
+	flags.BoolVar(&dryRun, "dry-run", false, "check every row without saving anything")
+	flags.UintVar(&userID, "user", 0, "id of the manager or admin the import runs as")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("usage: import -user <id> [-format csv|jsonl] [-dry-run] <file|->")
+	}
+	path := flags.Arg(0)
+
+	if formatName == "" {
+		formatName = strings.TrimPrefix(filepath.Ext(path), ".")
+	}
+	format, err := services.ParseImportFormat(formatName)
+	if err != nil {
+		return err
+	}
+
+	repos := dataaccess.NewGormRepositories(db)
+	actor, err := importActor(repos.Users, userID)
+	if err != nil {
+		return err
+	}
+
+	input := stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+
+	rows, err := services.ParseDestinationImport(input, format)
+	if err != nil {
+		return err
+	}
+
+	eventStream := &services.EventStream{Log: repos.Events}
+	defer eventStream.Close()
+	destinationService := services.DestinationService{
+		Repo:         repos.Destinations,
+		LocationRepo: repos.Locations,
+		UnitOfWork:   dataaccess.NewGormUnitOfWork(db),
+		Events:       eventStream,
+	}
+	report, err := destinationService.ImportDestinations(rows, dryRun, actor)
+	if err != nil {
+		return err
+	}
+
+	printImportReport(out, report)
+	return nil
+}
+
+func importActor(users repositories.UserRepository, userID uint) (entities.Actor, error) {
+	if userID == 0 {
+		return entities.Actor{}, errors.New("-user is required")
+	}
+	user, err := users.UserByID(userID)
+	if err != nil {
+		return entities.Actor{}, fmt.Errorf("user %d: %w", userID, err)
+	}
+	actor := entities.Actor{UserID: user.ID, Role: user.Role, Authenticated: true, EmailVerified: user.EmailVerified()}
+	if !actor.Can(entities.DestinationsImport) {
+		return entities.Actor{}, fmt.Errorf("user %d may not import destinations", userID)
+	}
+	return actor, nil
+}
+
+func printImportReport(out io.Writer, report *services.ImportReport) {
+	for _, row := range report.Rows {
+		if row.Status != services.ImportRejected {
+			continue
+		}
+		reasons := make([]string, 0, len(row.Errors))
+		for _, fieldErr := range row.Errors {
+			if fieldErr.Field == "" {
+				reasons = append(reasons, fieldErr.Message)
+			} else {
+				reasons = append(reasons, fieldErr.Field+": "+fieldErr.Message)
+			}
+		}
+		fmt.Fprintf(out, "line %d rejected: %s\n", row.Line, strings.Join(reasons, "; "))
+	}
+
+	verb := "imported"
+	if report.DryRun {
+		verb = "dry run, would have imported"
+	}
+	fmt.Fprintf(out, "%s: %d created, %d updated, %d rejected, %d new locations\n", verb, report.Created, report.Updated, report.Rejected, report.LocationsCreated)
+}
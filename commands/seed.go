@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/infrastructure/dataaccess"
+	"flag"
+	"fmt"
+	"gorm.io/gorm"
+	"io"
+	"strings"
+)
+
+// seededTables are emptied by `seed -wipe`, children first. Webhooks are configuration
+// rather than data and are kept.
+var seededTables = []string{"trip_stops", "trips", "destinations", "locations", "refresh_tokens", "revoked_tokens", "user_tokens", "role_changes", "users", "event_log"}
+
+// Seed runs the `seed` subcommand: it fills the database with reproducible fake data.
+func Seed(db *gorm.DB, args []string, out io.Writer) error {
+	var options services.SeedOptions
+	var wipeFirst bool
+
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	flags.SetOutput(out)
+	flags.IntVar(&options.Locations, "locations", 20, "number of locations")
+	flags.IntVar(&options.DestinationsPerLocation, "destinations", 5, "destinations per location")
+	flags.IntVar(&options.UsersPerRole, "users", 2, "users per role")
+	flags.Int64Var(&options.Seed, "seed", services.DefaultSeed, "random seed; the same seed produces the same data")
+	flags.IntVar(&options.BatchSize, "batch", services.DefaultSeedBatchSize, "rows per insert")
+	flags.StringVar(&options.Password, "password", services.DefaultSeedUserPassword, "password of every seeded user")
+	flags.BoolVar(&wipeFirst, "wipe", false, "delete existing users, locations, destinations and trips first")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if wipeFirst {
+		if err := wipe(db); err != nil {
+			return err
+		}
+		fmt.Fprintln(out, "wiped existing data")
+	}
+
+	seeder := services.Seeder{UnitOfWork: dataaccess.NewGormUnitOfWork(db)}
+	result, err := seeder.Seed(options)
+	fmt.Fprintf(out, "seeded %d users, %d locations and %d destinations\n", result.Users, result.Locations, result.Destinations)
+	if err != nil {
+		return err
+	}
+	if result.Users > 0 {
+		fmt.Fprintf(out, "users are admin1, manager1, user1, ... with password %q\n", options.Password)
+	}
+	return nil
+}
+
+func wipe(db *gorm.DB) error {
+	if db.Dialector.Name() == "postgres" {
+		return db.Exec("TRUNCATE " + strings.Join(seededTables, ", ") + " RESTART IDENTITY CASCADE").Error
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, table := range seededTables {
+			if err := tx.Exec("DELETE FROM " + table).Error; err != nil {
+				return err
+			}
+		}
+		// Restart the ids like TRUNCATE does; sqlite_sequence only exists once a table used AUTOINCREMENT.
+		var sequences int64
+		tx.Raw("SELECT count(*) FROM sqlite_master WHERE name = 'sqlite_sequence'").Scan(&sequences)
+		if sequences > 0 {
+			return tx.Exec("DELETE FROM sqlite_sequence WHERE name IN ?", seededTables).Error
+		}
+		return nil
+	})
+}
@@ -1,19 +1,29 @@
 package database
 
 import (
+	"Trip-Trove-API/domain/entities"
 	"fmt"
+	"github.com/glebarez/sqlite"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"log"
 	"os"
 )
 
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+	DriverMemory   = "memory"
+)
+
 type DbConfig struct {
+	Driver   string
 	Host     string
 	User     string
 	Password string
 	Port     string
 	Name     string
+	Path     string
 }
 
 func loadEnvDb() DbConfig {
@@ -24,25 +34,111 @@ func loadEnvDb() DbConfig {
 	dbHost := os.Getenv("DB_HOST")
 	fmt.Printf("Parsing %s\t%s\t%s\t%s\t%s\n", dbUser, dbPassword, dbName, dbPort, dbHost)
 	return DbConfig{
+		Driver:   Driver(),
 		Host:     dbHost,
 		User:     dbUser,
 		Password: dbPassword,
 		Name:     dbName,
 		Port:     dbPort,
+		Path:     envOrDefault("DB_PATH", "trip-trove.db"),
+	}
+}
+
+func (c DbConfig) postgresDSN() string {
+	return fmt.Sprintf(
+		"host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=Europe/Bucharest",
+		c.Host, c.User, c.Password, c.Name, c.Port,
+	)
+}
+
+// PostgresDSN is the connection string ConnectDB uses, for clients that need their own
+// connection such as LISTEN.
+func PostgresDSN() string {
+	return loadEnvDb().postgresDSN()
+}
+
+// Driver selects the storage backend through DB_DRIVER: postgres (the default), sqlite
+// for a single local file, or memory for a throwaway in-process store.
+func Driver() string {
+	return envOrDefault("DB_DRIVER", DriverPostgres)
+}
+
+func envOrDefault(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
 	}
+	return fallback
 }
 
 func ConnectDB() *gorm.DB {
 	dbConfig := loadEnvDb()
 
-	dsn := fmt.Sprintf(
-		"host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=Europe/Bucharest",
-		dbConfig.Host, dbConfig.User, dbConfig.Password, dbConfig.Name, dbConfig.Port,
-	)
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	var db *gorm.DB
+	var err error
+	switch dbConfig.Driver {
+	case DriverPostgres:
+		db, err = OpenPostgres(dbConfig.postgresDSN())
+	case DriverSQLite:
+		db, err = OpenSQLite(dbConfig.Path)
+	default:
+		err = fmt.Errorf("DB_DRIVER %q has no database connection", dbConfig.Driver)
+	}
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %s", err.Error())
 	}
 
 	return db
 }
+
+func OpenPostgres(dsn string) (*gorm.DB, error) {
+	return gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
+}
+
+func OpenSQLite(path string) (*gorm.DB, error) {
+	db, err := gorm.Open(sqlite.Open(path+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"), &gorm.Config{TranslateError: true})
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite allows a single writer; one connection avoids "database is locked" errors.
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	sqlDB.SetMaxOpenConns(1)
+
+	return db, nil
+}
+
+func EnableSearchExtensions(db *gorm.DB) error {
+	if db.Dialector.Name() != DriverPostgres {
+		return nil
+	}
+	return db.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error
+}
+
+// AutoMigrate is a development shortcut; deployed databases are managed by the migrations package.
+func AutoMigrate(db *gorm.DB) error {
+	entitiesToMigrate := []interface{}{
+		&entities.Destination{},
+		&entities.Location{},
+		&entities.User{},
+		&entities.RoleChange{},
+		&entities.RefreshToken{},
+		&entities.RevokedToken{},
+		&entities.UserToken{},
+		&entities.Trip{},
+		&entities.TripStop{},
+		&entities.EventRecord{},
+		&entities.Webhook{},
+		&entities.WebhookDelivery{},
+	}
+
+	for _, entity := range entitiesToMigrate {
+		if err := db.AutoMigrate(entity); err != nil {
+			return err
+		}
+	}
+
+	return EnableSearchExtensions(db)
+}
@@ -0,0 +1,48 @@
+-- The schema AutoMigrate created before migrations existed, column names included, so that
+-- databases created either way continue from here with the same migrations.
+CREATE TABLE IF NOT EXISTS locations (
+    id          bigserial PRIMARY KEY,
+    created_at  timestamptz,
+    updated_at  timestamptz,
+    deleted_at  timestamptz,
+    name        text NOT NULL,
+    country     text NOT NULL,
+    description text,
+    CONSTRAINT uni_locations_name UNIQUE (name)
+);
+CREATE INDEX IF NOT EXISTS idx_locations_deleted_at ON locations (deleted_at);
+
+CREATE TABLE IF NOT EXISTS destinations (
+    id                 bigserial PRIMARY KEY,
+    created_at         timestamptz,
+    updated_at         timestamptz,
+    deleted_at         timestamptz,
+    name               text NOT NULL,
+    location_id        bigint NOT NULL,
+    image_url          text,
+    description        text,
+    visitors_last_year bigint,
+    is_private         boolean NOT NULL,
+    CONSTRAINT uni_destinations_name UNIQUE (name)
+);
+CREATE INDEX IF NOT EXISTS idx_destinations_deleted_at ON destinations (deleted_at);
+
+CREATE TABLE IF NOT EXISTS users (
+    id            bigserial PRIMARY KEY,
+    created_at    timestamptz,
+    updated_at    timestamptz,
+    deleted_at    timestamptz,
+    username      text NOT NULL,
+    password      text NOT NULL,
+    email         text NOT NULL,
+    first_name    text NOT NULL,
+    last_name     text NOT NULL,
+    phone_number  text NOT NULL,
+    date_of_birth text,
+    address       text,
+    "access_type,type:tinyint" smallint NOT NULL,
+    CONSTRAINT uni_users_username UNIQUE (username),
+    CONSTRAINT uni_users_email UNIQUE (email),
+    CONSTRAINT uni_users_phone_number UNIQUE (phone_number)
+);
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
@@ -0,0 +1 @@
+ALTER TABLE users RENAME COLUMN access_type TO "access_type,type:tinyint";
@@ -0,0 +1,12 @@
+-- Databases created by AutoMigrate got a column literally named "access_type,type:tinyint"
+-- because of a malformed struct tag on entities.User.Role.
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_schema = current_schema() AND table_name = 'users'
+          AND column_name = 'access_type,type:tinyint'
+    ) THEN
+        ALTER TABLE users RENAME COLUMN "access_type,type:tinyint" TO access_type;
+    END IF;
+END $$;
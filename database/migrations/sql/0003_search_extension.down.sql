@@ -0,0 +1,2 @@
+-- pg_trgm is installed for the whole database and may be used outside this schema, so it stays.
+SELECT 1;
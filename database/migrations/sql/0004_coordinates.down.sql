@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_destinations_coordinates;
+ALTER TABLE destinations DROP COLUMN IF EXISTS longitude;
+ALTER TABLE destinations DROP COLUMN IF EXISTS latitude;
+
+ALTER TABLE locations DROP COLUMN IF EXISTS longitude;
+ALTER TABLE locations DROP COLUMN IF EXISTS latitude;
@@ -0,0 +1,6 @@
+ALTER TABLE locations ADD COLUMN IF NOT EXISTS latitude decimal;
+ALTER TABLE locations ADD COLUMN IF NOT EXISTS longitude decimal;
+
+ALTER TABLE destinations ADD COLUMN IF NOT EXISTS latitude decimal;
+ALTER TABLE destinations ADD COLUMN IF NOT EXISTS longitude decimal;
+CREATE INDEX IF NOT EXISTS idx_destinations_coordinates ON destinations (latitude, longitude);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_destinations_owner_id;
+ALTER TABLE destinations DROP COLUMN IF EXISTS owner_id;
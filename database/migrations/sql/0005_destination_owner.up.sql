@@ -0,0 +1,2 @@
+ALTER TABLE destinations ADD COLUMN IF NOT EXISTS owner_id bigint;
+CREATE INDEX IF NOT EXISTS idx_destinations_owner_id ON destinations (owner_id);
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS trips (
+    id         bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    owner_id   bigint NOT NULL,
+    name       text NOT NULL,
+    start_date varchar(10) NOT NULL,
+    end_date   varchar(10) NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_trips_deleted_at ON trips (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_trips_owner_id ON trips (owner_id);
+
+CREATE TABLE IF NOT EXISTS trip_stops (
+    id             bigserial PRIMARY KEY,
+    created_at     timestamptz,
+    updated_at     timestamptz,
+    deleted_at     timestamptz,
+    trip_id        bigint NOT NULL,
+    destination_id bigint NOT NULL,
+    day            bigint NOT NULL,
+    position       bigint NOT NULL,
+    notes          text,
+    CONSTRAINT fk_trips_stops FOREIGN KEY (trip_id) REFERENCES trips (id)
+);
+CREATE INDEX IF NOT EXISTS idx_trip_stops_deleted_at ON trip_stops (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_trip_stops_trip_id ON trip_stops (trip_id);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS event_log (
+    sequence            bigserial PRIMARY KEY,
+    type                text NOT NULL,
+    entity              text NOT NULL,
+    entity_id           bigint NOT NULL,
+    payload             text NOT NULL,
+    actor_user_id       bigint NOT NULL,
+    actor_role          smallint NOT NULL,
+    actor_authenticated boolean NOT NULL,
+    occurred_at         timestamptz NOT NULL
+);
@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS webhooks (
+    id          bigserial PRIMARY KEY,
+    created_at  timestamptz,
+    updated_at  timestamptz,
+    deleted_at  timestamptz,
+    url         text NOT NULL,
+    secret      text NOT NULL,
+    event_types text NOT NULL,
+    active      boolean NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_webhooks_deleted_at ON webhooks (deleted_at);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id              bigserial PRIMARY KEY,
+    created_at      timestamptz,
+    updated_at      timestamptz,
+    webhook_id      bigint NOT NULL,
+    event_sequence  bigint NOT NULL,
+    event_type      text NOT NULL,
+    payload         text NOT NULL,
+    status          text NOT NULL,
+    attempts        bigint NOT NULL,
+    response_status bigint NOT NULL,
+    last_error      text NOT NULL,
+    next_attempt_at timestamptz NOT NULL,
+    delivered_at    timestamptz,
+    replay_of       bigint
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook_id ON webhook_deliveries (webhook_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_status ON webhook_deliveries (status);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_next_attempt_at ON webhook_deliveries (next_attempt_at);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS role_changes (
+    id            bigserial PRIMARY KEY,
+    user_id       bigint NOT NULL,
+    changed_by_id bigint,
+    old_role      smallint NOT NULL,
+    new_role      smallint NOT NULL,
+    reason        text NOT NULL,
+    created_at    timestamptz
+);
+CREATE INDEX IF NOT EXISTS idx_role_changes_user_id ON role_changes (user_id);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_locations_name_country_trgm;
+DROP INDEX IF EXISTS idx_locations_search;
+
+DROP INDEX IF EXISTS idx_destinations_location_id;
+DROP INDEX IF EXISTS idx_destinations_name_trgm;
+DROP INDEX IF EXISTS idx_destinations_search;
@@ -0,0 +1,14 @@
+-- GIN indexes for full-text and trigram search. The expressions must stay the same as the
+-- ones in infrastructure/dataaccess/search.go, or the planner cannot use them.
+CREATE INDEX IF NOT EXISTS idx_destinations_search ON destinations USING gin ((
+    setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(description, '')), 'C')
+));
+CREATE INDEX IF NOT EXISTS idx_destinations_name_trgm ON destinations USING gin (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_destinations_location_id ON destinations (location_id);
+
+CREATE INDEX IF NOT EXISTS idx_locations_search ON locations USING gin ((
+    setweight(to_tsvector('simple', coalesce(name, '') || ' ' || coalesce(country, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(description, '')), 'C')
+));
+CREATE INDEX IF NOT EXISTS idx_locations_name_country_trgm ON locations USING gin ((name || ' ' || country) gin_trgm_ops);
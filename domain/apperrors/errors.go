@@ -0,0 +1,107 @@
+package apperrors
+
+import (
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+)
+
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindNotFound
+	KindConflict
+	KindValidation
+	KindForbidden
+	KindUnauthorized
+)
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+}
+
+// Error is the error type repositories and services return when the caller, not the
+// server, is at fault. Anything else is treated as an internal error.
+type Error struct {
+	Kind    Kind
+	Message string
+	Fields  []FieldError
+	Err     error
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+var ErrInvalidID = Validation("invalid ID format")
+
+func NotFound(message string) *Error {
+	return &Error{Kind: KindNotFound, Message: message}
+}
+
+func Conflict(message string) *Error {
+	return &Error{Kind: KindConflict, Message: message}
+}
+
+func Validation(message string, fields ...FieldError) *Error {
+	return &Error{Kind: KindValidation, Message: message, Fields: fields}
+}
+
+func Forbidden(message string) *Error {
+	return &Error{Kind: KindForbidden, Message: message}
+}
+
+func Unauthorized(message string) *Error {
+	return &Error{Kind: KindUnauthorized, Message: message}
+}
+
+// KindOf reports the kind of the first *Error in err's chain, or KindInternal if there is none.
+func KindOf(err error) Kind {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Kind
+	}
+	return KindInternal
+}
+
+// FieldsOf returns the field-level details of the first *Error in err's chain.
+func FieldsOf(err error) []FieldError {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Fields
+	}
+	return nil
+}
+
+// FromValidation turns the result of validator.Struct into a validation error with one
+// entry per failed field. Errors that are not validation failures are returned unchanged.
+func FromValidation(err error) error {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return err
+	}
+
+	fields := make([]FieldError, 0, len(validationErrors))
+	for _, fieldErr := range validationErrors {
+		message := fmt.Sprintf("failed on the '%s' rule", fieldErr.ActualTag())
+		if fieldErr.Param() != "" {
+			message = fmt.Sprintf("failed on the '%s' rule (parameter: %s)", fieldErr.ActualTag(), fieldErr.Param())
+		}
+		fields = append(fields, FieldError{
+			Field:   fieldErr.Field(),
+			Rule:    fieldErr.ActualTag(),
+			Param:   fieldErr.Param(),
+			Message: message,
+		})
+	}
+
+	return &Error{Kind: KindValidation, Message: "request validation failed", Fields: fields, Err: err}
+}
@@ -0,0 +1,32 @@
+package entities
+
+type Actor struct {
+	UserID        uint
+	Role          AccessType
+	Authenticated bool
+	EmailVerified bool
+}
+
+var Anonymous = Actor{}
+
+// Can checks permission against the active policy, the same one RequirePermission asks.
+func (a Actor) Can(permission Permission) bool {
+	return a.Authenticated && ActivePolicy().Allows(a.Role, permission)
+}
+
+func (a Actor) Owns(ownerID *uint) bool {
+	return a.Authenticated && ownerID != nil && *ownerID == a.UserID
+}
+
+// DestinationVisibility returns the filter repositories apply so that private
+// destinations are only returned to their owner and to those allowed to read them all.
+func (a Actor) DestinationVisibility() DestinationVisibility {
+	if a.Can(DestinationsReadPrivate) {
+		return DestinationVisibility{IncludePrivate: true}
+	}
+	if a.Authenticated {
+		ownerID := a.UserID
+		return DestinationVisibility{OwnerID: &ownerID}
+	}
+	return DestinationVisibility{}
+}
@@ -4,10 +4,24 @@ import "gorm.io/gorm"
 
 type Destination struct {
 	gorm.Model
-	Name             string `gorm:"column:name;not null;unique" json:"name" validate:"required,min=3,max=50"`
-	LocationID       uint   `gorm:"column:location_id;not null" json:"location_id" validate:"required,number"`
-	ImageUrl         string `gorm:"column:image_url" json:"image_url" validate:"max=100"`
-	Description      string `gorm:"column:description" json:"description" validate:"min=10,max=256"`
-	VisitorsLastYear int    `gorm:"column:visitors_last_year" json:"visitors_last_year" validate:"gte=0"`
-	IsPrivate        bool   `gorm:"column:is_private;not null" json:"is_private"`
+	Name             string   `gorm:"column:name;not null;unique" json:"name" validate:"required,min=3,max=50"`
+	LocationID       uint     `gorm:"column:location_id;not null" json:"location_id" validate:"required,number"`
+	ImageUrl         string   `gorm:"column:image_url" json:"image_url" validate:"max=100"`
+	Description      string   `gorm:"column:description" json:"description" validate:"min=10,max=256"`
+	VisitorsLastYear int      `gorm:"column:visitors_last_year" json:"visitors_last_year" validate:"gte=0"`
+	IsPrivate        bool     `gorm:"column:is_private;not null" json:"is_private"`
+	Latitude         *float64 `gorm:"column:latitude;index:idx_destinations_coordinates" json:"latitude" validate:"required_with=Longitude,omitempty,latitude"`
+	Longitude        *float64 `gorm:"column:longitude;index:idx_destinations_coordinates" json:"longitude" validate:"required_with=Latitude,omitempty,longitude"`
+	OwnerID          *uint    `gorm:"column:owner_id;index" json:"owner_id"`
+}
+
+func (d Destination) VisibleTo(actor Actor) bool {
+	return !d.IsPrivate || actor.Can(DestinationsReadPrivate) || actor.Owns(d.OwnerID)
+}
+
+func (d Destination) EditableBy(actor Actor) bool {
+	if actor.Can(DestinationsManageAny) {
+		return true
+	}
+	return actor.Can(DestinationsWrite) && actor.Owns(d.OwnerID)
 }
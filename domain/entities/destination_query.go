@@ -0,0 +1,34 @@
+package entities
+
+type DestinationSortField string
+
+const (
+	SortByName             DestinationSortField = "name"
+	SortByVisitorsLastYear DestinationSortField = "visitors_last_year"
+	SortByCreatedAt        DestinationSortField = "created_at"
+)
+
+type DestinationVisibility struct {
+	IncludePrivate bool
+	OwnerID        *uint
+}
+
+type DestinationFilter struct {
+	LocationID  *uint
+	Country     string
+	MinVisitors *int
+	MaxVisitors *int
+	Visibility  DestinationVisibility
+}
+
+type DestinationQuery struct {
+	Page     int
+	Limit    int
+	SortBy   DestinationSortField
+	SortDesc bool
+	Filter   DestinationFilter
+}
+
+func (q DestinationQuery) Offset() int {
+	return (q.Page - 1) * q.Limit
+}
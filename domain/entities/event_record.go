@@ -0,0 +1,21 @@
+package entities
+
+import "time"
+
+// EventRecord is a published domain event as kept in the bounded event log. Sequence is
+// assigned by the log and only ever grows.
+type EventRecord struct {
+	Sequence           uint64     `gorm:"column:sequence;primaryKey;autoIncrement"`
+	Type               string     `gorm:"column:type;not null"`
+	Entity             string     `gorm:"column:entity;not null"`
+	EntityID           uint       `gorm:"column:entity_id;not null"`
+	Payload            string     `gorm:"column:payload;type:text;not null"`
+	ActorUserID        uint       `gorm:"column:actor_user_id;not null"`
+	ActorRole          AccessType `gorm:"column:actor_role;type:smallint;not null"`
+	ActorAuthenticated bool       `gorm:"column:actor_authenticated;not null"`
+	OccurredAt         time.Time  `gorm:"column:occurred_at;not null"`
+}
+
+func (EventRecord) TableName() string {
+	return "event_log"
+}
@@ -0,0 +1,24 @@
+package entities
+
+import "math"
+
+const EarthRadiusKm = 6371.0
+
+type DestinationWithDistance struct {
+	Destination
+	DistanceKm float64 `json:"distance_km"`
+}
+
+// HaversineKm is the great-circle distance between two points, matching the formula the
+// Postgres repository evaluates in SQL.
+func HaversineKm(latitude1 float64, longitude1 float64, latitude2 float64, longitude2 float64) float64 {
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+
+	deltaLatitude := toRadians(latitude2 - latitude1)
+	deltaLongitude := toRadians(longitude2 - longitude1)
+
+	a := math.Pow(math.Sin(deltaLatitude/2), 2) +
+		math.Cos(toRadians(latitude1))*math.Cos(toRadians(latitude2))*math.Pow(math.Sin(deltaLongitude/2), 2)
+
+	return 2 * EarthRadiusKm * math.Asin(math.Sqrt(a))
+}
@@ -4,7 +4,9 @@ import "gorm.io/gorm"
 
 type Location struct {
 	gorm.Model
-	Name        string `gorm:"column:name;not null;unique" json:"name" validate:"required,min=3,max=30"`
-	Country     string `gorm:"column:country;not null" json:"country" validate:"required"`
-	Description string `gorm:"column:description" json:"description" validate:"max=256"`
+	Name        string   `gorm:"column:name;not null;unique" json:"name" validate:"required,min=3,max=30"`
+	Country     string   `gorm:"column:country;not null" json:"country" validate:"required"`
+	Description string   `gorm:"column:description" json:"description" validate:"max=256"`
+	Latitude    *float64 `gorm:"column:latitude" json:"latitude" validate:"required_with=Longitude,omitempty,latitude"`
+	Longitude   *float64 `gorm:"column:longitude" json:"longitude" validate:"required_with=Latitude,omitempty,longitude"`
 }
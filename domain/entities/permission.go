@@ -0,0 +1,158 @@
+package entities
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync/atomic"
+)
+
+// Permission names one capability. Roles are granted sets of them through a Policy.
+type Permission string
+
+const (
+	DestinationsWrite       Permission = "destinations:write"
+	DestinationsDelete      Permission = "destinations:delete"
+	DestinationsImport      Permission = "destinations:import"
+	DestinationsReadPrivate Permission = "destinations:read_private"
+	// DestinationsManageAny lifts the rule that only the owner may edit or delete a destination.
+	DestinationsManageAny Permission = "destinations:manage_any"
+
+	LocationsWrite  Permission = "locations:write"
+	LocationsDelete Permission = "locations:delete"
+
+	TripsWrite Permission = "trips:write"
+	// TripsManageAny gives access to the trips of every user, not just one's own.
+	TripsManageAny Permission = "trips:manage_any"
+
+	EventsRead Permission = "events:read"
+
+	UsersRead  Permission = "users:read"
+	UsersWrite Permission = "users:write"
+	UsersRoles Permission = "users:roles"
+
+	WebhooksManage Permission = "webhooks:manage"
+	GeneratorRun   Permission = "generator:run"
+	MetricsRead    Permission = "metrics:read"
+)
+
+var AllPermissions = []Permission{
+	DestinationsWrite, DestinationsDelete, DestinationsImport, DestinationsReadPrivate, DestinationsManageAny,
+	LocationsWrite, LocationsDelete,
+	TripsWrite, TripsManageAny,
+	EventsRead,
+	UsersRead, UsersWrite, UsersRoles,
+	WebhooksManage, GeneratorRun, MetricsRead,
+}
+
+// Policy maps each role to the permissions it grants. Tokens only carry the role, so a
+// changed policy applies to tokens that were issued before the change.
+type Policy struct {
+	roles map[AccessType]map[Permission]bool
+}
+
+func NewPolicy(grants map[AccessType][]Permission) Policy {
+	policy := Policy{roles: make(map[AccessType]map[Permission]bool, len(grants))}
+	for role, permissions := range grants {
+		policy.roles[role] = make(map[Permission]bool, len(permissions))
+		for _, permission := range permissions {
+			policy.roles[role][permission] = true
+		}
+	}
+	return policy
+}
+
+// DefaultPolicy grants what the ordered roles used to: managers get everything users get
+// plus the catalogue, and admins get every permission.
+func DefaultPolicy() Policy {
+	user := []Permission{TripsWrite, EventsRead}
+	manager := append(append([]Permission{}, user...),
+		DestinationsWrite, DestinationsDelete, DestinationsImport,
+		LocationsWrite, LocationsDelete,
+	)
+	return NewPolicy(map[AccessType][]Permission{
+		NormalUser: user,
+		Manager:    manager,
+		Admin:      AllPermissions,
+	})
+}
+
+func (p Policy) Allows(role AccessType, permission Permission) bool {
+	return p.roles[role][permission]
+}
+
+// Includes reports whether role is granted every permission of other.
+func (p Policy) Includes(role AccessType, other AccessType) bool {
+	for permission := range p.roles[other] {
+		if !p.roles[role][permission] {
+			return false
+		}
+	}
+	return true
+}
+
+// Permissions returns the permissions of role, sorted by name.
+func (p Policy) Permissions(role AccessType) []Permission {
+	permissions := make([]Permission, 0, len(p.roles[role]))
+	for permission := range p.roles[role] {
+		permissions = append(permissions, permission)
+	}
+	sort.Slice(permissions, func(i, j int) bool {
+		return permissions[i] < permissions[j]
+	})
+	return permissions
+}
+
+// LoadPolicy reads a JSON object from role names to permission lists, for example
+// {"manager": ["destinations:write", "locations:write"]}. Roles it leaves out keep their
+// default permissions. Admins must keep users:roles, or nobody could grant roles any more.
+func LoadPolicy(r io.Reader) (Policy, error) {
+	var config map[string][]Permission
+	decoder := json.NewDecoder(r)
+	if err := decoder.Decode(&config); err != nil {
+		return Policy{}, fmt.Errorf("role permissions: %w", err)
+	}
+
+	known := make(map[Permission]bool, len(AllPermissions))
+	for _, permission := range AllPermissions {
+		known[permission] = true
+	}
+
+	policy := DefaultPolicy()
+	for name, permissions := range config {
+		role, ok := ParseAccessType(name)
+		if !ok {
+			return Policy{}, fmt.Errorf("role permissions: unknown role %q", name)
+		}
+		granted := make(map[Permission]bool, len(permissions))
+		for _, permission := range permissions {
+			if !known[permission] {
+				return Policy{}, fmt.Errorf("role permissions: unknown permission %q for %s", permission, name)
+			}
+			granted[permission] = true
+		}
+		policy.roles[role] = granted
+	}
+
+	if !policy.Allows(Admin, UsersRoles) {
+		return Policy{}, fmt.Errorf("role permissions: admin must keep %s", UsersRoles)
+	}
+	return policy, nil
+}
+
+var activePolicy atomic.Pointer[Policy]
+
+func init() {
+	SetPolicy(DefaultPolicy())
+}
+
+// SetPolicy replaces the policy every permission check uses. It is meant to be called once
+// at startup, before requests are served.
+func SetPolicy(policy Policy) {
+	activePolicy.Store(&policy)
+}
+
+func ActivePolicy() Policy {
+	return *activePolicy.Load()
+}
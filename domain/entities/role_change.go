@@ -0,0 +1,15 @@
+package entities
+
+import "time"
+
+// RoleChange is the audit record of a change of role. ChangedByID is nil for changes made
+// outside the API, such as bootstrapping the first Admin.
+type RoleChange struct {
+	ID          uint       `gorm:"primaryKey"`
+	UserID      uint       `gorm:"column:user_id;not null;index"`
+	ChangedByID *uint      `gorm:"column:changed_by_id"`
+	OldRole     AccessType `gorm:"column:old_role;type:smallint;not null"`
+	NewRole     AccessType `gorm:"column:new_role;type:smallint;not null"`
+	Reason      string     `gorm:"column:reason;not null"`
+	CreatedAt   time.Time
+}
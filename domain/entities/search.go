@@ -0,0 +1,15 @@
+package entities
+
+type DestinationSearchResult struct {
+	Destination Destination `json:"destination"`
+	Location    string      `json:"location"`
+	Country     string      `json:"country"`
+	Rank        float64     `json:"rank"`
+	Snippet     string      `json:"snippet"`
+}
+
+type LocationSearchResult struct {
+	Location Location `json:"location"`
+	Rank     float64  `json:"rank"`
+	Snippet  string   `json:"snippet"`
+}
@@ -0,0 +1,59 @@
+package entities
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+type RefreshToken struct {
+	gorm.Model
+	UserID          uint       `gorm:"column:user_id;not null;index"`
+	TokenHash       string     `gorm:"column:token_hash;not null;uniqueIndex"`
+	FamilyID        string     `gorm:"column:family_id;not null;index"`
+	AccessTokenID   string     `gorm:"column:access_token_id;not null"`
+	AccessExpiresAt time.Time  `gorm:"column:access_expires_at;not null"`
+	ExpiresAt       time.Time  `gorm:"column:expires_at;not null"`
+	RevokedAt       *time.Time `gorm:"column:revoked_at"`
+}
+
+func (t RefreshToken) IsActive(now time.Time) bool {
+	return t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
+
+type RevokedToken struct {
+	TokenID   string    `gorm:"column:token_id;primaryKey"`
+	UserID    uint      `gorm:"column:user_id;not null;index"`
+	ExpiresAt time.Time `gorm:"column:expires_at;not null;index"`
+	CreatedAt time.Time
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type UserTokenPurpose string
+
+const (
+	PasswordResetToken     UserTokenPurpose = "password_reset"
+	EmailVerificationToken UserTokenPurpose = "email_verification"
+)
+
+// UserToken is a single-use token mailed to a user. Only its hash is stored; Email records
+// the address a verification token was sent to, so it cannot verify a later one.
+type UserToken struct {
+	gorm.Model
+	UserID    uint             `gorm:"column:user_id;not null;index"`
+	Purpose   UserTokenPurpose `gorm:"column:purpose;not null"`
+	TokenHash string           `gorm:"column:token_hash;not null;uniqueIndex"`
+	Email     string           `gorm:"column:email;not null"`
+	ExpiresAt time.Time        `gorm:"column:expires_at;not null"`
+	UsedAt    *time.Time       `gorm:"column:used_at"`
+}
+
+func (t UserToken) IsUsable(purpose UserTokenPurpose, now time.Time) bool {
+	return t.Purpose == purpose && t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
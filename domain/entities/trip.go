@@ -0,0 +1,32 @@
+package entities
+
+import "gorm.io/gorm"
+
+const DateLayout = "2006-01-02"
+
+type Trip struct {
+	gorm.Model
+	OwnerID   uint       `gorm:"column:owner_id;not null;index" json:"owner_id"`
+	Name      string     `gorm:"column:name;not null" json:"name" validate:"required,min=3,max=100"`
+	StartDate string     `gorm:"column:start_date;type:varchar(10);not null" json:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate   string     `gorm:"column:end_date;type:varchar(10);not null" json:"end_date" validate:"required,datetime=2006-01-02"`
+	Stops     []TripStop `gorm:"foreignKey:TripID" json:"stops" validate:"dive"`
+}
+
+type TripStop struct {
+	gorm.Model
+	TripID        uint   `gorm:"column:trip_id;not null;index" json:"trip_id"`
+	DestinationID uint   `gorm:"column:destination_id;not null" json:"destination_id" validate:"required"`
+	Day           int    `gorm:"column:day;not null" json:"day" validate:"required,gte=1"`
+	Position      int    `gorm:"column:position;not null" json:"position"`
+	Notes         string `gorm:"column:notes" json:"notes" validate:"max=256"`
+}
+
+type TripStopOrder struct {
+	StopID uint `json:"stop_id" validate:"required"`
+	Day    int  `json:"day" validate:"required,gte=1"`
+}
+
+type ReorderStopsRequest struct {
+	Stops []TripStopOrder `json:"stops" validate:"required,dive"`
+}
@@ -1,18 +1,28 @@
 package entities
 
-import "gorm.io/gorm"
+import (
+	"gorm.io/gorm"
+	"time"
+)
 
+// User is never serialized as is: the API maps it through presentation/dto, and the password
+// hash has no JSON name at all.
 type User struct {
 	gorm.Model
-	Username    string     `gorm:"column:username;unique;not null" json:"username" binding:"required" validate:"required,usernameValidator"`
-	Password    string     `gorm:"column:password;not null" json:"password" binding:"required" validate:"required"`
-	Email       string     `gorm:"column:email;unique;not null" json:"email" binding:"required" validate:"required,email"`
-	FirstName   string     `gorm:"column:first_name;not null" json:"first_name" binding:"required" validate:"required,nameValidator"`
-	LastName    string     `gorm:"column:last_name;not null" json:"last_name" binding:"required" validate:"required,nameValidator"`
-	PhoneNumber string     `gorm:"column:phone_number;unique;not null" json:"phone_number" binding:"required" validate:"required,e164"`
-	DateOfBirth string     `gorm:"column:date_of_birth" json:"date_of_birth" binding:"required" validate:"max=20"`
-	Address     string     `gorm:"column:address" json:"address" binding:"required" validate:"max=100"`
-	Role        AccessType `gorm:"column:access_type,type:tinyint;not null"`
+	Username        string     `gorm:"column:username;unique;not null" json:"username"`
+	Password        string     `gorm:"column:password;not null" json:"-"`
+	Email           string     `gorm:"column:email;unique;not null" json:"email"`
+	FirstName       string     `gorm:"column:first_name;not null" json:"first_name"`
+	LastName        string     `gorm:"column:last_name;not null" json:"last_name"`
+	PhoneNumber     string     `gorm:"column:phone_number;unique;not null" json:"phone_number"`
+	DateOfBirth     string     `gorm:"column:date_of_birth" json:"date_of_birth"`
+	Address         string     `gorm:"column:address" json:"address"`
+	Role            AccessType `gorm:"column:access_type;type:smallint;not null" json:"role"`
+	EmailVerifiedAt *time.Time `gorm:"column:email_verified_at" json:"-"`
+}
+
+func (u User) EmailVerified() bool {
+	return u.EmailVerifiedAt != nil
 }
 
 type LoginRequest struct {
@@ -21,9 +31,11 @@ type LoginRequest struct {
 }
 
 type LoginResponse struct {
-	Email string `json:"email"`
-	Jwt   string `json:"jwt"`
-	ID    uint   `json:"id"`
+	Email        string `json:"email"`
+	Jwt          string `json:"jwt"`
+	ExpiresAt    int64  `json:"expires_at"`
+	RefreshToken string `json:"refresh_token"`
+	ID           uint   `json:"id"`
 }
 
 type AccessType uint8
@@ -33,3 +45,25 @@ const (
 	Manager
 	Admin
 )
+
+func ParseAccessType(name string) (AccessType, bool) {
+	for _, role := range []AccessType{NormalUser, Manager, Admin} {
+		if role.String() == name {
+			return role, true
+		}
+	}
+	return 0, false
+}
+
+func (a AccessType) String() string {
+	switch a {
+	case NormalUser:
+		return "user"
+	case Manager:
+		return "manager"
+	case Admin:
+		return "admin"
+	default:
+		return "unknown"
+	}
+}
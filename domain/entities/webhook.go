@@ -0,0 +1,98 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+// Webhook is an endpoint registered by an Admin to receive the event types it lists.
+// The secret signs every delivery and is never returned by the API.
+type Webhook struct {
+	gorm.Model
+	URL        string            `gorm:"column:url;not null" json:"url"`
+	Secret     string            `gorm:"column:secret;not null" json:"-"`
+	EventTypes WebhookEventTypes `gorm:"column:event_types;type:text;not null" json:"event_types"`
+	Active     bool              `gorm:"column:active;not null" json:"active"`
+}
+
+func (w Webhook) Subscribes(eventType string) bool {
+	for _, subscribed := range w.EventTypes {
+		if subscribed == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookEventTypes is stored as a comma separated list so every backend can hold it.
+type WebhookEventTypes []string
+
+func (t WebhookEventTypes) Value() (driver.Value, error) {
+	return strings.Join(t, ","), nil
+}
+
+func (t *WebhookEventTypes) Scan(value interface{}) error {
+	var joined string
+	switch v := value.(type) {
+	case string:
+		joined = v
+	case []byte:
+		joined = string(v)
+	case nil:
+	default:
+		return fmt.Errorf("cannot scan %T into WebhookEventTypes", value)
+	}
+
+	*t = WebhookEventTypes{}
+	if joined != "" {
+		*t = strings.Split(joined, ",")
+	}
+	return nil
+}
+
+type WebhookRequest struct {
+	URL        string   `json:"url" validate:"required,url,max=2048"`
+	Secret     string   `json:"secret" validate:"omitempty,min=16,max=256"`
+	EventTypes []string `json:"event_types" validate:"required,min=1,dive,required"`
+	Active     *bool    `json:"active"`
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	DeliveryPending   WebhookDeliveryStatus = "pending"
+	DeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	DeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is one event sent, or still to be sent, to one webhook. Payload is the
+// exact body that was signed. A replay is a new delivery pointing at the original.
+type WebhookDelivery struct {
+	ID             uint                  `gorm:"primaryKey" json:"id"`
+	WebhookID      uint                  `gorm:"column:webhook_id;not null;index" json:"webhook_id"`
+	EventSequence  uint64                `gorm:"column:event_sequence;not null" json:"event_sequence"`
+	EventType      string                `gorm:"column:event_type;not null" json:"event_type"`
+	Payload        string                `gorm:"column:payload;type:text;not null" json:"payload"`
+	Status         WebhookDeliveryStatus `gorm:"column:status;not null;index" json:"status"`
+	Attempts       int                   `gorm:"column:attempts;not null" json:"attempts"`
+	ResponseStatus int                   `gorm:"column:response_status;not null" json:"response_status"`
+	LastError      string                `gorm:"column:last_error;type:text;not null" json:"last_error"`
+	NextAttemptAt  time.Time             `gorm:"column:next_attempt_at;not null;index" json:"next_attempt_at"`
+	DeliveredAt    *time.Time            `gorm:"column:delivered_at" json:"delivered_at"`
+	ReplayOf       *uint                 `gorm:"column:replay_of" json:"replay_of"`
+	CreatedAt      time.Time             `json:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+}
+
+type WebhookDeliveryQuery struct {
+	Page   int
+	Limit  int
+	Status WebhookDeliveryStatus
+}
+
+func (q WebhookDeliveryQuery) Offset() int {
+	return (q.Page - 1) * q.Limit
+}
@@ -0,0 +1,34 @@
+package events
+
+import "sync"
+
+// Bus fans published events out to the subscribers of every API instance it connects.
+type Bus interface {
+	Publisher
+	Subscribe(subscriber Publisher)
+}
+
+// LocalBus only reaches the subscribers of this process. It is enough for a single instance.
+type LocalBus struct {
+	mu          sync.RWMutex
+	subscribers []Publisher
+}
+
+func NewLocalBus() *LocalBus {
+	return &LocalBus{}
+}
+
+func (b *LocalBus) Subscribe(subscriber Publisher) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers = append(b.subscribers, subscriber)
+}
+
+func (b *LocalBus) Publish(event Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, subscriber := range b.subscribers {
+		subscriber.Publish(event)
+	}
+}
@@ -0,0 +1,99 @@
+package events
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"gorm.io/gorm"
+	"time"
+)
+
+// Envelope is the wire format shared by every transport that delivers events.
+type Envelope struct {
+	Sequence  uint64        `json:"seq,omitempty"`
+	Type      Type          `json:"type"`
+	Entity    EntityKind    `json:"entity"`
+	Data      interface{}   `json:"data"`
+	Actor     EnvelopeActor `json:"actor"`
+	Timestamp time.Time     `json:"timestamp"`
+}
+
+type EnvelopeActor struct {
+	UserID        uint                `json:"user_id,omitempty"`
+	Role          entities.AccessType `json:"role"`
+	Authenticated bool                `json:"authenticated"`
+}
+
+// EnvelopeModel, EnvelopeDestination and EnvelopeLocation carry the same fields, under the
+// same names, as the REST response DTOs, so an entity looks alike on every channel and no
+// internal column goes out with an event.
+type EnvelopeModel struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type EnvelopeDestination struct {
+	EnvelopeModel
+	Name             string   `json:"name"`
+	LocationID       uint     `json:"location_id"`
+	ImageUrl         string   `json:"image_url"`
+	Description      string   `json:"description"`
+	VisitorsLastYear int      `json:"visitors_last_year"`
+	IsPrivate        bool     `json:"is_private"`
+	Latitude         *float64 `json:"latitude"`
+	Longitude        *float64 `json:"longitude"`
+	OwnerID          *uint    `json:"owner_id"`
+}
+
+type EnvelopeLocation struct {
+	EnvelopeModel
+	Name        string   `json:"name"`
+	Country     string   `json:"country"`
+	Description string   `json:"description"`
+	Latitude    *float64 `json:"latitude"`
+	Longitude   *float64 `json:"longitude"`
+}
+
+func newEnvelopeModel(model gorm.Model) EnvelopeModel {
+	return EnvelopeModel{ID: model.ID, CreatedAt: model.CreatedAt, UpdatedAt: model.UpdatedAt}
+}
+
+func (e Event) Envelope() Envelope {
+	var data interface{}
+	switch {
+	case e.Entity == EntityDestination && e.Destination != nil:
+		data = EnvelopeDestination{
+			EnvelopeModel:    newEnvelopeModel(e.Destination.Model),
+			Name:             e.Destination.Name,
+			LocationID:       e.Destination.LocationID,
+			ImageUrl:         e.Destination.ImageUrl,
+			Description:      e.Destination.Description,
+			VisitorsLastYear: e.Destination.VisitorsLastYear,
+			IsPrivate:        e.Destination.IsPrivate,
+			Latitude:         e.Destination.Latitude,
+			Longitude:        e.Destination.Longitude,
+			OwnerID:          e.Destination.OwnerID,
+		}
+	case e.Entity == EntityLocation && e.Location != nil:
+		data = EnvelopeLocation{
+			EnvelopeModel: newEnvelopeModel(e.Location.Model),
+			Name:          e.Location.Name,
+			Country:       e.Location.Country,
+			Description:   e.Location.Description,
+			Latitude:      e.Location.Latitude,
+			Longitude:     e.Location.Longitude,
+		}
+	}
+
+	return Envelope{
+		Sequence: e.Sequence,
+		Type:     e.Type,
+		Entity:   e.Entity,
+		Data:     data,
+		Actor: EnvelopeActor{
+			UserID:        e.Actor.UserID,
+			Role:          e.Actor.Role,
+			Authenticated: e.Actor.Authenticated,
+		},
+		Timestamp: e.OccurredAt,
+	}
+}
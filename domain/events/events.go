@@ -0,0 +1,95 @@
+package events
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"errors"
+	"time"
+)
+
+type Type string
+
+const (
+	DestinationCreated Type = "DestinationCreated"
+	DestinationUpdated Type = "DestinationUpdated"
+	DestinationDeleted Type = "DestinationDeleted"
+	LocationCreated    Type = "LocationCreated"
+	LocationUpdated    Type = "LocationUpdated"
+	LocationDeleted    Type = "LocationDeleted"
+)
+
+var Types = []Type{
+	DestinationCreated, DestinationUpdated, DestinationDeleted,
+	LocationCreated, LocationUpdated, LocationDeleted,
+}
+
+func (t Type) Known() bool {
+	for _, known := range Types {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+type EntityKind string
+
+const (
+	EntityDestination EntityKind = "destination"
+	EntityLocation    EntityKind = "location"
+)
+
+// Event records a committed mutation. Exactly one of Destination and Location is set,
+// matching Entity. Sequence is assigned once the event is written to the event log and
+// stays 0 if that fails.
+type Event struct {
+	Sequence    uint64
+	Type        Type
+	Entity      EntityKind
+	Destination *entities.Destination
+	Location    *entities.Location
+	Actor       entities.Actor
+	OccurredAt  time.Time
+}
+
+// Publisher delivers events to whoever listens for them. Services publish only after
+// the change is committed.
+type Publisher interface {
+	Publish(event Event)
+}
+
+// Replayer returns the logged events that came after sequence, oldest first. It fails with
+// ErrResyncRequired when the log no longer reaches back that far.
+type Replayer interface {
+	EventsSince(sequence uint64) ([]Event, error)
+}
+
+var ErrResyncRequired = errors.New("the event log no longer covers the requested sequence, a full resync is required")
+
+func NewDestinationEvent(eventType Type, destination entities.Destination, actor entities.Actor) Event {
+	return Event{
+		Type:        eventType,
+		Entity:      EntityDestination,
+		Destination: &destination,
+		Actor:       actor,
+		OccurredAt:  time.Now().UTC(),
+	}
+}
+
+func NewLocationEvent(eventType Type, location entities.Location, actor entities.Actor) Event {
+	return Event{
+		Type:       eventType,
+		Entity:     EntityLocation,
+		Location:   &location,
+		Actor:      actor,
+		OccurredAt: time.Now().UTC(),
+	}
+}
+
+// VisibleTo reports whether actor may receive the event. Events about private
+// destinations only reach their owner and Admins.
+func (e Event) VisibleTo(actor entities.Actor) bool {
+	if e.Destination != nil {
+		return e.Destination.VisibleTo(actor)
+	}
+	return true
+}
@@ -0,0 +1,65 @@
+package events
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"encoding/json"
+	"fmt"
+)
+
+// Record converts the event to the form kept in the event log.
+func (e Event) Record() (entities.EventRecord, error) {
+	record := entities.EventRecord{
+		Sequence:           e.Sequence,
+		Type:               string(e.Type),
+		Entity:             string(e.Entity),
+		ActorUserID:        e.Actor.UserID,
+		ActorRole:          e.Actor.Role,
+		ActorAuthenticated: e.Actor.Authenticated,
+		OccurredAt:         e.OccurredAt,
+	}
+
+	var payload interface{}
+	switch e.Entity {
+	case EntityDestination:
+		record.EntityID = e.Destination.ID
+		payload = e.Destination
+	case EntityLocation:
+		record.EntityID = e.Location.ID
+		payload = e.Location
+	default:
+		return entities.EventRecord{}, fmt.Errorf("unknown event entity %q", e.Entity)
+	}
+
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return entities.EventRecord{}, err
+	}
+	record.Payload = string(encoded)
+
+	return record, nil
+}
+
+func FromRecord(record entities.EventRecord) (Event, error) {
+	event := Event{
+		Sequence: record.Sequence,
+		Type:     Type(record.Type),
+		Entity:   EntityKind(record.Entity),
+		Actor: entities.Actor{
+			UserID:        record.ActorUserID,
+			Role:          record.ActorRole,
+			Authenticated: record.ActorAuthenticated,
+		},
+		OccurredAt: record.OccurredAt.UTC(),
+	}
+
+	switch event.Entity {
+	case EntityDestination:
+		event.Destination = &entities.Destination{}
+		return event, json.Unmarshal([]byte(record.Payload), event.Destination)
+	case EntityLocation:
+		event.Location = &entities.Location{}
+		return event, json.Unmarshal([]byte(record.Payload), event.Location)
+	default:
+		return Event{}, fmt.Errorf("unknown event entity %q", record.Entity)
+	}
+}
@@ -0,0 +1,14 @@
+package mail
+
+// Message is a plain text email.
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+}
+
+// Mailer delivers messages. infrastructure/mail has an SMTP implementation and one that
+// writes messages to a directory or the log for local use.
+type Mailer interface {
+	Send(message Message) error
+}
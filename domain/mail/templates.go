@@ -0,0 +1,50 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+type Template string
+
+const (
+	PasswordResetTemplate     Template = "password_reset"
+	EmailVerificationTemplate Template = "email_verification"
+)
+
+// TemplateData is what every template can use. Link carries the single-use token.
+type TemplateData struct {
+	Name      string
+	Link      string
+	ExpiresIn string
+}
+
+//go:embed templates/*.tmpl
+var templateFiles embed.FS
+
+// Each template file defines <name>_subject and <name>_body.
+var templates = template.Must(template.New("").ParseFS(templateFiles, "templates/*.tmpl"))
+
+// Render fills the template name for the recipient to.
+func Render(name Template, to string, data TemplateData) (Message, error) {
+	subject, err := execute(string(name)+"_subject", data)
+	if err != nil {
+		return Message{}, err
+	}
+	text, err := execute(string(name)+"_body", data)
+	if err != nil {
+		return Message{}, err
+	}
+	return Message{To: to, Subject: strings.TrimSpace(subject), Text: strings.TrimSpace(text) + "\n"}, nil
+}
+
+func execute(name string, data TemplateData) (string, error) {
+	var buffer bytes.Buffer
+	if err := templates.ExecuteTemplate(&buffer, name, data); err != nil {
+		return "", fmt.Errorf("mail template %s: %w", name, err)
+	}
+	return buffer.String(), nil
+}
@@ -5,10 +5,17 @@ import "Trip-Trove-API/domain/entities"
 type DestinationRepository interface {
 	AllDestinations() ([]entities.Destination, error)
 	AllDestinationIDs() ([]uint, error)
+	QueryDestinations(query entities.DestinationQuery) ([]entities.Destination, int64, error)
+	SearchDestinations(term string, limit int, visibility entities.DestinationVisibility) ([]entities.DestinationSearchResult, error)
+	DestinationsNearby(latitude float64, longitude float64, radiusKm float64, limit int, visibility entities.DestinationVisibility) ([]entities.DestinationWithDistance, error)
 	DestinationByID(id uint) (*entities.Destination, error)
+	DestinationByName(name string) (*entities.Destination, error)
 	DestinationIDsForLocation(locationID uint) ([]uint, error)
+	DestinationsForLocation(locationID uint) ([]entities.Destination, error)
 	DeleteDestinationsByLocationID(locationID uint) error
 	CreateDestination(destination entities.Destination) (entities.Destination, error)
+	// CreateDestinations inserts every destination in one statement and returns them with their ids.
+	CreateDestinations(destinations []entities.Destination) ([]entities.Destination, error)
 	UpdateDestination(id uint, updatedDestination entities.Destination) (entities.Destination, error)
 	DeleteDestination(id uint) (entities.Destination, error)
 }
@@ -0,0 +1,13 @@
+package repositories
+
+import "Trip-Trove-API/domain/entities"
+
+type EventLogRepository interface {
+	AppendEvent(record entities.EventRecord) (entities.EventRecord, error)
+	// EventsSince returns up to limit records with a sequence greater than sequence, oldest first.
+	EventsSince(sequence uint64, limit int) ([]entities.EventRecord, error)
+	// SequenceRange returns the oldest and latest sequence still in the log, both 0 when it is empty.
+	SequenceRange() (oldest uint64, latest uint64, err error)
+	// TrimEvents deletes every record with a sequence lower than keepFrom.
+	TrimEvents(keepFrom uint64) error
+}
@@ -5,8 +5,12 @@ import "Trip-Trove-API/domain/entities"
 type LocationRepository interface {
 	AllLocations() ([]entities.Location, error)
 	AllLocationIDs() ([]uint, error)
+	SearchLocations(term string, limit int) ([]entities.LocationSearchResult, error)
 	LocationByID(id uint) (*entities.Location, error)
+	LocationByName(name string) (*entities.Location, error)
 	CreateLocation(location entities.Location) (entities.Location, error)
+	// CreateLocations inserts every location in one statement and returns them with their ids.
+	CreateLocations(locations []entities.Location) ([]entities.Location, error)
 	UpdateLocation(id uint, updatedLocation entities.Location) (entities.Location, error)
 	DeleteLocation(id uint) (entities.Location, error)
 }
@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"time"
+)
+
+type TokenRepository interface {
+	CreateRefreshToken(token entities.RefreshToken) (entities.RefreshToken, error)
+	RefreshTokenByHash(hash string) (*entities.RefreshToken, error)
+	// RevokeRefreshToken revokes an active token and reports whether this call was the one that did.
+	RevokeRefreshToken(id uint) (bool, error)
+	RevokeRefreshTokenFamily(familyID string) ([]entities.RefreshToken, error)
+	RevokeUserRefreshTokens(userID uint) ([]entities.RefreshToken, error)
+	RevokeAccessTokens(tokens []entities.RevokedToken) error
+	IsAccessTokenRevoked(tokenID string) (bool, error)
+	DeleteExpiredRevocations(before time.Time) error
+	CreateUserToken(token entities.UserToken) (entities.UserToken, error)
+	UserTokenByHash(hash string) (*entities.UserToken, error)
+	// UseUserToken marks an unused token as used and reports whether this call was the one that did.
+	UseUserToken(id uint) (bool, error)
+	DeleteUserTokens(userID uint, purpose entities.UserTokenPurpose) error
+}
@@ -0,0 +1,15 @@
+package repositories
+
+import "Trip-Trove-API/domain/entities"
+
+type TripRepository interface {
+	AllTrips() ([]entities.Trip, error)
+	TripsByOwner(ownerID uint) ([]entities.Trip, error)
+	TripByID(id uint) (*entities.Trip, error)
+	CreateTrip(trip entities.Trip) (entities.Trip, error)
+	UpdateTrip(id uint, updatedTrip entities.Trip) (entities.Trip, error)
+	DeleteTrip(id uint) (entities.Trip, error)
+	AddStop(stop entities.TripStop) (entities.TripStop, error)
+	DeleteStop(tripID uint, stopID uint) (entities.TripStop, error)
+	UpdateStopOrder(tripID uint, stops []entities.TripStop) error
+}
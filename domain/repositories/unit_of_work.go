@@ -0,0 +1,18 @@
+package repositories
+
+// Repositories groups the repositories that share a single unit of work.
+type Repositories struct {
+	Destinations DestinationRepository
+	Locations    LocationRepository
+	Users        UserRepository
+	Tokens       TokenRepository
+	Trips        TripRepository
+	Events       EventLogRepository
+	Webhooks     WebhookRepository
+}
+
+// UnitOfWork runs fn against repositories bound to one transaction. The transaction
+// commits when fn returns nil and rolls back when it returns an error or panics.
+type UnitOfWork interface {
+	Do(fn func(repos Repositories) error) error
+}
@@ -1,13 +1,29 @@
 package repositories
 
-import "Trip-Trove-API/domain/entities"
+import (
+	"Trip-Trove-API/domain/entities"
+	"time"
+)
 
 type UserRepository interface {
 	AllUsers() ([]entities.User, error)
 	AllUserIDs() ([]uint, error)
 	UserByID(id uint) (*entities.User, error)
+	UserByEmail(email string) (*entities.User, error)
 	Register(user entities.User) (entities.User, error)
-	Login(loginData entities.LoginRequest) (entities.LoginResponse, error)
+	// CreateUsers inserts users as given: their role is kept and passwords must already be hashed.
+	CreateUsers(users []entities.User) ([]entities.User, error)
+	Authenticate(loginData entities.LoginRequest) (*entities.User, error)
+	// UpdateUser copies the non-zero profile fields of updatedUser. It never changes the
+	// password or the role.
 	UpdateUser(id uint, updatedUser entities.User) (entities.User, error)
+	UpdatePassword(id uint, passwordHash string) error
+	// SetEmailVerifiedAt marks the email of the user as verified, or as unverified when verifiedAt is nil.
+	SetEmailVerifiedAt(id uint, verifiedAt *time.Time) error
 	DeleteUser(id uint) (entities.User, error)
+	UpdateRole(id uint, role entities.AccessType) error
+	CountUsersByRole(role entities.AccessType) (int64, error)
+	CreateRoleChange(change entities.RoleChange) (entities.RoleChange, error)
+	// RoleChanges returns the role changes of the user, oldest first.
+	RoleChanges(userID uint) ([]entities.RoleChange, error)
 }
@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"time"
+)
+
+type WebhookRepository interface {
+	AllWebhooks() ([]entities.Webhook, error)
+	ActiveWebhooks() ([]entities.Webhook, error)
+	WebhookByID(id uint) (*entities.Webhook, error)
+	CreateWebhook(webhook entities.Webhook) (entities.Webhook, error)
+	// UpdateWebhook replaces every field, except the secret when the update leaves it empty.
+	UpdateWebhook(id uint, updatedWebhook entities.Webhook) (entities.Webhook, error)
+	DeleteWebhook(id uint) (entities.Webhook, error)
+
+	CreateDelivery(delivery entities.WebhookDelivery) (entities.WebhookDelivery, error)
+	DeliveryByID(id uint) (*entities.WebhookDelivery, error)
+	// DeliveriesForWebhook returns a page of deliveries, newest first, and the total matching the query.
+	DeliveriesForWebhook(webhookID uint, query entities.WebhookDeliveryQuery) ([]entities.WebhookDelivery, int64, error)
+	// DueDeliveries returns up to limit pending deliveries whose next attempt is not after now.
+	DueDeliveries(now time.Time, limit int) ([]entities.WebhookDelivery, error)
+	// ClaimDelivery pushes the next attempt of a pending, due delivery to until so no other
+	// worker picks it up meanwhile. It reports false when the delivery was no longer due.
+	ClaimDelivery(id uint, now time.Time, until time.Time) (bool, error)
+	// SaveAttempt stores the outcome of an attempt: status, attempts, response and next attempt.
+	SaveAttempt(delivery entities.WebhookDelivery) error
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/mail"
+	"Trip-Trove-API/utils"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/bcrypt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	PasswordResetTTL     = time.Hour
+	EmailVerificationTTL = 48 * time.Hour
+)
+
+var (
+	ErrInvalidAccountToken  = apperrors.Validation("the link is invalid or has expired", apperrors.FieldError{Field: "token", Rule: "valid", Message: "is invalid, already used or expired"})
+	ErrEmailAlreadyVerified = apperrors.Conflict("email address is already verified")
+	ErrEmailNotVerified     = apperrors.Forbidden("verify your email address first")
+
+	errMailerMissing = errors.New("no mailer is configured")
+)
+
+// RequireVerifiedEmail is the check for actions unverified accounts may not take.
+func RequireVerifiedEmail(actor entities.Actor) error {
+	if actor.Authenticated && !actor.EmailVerified {
+		return ErrEmailNotVerified
+	}
+	return nil
+}
+
+// RequestPasswordReset mails a reset link to the account using email. Unknown addresses are
+// not reported, so the endpoint cannot be used to find out who has an account.
+func (service *UserService) RequestPasswordReset(email string) error {
+	user, err := service.Repo.UserByEmail(email)
+	if apperrors.KindOf(err) == apperrors.KindNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return service.sendAccountToken(*user, entities.PasswordResetToken)
+}
+
+// ResetPassword sets a new password with a token from RequestPasswordReset and ends every
+// session of the user. The caller validates the strength of newPassword.
+func (service *UserService) ResetPassword(token string, newPassword string) error {
+	user, stored, err := service.useAccountToken(token, entities.PasswordResetToken)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	if err := service.Repo.UpdatePassword(user.ID, string(hashedPassword)); err != nil {
+		return err
+	}
+
+	// Receiving the link proves the address as well as a verification link would.
+	if !user.EmailVerified() {
+		if err := service.Repo.SetEmailVerifiedAt(user.ID, stored.UsedAt); err != nil {
+			return err
+		}
+	}
+
+	return service.endSessions(user.ID)
+}
+
+// SendEmailVerification mails a new verification link to the user; earlier links stop working.
+func (service *UserService) SendEmailVerification(userID uint) error {
+	user, err := service.Repo.UserByID(userID)
+	if err != nil {
+		return err
+	}
+	if user.EmailVerified() {
+		return ErrEmailAlreadyVerified
+	}
+
+	return service.sendAccountToken(*user, entities.EmailVerificationToken)
+}
+
+// VerifyEmail marks the email of the user as verified with a token from SendEmailVerification.
+// Access tokens issued before carry the old state until they are refreshed.
+func (service *UserService) VerifyEmail(token string) error {
+	user, stored, err := service.useAccountToken(token, entities.EmailVerificationToken)
+	if err != nil {
+		return err
+	}
+	return service.Repo.SetEmailVerifiedAt(user.ID, stored.UsedAt)
+}
+
+// useAccountToken consumes token. It fails the same way for unknown, used and expired tokens,
+// and for tokens mailed to an address the user has since changed.
+func (service *UserService) useAccountToken(token string, purpose entities.UserTokenPurpose) (*entities.User, *entities.UserToken, error) {
+	stored, err := service.TokenRepo.UserTokenByHash(utils.HashToken(token))
+	if apperrors.KindOf(err) == apperrors.KindNotFound {
+		return nil, nil, ErrInvalidAccountToken
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	if !stored.IsUsable(purpose, now) {
+		return nil, nil, ErrInvalidAccountToken
+	}
+
+	user, err := service.Repo.UserByID(stored.UserID)
+	if apperrors.KindOf(err) == apperrors.KindNotFound {
+		return nil, nil, ErrInvalidAccountToken
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if !strings.EqualFold(user.Email, stored.Email) {
+		return nil, nil, ErrInvalidAccountToken
+	}
+
+	used, err := service.TokenRepo.UseUserToken(stored.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !used {
+		return nil, nil, ErrInvalidAccountToken
+	}
+	stored.UsedAt = &now
+
+	return user, stored, nil
+}
+
+var accountTokenMails = map[entities.UserTokenPurpose]struct {
+	template mail.Template
+	path     string
+	ttl      time.Duration
+}{
+	entities.PasswordResetToken:     {mail.PasswordResetTemplate, "/reset-password", PasswordResetTTL},
+	entities.EmailVerificationToken: {mail.EmailVerificationTemplate, "/verify-email", EmailVerificationTTL},
+}
+
+// sendAccountToken replaces the outstanding tokens of the user for purpose with a new one and
+// mails it as a link to LinkBaseURL.
+func (service *UserService) sendAccountToken(user entities.User, purpose entities.UserTokenPurpose) error {
+	if service.Mailer == nil {
+		return errMailerMissing
+	}
+	settings := accountTokenMails[purpose]
+
+	if err := service.TokenRepo.DeleteUserTokens(user.ID, purpose); err != nil {
+		return err
+	}
+
+	token, err := utils.RandomToken(32)
+	if err != nil {
+		return err
+	}
+	_, err = service.TokenRepo.CreateUserToken(entities.UserToken{
+		UserID:    user.ID,
+		Purpose:   purpose,
+		TokenHash: utils.HashToken(token),
+		Email:     user.Email,
+		ExpiresAt: time.Now().Add(settings.ttl),
+	})
+	if err != nil {
+		return err
+	}
+
+	link := strings.TrimSuffix(service.LinkBaseURL, "/") + settings.path + "?token=" + url.QueryEscape(token)
+	message, err := mail.Render(settings.template, user.Email, mail.TemplateData{
+		Name:      user.FirstName,
+		Link:      link,
+		ExpiresIn: formatTTL(settings.ttl),
+	})
+	if err != nil {
+		return err
+	}
+	return service.Mailer.Send(message)
+}
+
+// sendEmailVerificationQuietly is used where the mail is a side effect: failing to send it
+// must not undo the registration or profile change that triggered it.
+func (service *UserService) sendEmailVerificationQuietly(user entities.User) {
+	if service.Mailer == nil {
+		return
+	}
+	if err := service.sendAccountToken(user, entities.EmailVerificationToken); err != nil {
+		log.Printf("Failed to send the verification email to user %d: %v", user.ID, err)
+	}
+}
+
+func formatTTL(ttl time.Duration) string {
+	if hours := int(ttl / time.Hour); hours > 1 {
+		return fmt.Sprintf("%d hours", hours)
+	}
+	return "1 hour"
+}
@@ -0,0 +1,244 @@
+package services
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/events"
+	"Trip-Trove-API/domain/repositories"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type ImportRowStatus string
+
+const (
+	ImportCreated  ImportRowStatus = "created"
+	ImportUpdated  ImportRowStatus = "updated"
+	ImportRejected ImportRowStatus = "rejected"
+)
+
+type ImportRowResult struct {
+	Line            int                    `json:"line"`
+	Name            string                 `json:"name,omitempty"`
+	Status          ImportRowStatus        `json:"status"`
+	DestinationID   uint                   `json:"destination_id,omitempty"`
+	LocationCreated bool                   `json:"location_created,omitempty"`
+	Errors          []apperrors.FieldError `json:"errors,omitempty"`
+}
+
+// ImportReport describes what an import did, or would have done for a dry run, row by row.
+// Destination ids are only reported for imports that were committed.
+type ImportReport struct {
+	DryRun           bool              `json:"dry_run"`
+	Created          int               `json:"created"`
+	Updated          int               `json:"updated"`
+	Rejected         int               `json:"rejected"`
+	LocationsCreated int               `json:"locations_created"`
+	Rows             []ImportRowResult `json:"rows"`
+}
+
+// errImportDryRun rolls back the unit of work of a dry run once every row has been tried.
+var errImportDryRun = errors.New("import dry run")
+
+// ImportDestinations creates or updates one destination per row in a single unit of work,
+// matching existing destinations by name. A row's location is looked up by name and created
+// when missing. Rows that fail validation or that the actor may not apply are rejected and
+// reported; the others are still imported. With dryRun every row is checked the same way but
+// nothing is kept.
+func (service *DestinationService) ImportDestinations(rows []DestinationImportRow, dryRun bool, actor entities.Actor) (*ImportReport, error) {
+	if !actor.Can(entities.DestinationsImport) {
+		return nil, ErrForbidden
+	}
+	if len(rows) > MaxImportRows {
+		return nil, tooManyImportRows()
+	}
+
+	var report *ImportReport
+	var pending []events.Event
+	err := service.UnitOfWork.Do(func(repos repositories.Repositories) error {
+		importer := destinationImporter{repos: repos, actor: actor, seen: make(map[string]int)}
+		report = &ImportReport{DryRun: dryRun, Rows: make([]ImportRowResult, 0, len(rows))}
+
+		for _, row := range rows {
+			result, err := importer.importRow(row)
+			if err != nil {
+				return fmt.Errorf("line %d: %w", row.Line, err)
+			}
+			report.add(result)
+		}
+
+		pending = importer.events
+		if dryRun {
+			return errImportDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errImportDryRun) {
+		return nil, err
+	}
+
+	if dryRun {
+		for i := range report.Rows {
+			report.Rows[i].DestinationID = 0
+		}
+		return report, nil
+	}
+
+	if service.Events != nil {
+		for _, event := range pending {
+			service.Events.Publish(event)
+		}
+	}
+	return report, nil
+}
+
+func (report *ImportReport) add(result ImportRowResult) {
+	switch result.Status {
+	case ImportCreated:
+		report.Created++
+	case ImportUpdated:
+		report.Updated++
+	case ImportRejected:
+		report.Rejected++
+	}
+	if result.LocationCreated {
+		report.LocationsCreated++
+	}
+	report.Rows = append(report.Rows, result)
+}
+
+// destinationImporter applies the rows of one import inside its unit of work and collects the
+// events to publish once it has been committed.
+type destinationImporter struct {
+	repos  repositories.Repositories
+	actor  entities.Actor
+	seen   map[string]int
+	events []events.Event
+}
+
+// importRow returns an error only when the import as a whole has to stop. Problems with the
+// row itself are reported on the result.
+func (importer *destinationImporter) importRow(row DestinationImportRow) (ImportRowResult, error) {
+	destination := row.Destination
+	result := ImportRowResult{Line: row.Line, Name: destination.Name}
+	reject := func(fields ...apperrors.FieldError) (ImportRowResult, error) {
+		result.Status = ImportRejected
+		result.Errors = fields
+		return result, nil
+	}
+
+	if len(row.Errors) > 0 {
+		return reject(row.Errors...)
+	}
+	if line, ok := importer.seen[destination.Name]; ok {
+		return reject(importRowError("name", "unique", fmt.Sprintf("the same name is already used on line %d", line)))
+	}
+	if destination.Name != "" {
+		importer.seen[destination.Name] = row.Line
+	}
+
+	if err := validateDestination(destination, "LocationID"); err != nil {
+		return importer.rejectInvalid(reject, err, destinationImportColumns)
+	}
+
+	existing, err := importer.repos.Destinations.DestinationByName(destination.Name)
+	if err != nil && apperrors.KindOf(err) != apperrors.KindNotFound {
+		return result, err
+	}
+	if err == nil {
+		if !existing.VisibleTo(importer.actor) {
+			return reject(importRowError("name", "unique", "a destination with this name already exists"))
+		}
+		if !existing.EditableBy(importer.actor) {
+			return reject(importRowError("name", "forbidden", "you are not allowed to update this destination"))
+		}
+	} else {
+		existing = nil
+	}
+
+	location, err := importer.repos.Locations.LocationByName(row.Location)
+	switch {
+	case err == nil:
+		if !strings.EqualFold(location.Country, row.Country) {
+			return reject(importRowError("country", "location", fmt.Sprintf("location %q is in %s", location.Name, location.Country)))
+		}
+	case apperrors.KindOf(err) == apperrors.KindNotFound:
+		newLocation := entities.Location{Name: row.Location, Country: row.Country}
+		if err := validateLocation(newLocation); err != nil {
+			return importer.rejectInvalid(reject, err, locationImportColumns)
+		}
+		created, err := importer.repos.Locations.CreateLocation(newLocation)
+		if err != nil {
+			return result, err
+		}
+		location = &created
+		result.LocationCreated = true
+		importer.events = append(importer.events, events.NewLocationEvent(events.LocationCreated, created, importer.actor))
+	default:
+		return result, err
+	}
+	destination.LocationID = location.ID
+
+	if existing != nil {
+		destination.OwnerID = nil
+		updated, err := importer.repos.Destinations.UpdateDestination(existing.ID, destination)
+		if err != nil {
+			return result, err
+		}
+		result.Status = ImportUpdated
+		result.DestinationID = updated.ID
+		importer.events = append(importer.events, events.NewDestinationEvent(events.DestinationUpdated, updated, importer.actor))
+		return result, nil
+	}
+
+	destination.OwnerID = nil
+	if importer.actor.Authenticated {
+		ownerID := importer.actor.UserID
+		destination.OwnerID = &ownerID
+	}
+	created, err := importer.repos.Destinations.CreateDestination(destination)
+	if err != nil {
+		return result, err
+	}
+	result.Status = ImportCreated
+	result.DestinationID = created.ID
+	importer.events = append(importer.events, events.NewDestinationEvent(events.DestinationCreated, created, importer.actor))
+	return result, nil
+}
+
+// destinationImportColumns and locationImportColumns name the import column behind each
+// validated struct field, so a rejected row points at its own columns.
+var (
+	destinationImportColumns = map[string]string{
+		"Name":             "name",
+		"ImageUrl":         "image_url",
+		"Description":      "description",
+		"VisitorsLastYear": "visitors_last_year",
+		"Latitude":         "latitude",
+		"Longitude":        "longitude",
+	}
+	locationImportColumns = map[string]string{
+		"Name":    "location",
+		"Country": "country",
+	}
+)
+
+// rejectInvalid reports validation failures on the row and anything else as fatal.
+func (importer *destinationImporter) rejectInvalid(reject func(...apperrors.FieldError) (ImportRowResult, error), err error, columns map[string]string) (ImportRowResult, error) {
+	if apperrors.KindOf(err) != apperrors.KindValidation {
+		return ImportRowResult{}, err
+	}
+
+	fields := apperrors.FieldsOf(err)
+	for i := range fields {
+		if column, ok := columns[fields[i].Field]; ok {
+			fields[i].Field = column
+		}
+	}
+	return reject(fields...)
+}
+
+func importRowError(field string, rule string, message string) apperrors.FieldError {
+	return apperrors.FieldError{Field: field, Rule: rule, Message: message}
+}
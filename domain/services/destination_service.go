@@ -1,33 +1,33 @@
 package services
 
 import (
+	"Trip-Trove-API/domain/apperrors"
 	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/events"
 	"Trip-Trove-API/domain/repositories"
-	"Trip-Trove-API/infrastructure/websocket"
-	"errors"
 	"fmt"
 	"github.com/jaswdr/faker"
-	"time"
+	"math"
 )
 
 type IDestinationService interface {
-	AllDestinations() ([]entities.Destination, error)
-	DestinationByID(idStr string) (*entities.Destination, error)
-	DestinationsByLocationID(locationIDStr string) (*DestinationsByLocation, error)
-	CreateDestination(destination entities.Destination) (entities.Destination, error)
-	UpdateDestination(idStr string, updatedDestination entities.Destination) (entities.Destination, error)
-	DeleteDestination(idStr string) (entities.Destination, error)
-	StartGeneratingDestinations(interval time.Duration, f faker.Faker)
-	StopGeneratingDestinations()
-	GenerateFakeDestination(f faker.Faker) (entities.Destination, error)
+	AllDestinations(query entities.DestinationQuery, actor entities.Actor) (*DestinationPage, error)
+	SearchDestinations(term string, limit int, actor entities.Actor) ([]entities.DestinationSearchResult, error)
+	NearbyDestinations(latitude float64, longitude float64, radiusKm float64, limit int, actor entities.Actor) ([]entities.DestinationWithDistance, error)
+	DestinationByID(idStr string, actor entities.Actor) (*entities.Destination, error)
+	DestinationsByLocationID(locationIDStr string, actor entities.Actor) (*DestinationsByLocation, error)
+	CreateDestination(destination entities.Destination, actor entities.Actor) (entities.Destination, error)
+	UpdateDestination(idStr string, updatedDestination entities.Destination, actor entities.Actor) (entities.Destination, error)
+	DeleteDestination(idStr string, actor entities.Actor) (entities.Destination, error)
+	GenerateFakeDestination(f faker.Faker, actor entities.Actor) (entities.Destination, error)
+	ImportDestinations(rows []DestinationImportRow, dryRun bool, actor entities.Actor) (*ImportReport, error)
 }
 
 type DestinationService struct {
 	Repo         repositories.DestinationRepository
 	LocationRepo repositories.LocationRepository
-	Ticker       *time.Ticker
-	StopChan     chan bool
-	WsManager    *websocket.WebSocketManager
+	UnitOfWork   repositories.UnitOfWork
+	Events       events.Publisher
 }
 
 type DestinationDetails struct {
@@ -45,20 +45,124 @@ type DestinationsByLocation struct {
 	Destinations []entities.Destination
 }
 
+type DestinationPage struct {
+	Destinations []entities.Destination
+	Total        int64
+	Page         int
+	Limit        int
+}
+
+const (
+	DefaultPageLimit   = 20
+	MaxPageLimit       = 100
+	DefaultSearchLimit = 10
+	MaxSearchLimit     = 50
+	MinSearchTermRunes = 2
+	DefaultRadiusKm    = 25
+	MaxRadiusKm        = 500
+)
+
+var (
+	ErrInvalidQuery = apperrors.Validation("invalid query parameters")
+	ErrForbidden    = apperrors.Forbidden("access denied")
+)
+
 var _ IDestinationService = &DestinationService{}
 
-func (service *DestinationService) AllDestinations() ([]entities.Destination, error) {
-	destinations, err := service.Repo.AllDestinations()
+func (service *DestinationService) AllDestinations(query entities.DestinationQuery, actor entities.Actor) (*DestinationPage, error) {
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Limit == 0 {
+		query.Limit = DefaultPageLimit
+	}
+	if query.SortBy == "" {
+		query.SortBy = entities.SortByCreatedAt
+	}
+
+	if query.Page < 1 || query.Limit < 1 || query.Limit > MaxPageLimit {
+		return nil, fmt.Errorf("%w: page must be positive and limit between 1 and %d", ErrInvalidQuery, MaxPageLimit)
+	}
+	switch query.SortBy {
+	case entities.SortByName, entities.SortByVisitorsLastYear, entities.SortByCreatedAt:
+	default:
+		return nil, fmt.Errorf("%w: unsupported sort field %q", ErrInvalidQuery, query.SortBy)
+	}
+	filter := query.Filter
+	if filter.MinVisitors != nil && filter.MaxVisitors != nil && *filter.MinVisitors > *filter.MaxVisitors {
+		return nil, fmt.Errorf("%w: min_visitors is greater than max_visitors", ErrInvalidQuery)
+	}
+
+	query.Filter.Visibility = actor.DestinationVisibility()
+
+	destinations, total, err := service.Repo.QueryDestinations(query)
+	if err != nil {
+		return nil, err
+	}
+
+	if destinations == nil {
+		destinations = []entities.Destination{}
+	}
+
+	return &DestinationPage{
+		Destinations: destinations,
+		Total:        total,
+		Page:         query.Page,
+		Limit:        query.Limit,
+	}, nil
+}
+
+func (service *DestinationService) SearchDestinations(term string, limit int, actor entities.Actor) ([]entities.DestinationSearchResult, error) {
+	term, limit, err := normalizeSearch(term, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := service.Repo.SearchDestinations(term, limit, actor.DestinationVisibility())
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (service *DestinationService) NearbyDestinations(latitude float64, longitude float64, radiusKm float64, limit int, actor entities.Actor) ([]entities.DestinationWithDistance, error) {
+	if radiusKm == 0 {
+		radiusKm = DefaultRadiusKm
+	}
+	if limit == 0 {
+		limit = DefaultPageLimit
+	}
+
+	// NaN fails every comparison, so it would pass the range checks below.
+	if !isFinite(latitude) || !isFinite(longitude) || !isFinite(radiusKm) {
+		return nil, fmt.Errorf("%w: coordinates and radius_km must be finite numbers", ErrInvalidQuery)
+	}
+	if latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+		return nil, fmt.Errorf("%w: coordinates are out of range", ErrInvalidQuery)
+	}
+	if radiusKm < 0 || radiusKm > MaxRadiusKm || limit < 1 || limit > MaxPageLimit {
+		return nil, fmt.Errorf("%w: radius_km must be at most %d and limit between 1 and %d", ErrInvalidQuery, MaxRadiusKm, MaxPageLimit)
+	}
+
+	destinations, err := service.Repo.DestinationsNearby(latitude, longitude, radiusKm, limit, actor.DestinationVisibility())
 	if err != nil {
 		return nil, err
 	}
+
+	if destinations == nil {
+		destinations = []entities.DestinationWithDistance{}
+	}
 	return destinations, nil
 }
 
-func (service *DestinationService) DestinationByID(idStr string) (*entities.Destination, error) {
+func isFinite(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
+
+func (service *DestinationService) DestinationByID(idStr string, actor entities.Actor) (*entities.Destination, error) {
 	var id uint
 	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
-		return nil, errors.New("invalid ID format")
+		return nil, apperrors.ErrInvalidID
 	}
 
 	destination, err := service.Repo.DestinationByID(id)
@@ -66,13 +170,17 @@ func (service *DestinationService) DestinationByID(idStr string) (*entities.Dest
 		return nil, err
 	}
 
+	if !destination.VisibleTo(actor) {
+		return nil, apperrors.NotFound("destination not found")
+	}
+
 	return destination, nil
 }
 
-func (service *DestinationService) DestinationsByLocationID(locationIDStr string) (*DestinationsByLocation, error) {
+func (service *DestinationService) DestinationsByLocationID(locationIDStr string, actor entities.Actor) (*DestinationsByLocation, error) {
 	var locationID uint
 	if _, err := fmt.Sscanf(locationIDStr, "%d", &locationID); err != nil {
-		return nil, errors.New("invalid ID format")
+		return nil, apperrors.ErrInvalidID
 	}
 
 	location, err := service.LocationRepo.LocationByID(locationID)
@@ -88,6 +196,9 @@ func (service *DestinationService) DestinationsByLocationID(locationIDStr string
 		if err != nil {
 			return &DestinationsByLocation{}, err
 		}
+		if !destination.VisibleTo(actor) {
+			continue
+		}
 		destinations = append(destinations, *destination)
 	}
 
@@ -99,53 +210,94 @@ func (service *DestinationService) DestinationsByLocationID(locationIDStr string
 	return destinationsByLocation, nil
 }
 
-func (service *DestinationService) CreateDestination(destination entities.Destination) (entities.Destination, error) {
+func (service *DestinationService) CreateDestination(destination entities.Destination, actor entities.Actor) (entities.Destination, error) {
+	if !actor.Can(entities.DestinationsWrite) {
+		return entities.Destination{}, ErrForbidden
+	}
+
 	_, err := service.LocationRepo.LocationByID(destination.LocationID)
 	if err != nil {
 		return entities.Destination{}, err
 	}
 
+	destination.OwnerID = nil
+	if actor.Authenticated {
+		ownerID := actor.UserID
+		destination.OwnerID = &ownerID
+	}
+
 	destination, err = service.Repo.CreateDestination(destination)
 	if err != nil {
 		return entities.Destination{}, err
 	}
+	service.publish(events.DestinationCreated, destination, actor)
 	return destination, nil
 }
 
-func (service *DestinationService) DeleteDestination(idStr string) (entities.Destination, error) {
+func (service *DestinationService) DeleteDestination(idStr string, actor entities.Actor) (entities.Destination, error) {
 	var id uint
 	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
-		return entities.Destination{}, errors.New("invalid ID format")
+		return entities.Destination{}, apperrors.ErrInvalidID
+	}
+
+	if !actor.Can(entities.DestinationsDelete) {
+		return entities.Destination{}, ErrForbidden
+	}
+	if err := service.authorizeEdit(id, actor); err != nil {
+		return entities.Destination{}, err
 	}
 
 	destination, err := service.Repo.DeleteDestination(id)
 	if err != nil {
 		return entities.Destination{}, err
 	}
+	service.publish(events.DestinationDeleted, destination, actor)
 	return destination, nil
 }
 
-func (service *DestinationService) UpdateDestination(idStr string, destination entities.Destination) (entities.Destination, error) {
+func (service *DestinationService) UpdateDestination(idStr string, destination entities.Destination, actor entities.Actor) (entities.Destination, error) {
 	var id uint
 	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
-		return entities.Destination{}, errors.New("invalid ID format")
+		return entities.Destination{}, apperrors.ErrInvalidID
+	}
+
+	if err := service.authorizeEdit(id, actor); err != nil {
+		return entities.Destination{}, err
 	}
 
+	destination.OwnerID = nil
+
 	destination, err := service.Repo.UpdateDestination(id, destination)
 	if err != nil {
 		return entities.Destination{}, err
 	}
+	service.publish(events.DestinationUpdated, destination, actor)
 	return destination, nil
 }
 
-func (service *DestinationService) GenerateFakeLocation(f faker.Faker) (entities.Location, error) {
-	fakeLocation := entities.Location{
-		Name:        f.Address().City(),
-		Country:     f.Address().Country(),
-		Description: f.Lorem().Sentence(10),
+func (service *DestinationService) publish(eventType events.Type, destination entities.Destination, actor entities.Actor) {
+	if service.Events != nil {
+		service.Events.Publish(events.NewDestinationEvent(eventType, destination, actor))
+	}
+}
+
+func (service *DestinationService) authorizeEdit(id uint, actor entities.Actor) error {
+	destination, err := service.Repo.DestinationByID(id)
+	if err != nil {
+		return err
+	}
+
+	if !destination.VisibleTo(actor) {
+		return apperrors.NotFound("destination not found")
+	}
+	if !destination.EditableBy(actor) {
+		return ErrForbidden
 	}
+	return nil
+}
 
-	location, err := service.LocationRepo.CreateLocation(fakeLocation)
+func (service *DestinationService) GenerateFakeLocation(f faker.Faker) (entities.Location, error) {
+	location, err := service.LocationRepo.CreateLocation(FakeLocation(f))
 	if err != nil {
 		return entities.Location{}, err
 	}
@@ -153,55 +305,55 @@ func (service *DestinationService) GenerateFakeLocation(f faker.Faker) (entities
 	return location, nil
 }
 
-func (service *DestinationService) GenerateFakeDestination(f faker.Faker) (entities.Destination, error) {
+// FakeLocation builds an unsaved location from f. A seeded f always builds the same one.
+func FakeLocation(f faker.Faker) entities.Location {
+	return entities.Location{
+		Name:        f.Address().City(),
+		Country:     f.Address().Country(),
+		Description: f.Lorem().Sentence(10),
+	}
+}
+
+// FakeDestination builds an unsaved public destination in the given location.
+func FakeDestination(f faker.Faker, locationID uint) entities.Destination {
 	min, max := 1, 9
 	randomMultipleOfTen := f.IntBetween(min, max) * 10000
+	latitude, longitude := f.Address().Latitude(), f.Address().Longitude()
 
-	location, err := service.GenerateFakeLocation(f)
-	if err != nil {
-		return entities.Destination{}, err
-	}
-	fakeDestination := entities.Destination{
+	return entities.Destination{
 		Name:             f.Company().Name(),
-		LocationID:       location.ID,
+		LocationID:       locationID,
 		ImageUrl:         f.Internet().URL(),
 		Description:      f.Lorem().Paragraph(3),
 		VisitorsLastYear: randomMultipleOfTen,
 		IsPrivate:        false,
+		Latitude:         &latitude,
+		Longitude:        &longitude,
 	}
+}
 
-	destination, err := service.Repo.CreateDestination(fakeDestination)
+// GenerateFakeDestination creates a fake destination in a new fake location and publishes
+// both as created by actor.
+func (service *DestinationService) GenerateFakeDestination(f faker.Faker, actor entities.Actor) (entities.Destination, error) {
+	var destination entities.Destination
+	var location entities.Location
+	err := service.UnitOfWork.Do(func(repos repositories.Repositories) error {
+		var err error
+		location, err = repos.Locations.CreateLocation(FakeLocation(f))
+		if err != nil {
+			return err
+		}
+
+		destination, err = repos.Destinations.CreateDestination(FakeDestination(f, location.ID))
+		return err
+	})
 	if err != nil {
 		return entities.Destination{}, err
 	}
 
+	if service.Events != nil {
+		service.Events.Publish(events.NewLocationEvent(events.LocationCreated, location, actor))
+	}
+	service.publish(events.DestinationCreated, destination, actor)
 	return destination, nil
 }
-
-func (service *DestinationService) StartGeneratingDestinations(interval time.Duration, f faker.Faker) {
-	service.Ticker = time.NewTicker(interval)
-	service.StopChan = make(chan bool)
-
-	go func() {
-		for {
-			select {
-			case <-service.Ticker.C:
-				destination, err := service.GenerateFakeDestination(f)
-				if err != nil {
-					return
-				}
-
-				service.WsManager.AddToBroadcast(websocket.EventUpdateNotification{Action: "GenerateDestination", Destination: destination})
-
-			case <-service.StopChan:
-				return
-			}
-		}
-	}()
-}
-
-func (service *DestinationService) StopGeneratingDestinations() {
-	service.Ticker.Stop()
-	//service.StopChan <- true
-	return
-}
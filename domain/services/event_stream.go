@@ -0,0 +1,173 @@
+package services
+
+import (
+	"Trip-Trove-API/domain/events"
+	"Trip-Trove-API/domain/repositories"
+	"log"
+	"sync"
+)
+
+// DefaultEventRetention is how many events the log keeps for clients that reconnect.
+const DefaultEventRetention = 10000
+
+// trimEvery spaces out the deletes that keep the event log bounded.
+const trimEvery = 100
+
+// DefaultEventQueueSize is how many events may wait for the event stream worker.
+const DefaultEventQueueSize = 1024
+
+// EventStream numbers every event, appends it to the event log and passes it on to the
+// live subscribers. It is the Publisher the other services are given. A single worker does
+// the logging and delivery, so publishing never waits on the database or a subscriber.
+type EventStream struct {
+	Log         repositories.EventLogRepository
+	Subscribers []events.Publisher
+	Retention   uint64
+	// QueueSize bounds the events waiting for the worker; Publish blocks while it is full.
+	QueueSize int
+
+	start  sync.Once
+	queue  chan streamItem
+	done   chan struct{}
+	mu     sync.RWMutex
+	closed bool
+}
+
+// streamItem is an event to deliver, or a flush request when flushed is set.
+type streamItem struct {
+	event   events.Event
+	flushed chan struct{}
+}
+
+// Publish queues the event. The worker logs and delivers events in the order they were
+// published, so subscribers see them in sequence order. If the log cannot be written the
+// event is still delivered live, without a sequence number.
+func (stream *EventStream) Publish(event events.Event) {
+	if !stream.enqueue(streamItem{event: event}) {
+		log.Printf("Dropped a %s event published after the event stream closed", event.Type)
+	}
+}
+
+// Flush waits until every event published before it was logged and delivered.
+func (stream *EventStream) Flush() {
+	flushed := make(chan struct{})
+	if stream.enqueue(streamItem{flushed: flushed}) {
+		<-flushed
+	}
+}
+
+// Close delivers the queued events and stops the worker. Later events are dropped.
+func (stream *EventStream) Close() {
+	stream.start.Do(stream.run)
+
+	stream.mu.Lock()
+	if !stream.closed {
+		stream.closed = true
+		close(stream.queue)
+	}
+	stream.mu.Unlock()
+
+	<-stream.done
+}
+
+func (stream *EventStream) enqueue(item streamItem) bool {
+	stream.start.Do(stream.run)
+
+	stream.mu.RLock()
+	defer stream.mu.RUnlock()
+	if stream.closed {
+		return false
+	}
+	stream.queue <- item
+	return true
+}
+
+func (stream *EventStream) run() {
+	size := stream.QueueSize
+	if size <= 0 {
+		size = DefaultEventQueueSize
+	}
+	stream.queue = make(chan streamItem, size)
+	stream.done = make(chan struct{})
+
+	go func() {
+		defer close(stream.done)
+		for item := range stream.queue {
+			if item.flushed != nil {
+				close(item.flushed)
+				continue
+			}
+			stream.deliver(item.event)
+		}
+	}()
+}
+
+func (stream *EventStream) deliver(event events.Event) {
+	if sequence, err := stream.append(event); err != nil {
+		log.Printf("Error appending to the event log: %v", err)
+	} else {
+		event.Sequence = sequence
+	}
+
+	for _, subscriber := range stream.Subscribers {
+		subscriber.Publish(event)
+	}
+}
+
+func (stream *EventStream) append(event events.Event) (uint64, error) {
+	record, err := event.Record()
+	if err != nil {
+		return 0, err
+	}
+
+	record, err = stream.Log.AppendEvent(record)
+	if err != nil {
+		return 0, err
+	}
+
+	retention := stream.retention()
+	if record.Sequence%trimEvery == 0 && record.Sequence > retention {
+		if err := stream.Log.TrimEvents(record.Sequence - retention + 1); err != nil {
+			log.Printf("Error trimming the event log: %v", err)
+		}
+	}
+
+	return record.Sequence, nil
+}
+
+// EventsSince replays the logged events after sequence. A client that is ahead of the log,
+// or so far behind that events were already trimmed, gets events.ErrResyncRequired.
+func (stream *EventStream) EventsSince(sequence uint64) ([]events.Event, error) {
+	oldest, latest, err := stream.Log.SequenceRange()
+	if err != nil {
+		return nil, err
+	}
+	if sequence > latest || (sequence < latest && sequence+1 < oldest) {
+		return nil, events.ErrResyncRequired
+	}
+	if sequence == latest {
+		return []events.Event{}, nil
+	}
+
+	records, err := stream.Log.EventsSince(sequence, int(stream.retention()))
+	if err != nil {
+		return nil, err
+	}
+
+	replayed := make([]events.Event, 0, len(records))
+	for _, record := range records {
+		event, err := events.FromRecord(record)
+		if err != nil {
+			return nil, err
+		}
+		replayed = append(replayed, event)
+	}
+	return replayed, nil
+}
+
+func (stream *EventStream) retention() uint64 {
+	if stream.Retention == 0 {
+		return DefaultEventRetention
+	}
+	return stream.Retention
+}
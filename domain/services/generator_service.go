@@ -0,0 +1,259 @@
+package services
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/utils"
+	"context"
+	"fmt"
+	"github.com/jaswdr/faker"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+type IGeneratorService interface {
+	StartJob(request GeneratorRequest, actor entities.Actor) (GeneratorJob, error)
+	Jobs() []GeneratorJob
+	JobByID(id string) (GeneratorJob, error)
+	StopJob(id string) (GeneratorJob, error)
+}
+
+type GeneratorJobStatus string
+
+const (
+	JobRunning   GeneratorJobStatus = "running"
+	JobCompleted GeneratorJobStatus = "completed"
+	JobStopped   GeneratorJobStatus = "stopped"
+	JobFailed    GeneratorJobStatus = "failed"
+)
+
+const (
+	MinGeneratorInterval  = 100 * time.Millisecond
+	MaxGeneratorCount     = 10000
+	MaxRunningGenerators  = 5
+	finishedJobsRetention = 50
+)
+
+// GeneratorRequest starts a job creating Count fake destinations, one every IntervalMs.
+// The same Seed produces the same destinations.
+type GeneratorRequest struct {
+	IntervalMs int    `json:"interval_ms" validate:"required,min=100,max=3600000"`
+	Count      int    `json:"count" validate:"required,min=1,max=10000"`
+	Seed       *int64 `json:"seed"`
+}
+
+type GeneratorJob struct {
+	ID         string             `json:"id"`
+	Status     GeneratorJobStatus `json:"status"`
+	IntervalMs int                `json:"interval_ms"`
+	Count      int                `json:"count"`
+	Seed       int64              `json:"seed"`
+	Generated  int                `json:"generated"`
+	Skipped    int                `json:"skipped"`
+	LastError  string             `json:"last_error,omitempty"`
+	StartedBy  uint               `json:"started_by"`
+	StartedAt  time.Time          `json:"started_at"`
+	FinishedAt *time.Time         `json:"finished_at"`
+}
+
+// GeneratorService runs fake destination generator jobs. Jobs live in this process only;
+// each runs in its own goroutine and is cancelled through its context.
+type GeneratorService struct {
+	Destinations IDestinationService
+
+	mu   sync.Mutex
+	jobs map[string]*generatorJob
+}
+
+type generatorJob struct {
+	job    GeneratorJob
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+var _ IGeneratorService = &GeneratorService{}
+
+func (service *GeneratorService) StartJob(request GeneratorRequest, actor entities.Actor) (GeneratorJob, error) {
+	interval := time.Duration(request.IntervalMs) * time.Millisecond
+	if interval < MinGeneratorInterval || request.Count < 1 || request.Count > MaxGeneratorCount {
+		return GeneratorJob{}, apperrors.Validation(fmt.Sprintf("interval must be at least %s and count between 1 and %d", MinGeneratorInterval, MaxGeneratorCount))
+	}
+
+	id, err := utils.RandomToken(8)
+	if err != nil {
+		return GeneratorJob{}, err
+	}
+	seed := time.Now().UnixNano()
+	if request.Seed != nil {
+		seed = *request.Seed
+	}
+
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	if service.jobs == nil {
+		service.jobs = make(map[string]*generatorJob)
+	}
+	if service.running() >= MaxRunningGenerators {
+		return GeneratorJob{}, apperrors.Conflict(fmt.Sprintf("at most %d generator jobs can run at once", MaxRunningGenerators))
+	}
+	service.forgetFinished()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	job := &generatorJob{
+		job: GeneratorJob{
+			ID:         id,
+			Status:     JobRunning,
+			IntervalMs: request.IntervalMs,
+			Count:      request.Count,
+			Seed:       seed,
+			StartedBy:  actor.UserID,
+			StartedAt:  time.Now(),
+		},
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	service.jobs[id] = job
+
+	go service.run(ctx, job, interval, actor)
+	return job.job, nil
+}
+
+func (service *GeneratorService) Jobs() []GeneratorJob {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	jobs := make([]GeneratorJob, 0, len(service.jobs))
+	for _, job := range service.jobs {
+		jobs = append(jobs, job.job)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].StartedAt.Before(jobs[j].StartedAt)
+	})
+	return jobs
+}
+
+func (service *GeneratorService) JobByID(id string) (GeneratorJob, error) {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	job, ok := service.jobs[id]
+	if !ok {
+		return GeneratorJob{}, apperrors.NotFound("generator job not found")
+	}
+	return job.job, nil
+}
+
+// StopJob cancels a running job and waits for it to finish. Stopping a finished job
+// returns it unchanged.
+func (service *GeneratorService) StopJob(id string) (GeneratorJob, error) {
+	service.mu.Lock()
+	job, ok := service.jobs[id]
+	service.mu.Unlock()
+	if !ok {
+		return GeneratorJob{}, apperrors.NotFound("generator job not found")
+	}
+
+	job.cancel()
+	<-job.done
+
+	return service.JobByID(id)
+}
+
+// Close stops every running job.
+func (service *GeneratorService) Close() {
+	service.mu.Lock()
+	jobs := make([]*generatorJob, 0, len(service.jobs))
+	for _, job := range service.jobs {
+		jobs = append(jobs, job)
+	}
+	service.mu.Unlock()
+
+	for _, job := range jobs {
+		job.cancel()
+		<-job.done
+	}
+}
+
+func (service *GeneratorService) run(ctx context.Context, job *generatorJob, interval time.Duration, actor entities.Actor) {
+	defer close(job.done)
+	defer job.cancel()
+
+	f := faker.NewWithSeed(rand.NewSource(job.job.Seed))
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			service.finish(job, JobStopped, "")
+			return
+		case <-ticker.C:
+		}
+
+		_, err := service.Destinations.GenerateFakeDestination(f, actor)
+
+		service.mu.Lock()
+		switch {
+		case err == nil:
+			job.job.Generated++
+		case apperrors.KindOf(err) == apperrors.KindConflict:
+			// Fake names repeat now and then; a clash only skips that destination.
+			job.job.Skipped++
+		}
+		attempts := job.job.Generated + job.job.Skipped
+		service.mu.Unlock()
+
+		if err != nil && apperrors.KindOf(err) != apperrors.KindConflict {
+			service.finish(job, JobFailed, err.Error())
+			return
+		}
+		if attempts >= job.job.Count {
+			service.finish(job, JobCompleted, "")
+			return
+		}
+	}
+}
+
+func (service *GeneratorService) finish(job *generatorJob, status GeneratorJobStatus, lastError string) {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	finishedAt := time.Now()
+	job.job.Status = status
+	job.job.LastError = lastError
+	job.job.FinishedAt = &finishedAt
+}
+
+// running counts the running jobs. Callers hold the lock.
+func (service *GeneratorService) running() int {
+	running := 0
+	for _, job := range service.jobs {
+		if job.job.Status == JobRunning {
+			running++
+		}
+	}
+	return running
+}
+
+// forgetFinished keeps only the most recent finished jobs. Callers hold the lock.
+func (service *GeneratorService) forgetFinished() {
+	finished := make([]*generatorJob, 0)
+	for _, job := range service.jobs {
+		if job.job.Status != JobRunning {
+			finished = append(finished, job)
+		}
+	}
+	if len(finished) <= finishedJobsRetention {
+		return
+	}
+
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i].job.FinishedAt.Before(*finished[j].job.FinishedAt)
+	})
+	for _, job := range finished[:len(finished)-finishedJobsRetention] {
+		delete(service.jobs, job.job.ID)
+	}
+}
@@ -0,0 +1,292 @@
+package services
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type ImportFormat string
+
+const (
+	ImportCSV   ImportFormat = "csv"
+	ImportJSONL ImportFormat = "jsonl"
+)
+
+// MaxImportRows bounds a single import, which runs in one transaction.
+const MaxImportRows = 5000
+
+// maxImportLine is the longest JSON Lines record accepted.
+const maxImportLine = 1 << 20
+
+// importColumns are the CSV header names, which are also the JSON Lines keys.
+var importColumns = []string{"name", "location", "country", "description", "image_url", "visitors_last_year", "is_private", "latitude", "longitude"}
+
+var requiredImportColumns = []string{"name", "location", "country"}
+
+// DestinationImportRow is one parsed record. Location and Country name the location the
+// destination belongs to; Destination has no LocationID yet. Errors holds the problems
+// found while parsing, and a row with errors is rejected without touching the database.
+type DestinationImportRow struct {
+	Line        int
+	Destination entities.Destination
+	Location    string
+	Country     string
+	Errors      []apperrors.FieldError
+}
+
+type destinationImportRecord struct {
+	Name             string   `json:"name"`
+	Location         string   `json:"location"`
+	Country          string   `json:"country"`
+	Description      string   `json:"description"`
+	ImageUrl         string   `json:"image_url"`
+	VisitorsLastYear int      `json:"visitors_last_year"`
+	IsPrivate        bool     `json:"is_private"`
+	Latitude         *float64 `json:"latitude"`
+	Longitude        *float64 `json:"longitude"`
+}
+
+func (record destinationImportRecord) row(line int) DestinationImportRow {
+	return DestinationImportRow{
+		Line: line,
+		Destination: entities.Destination{
+			Name:             strings.TrimSpace(record.Name),
+			ImageUrl:         strings.TrimSpace(record.ImageUrl),
+			Description:      strings.TrimSpace(record.Description),
+			VisitorsLastYear: record.VisitorsLastYear,
+			IsPrivate:        record.IsPrivate,
+			Latitude:         record.Latitude,
+			Longitude:        record.Longitude,
+		},
+		Location: strings.TrimSpace(record.Location),
+		Country:  strings.TrimSpace(record.Country),
+	}
+}
+
+func ParseImportFormat(s string) (ImportFormat, error) {
+	switch strings.ToLower(s) {
+	case "csv":
+		return ImportCSV, nil
+	case "jsonl", "ndjson":
+		return ImportJSONL, nil
+	default:
+		return "", apperrors.Validation(fmt.Sprintf("unsupported import format %q, expected csv or jsonl", s))
+	}
+}
+
+// ParseDestinationImport reads every row of r. Problems with a single row are recorded on
+// that row; an unreadable file, a bad CSV header or too many rows fail the whole import.
+func ParseDestinationImport(r io.Reader, format ImportFormat) ([]DestinationImportRow, error) {
+	var rows []DestinationImportRow
+	var err error
+	switch format {
+	case ImportCSV:
+		rows, err = parseImportCSV(r)
+	case ImportJSONL:
+		rows, err = parseImportJSONL(r)
+	default:
+		return nil, apperrors.Validation(fmt.Sprintf("unsupported import format %q, expected csv or jsonl", format))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, apperrors.Validation("the import contains no rows")
+	}
+	return rows, nil
+}
+
+func parseImportCSV(r io.Reader) ([]DestinationImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, apperrors.Validation("the import contains no rows")
+	}
+	if err != nil {
+		return nil, importFileError(err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !knownImportColumn(name) {
+			return nil, apperrors.Validation(fmt.Sprintf("unknown column %q, expected some of %s", name, strings.Join(importColumns, ", ")))
+		}
+		if _, ok := columns[name]; ok {
+			return nil, apperrors.Validation(fmt.Sprintf("column %q appears more than once", name))
+		}
+		columns[name] = i
+	}
+	for _, name := range requiredImportColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, apperrors.Validation(fmt.Sprintf("missing required column %q", name))
+		}
+	}
+
+	rows := make([]DestinationImportRow, 0)
+	for {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil && !errors.Is(err, csv.ErrFieldCount) {
+			return nil, importFileError(err)
+		}
+		line, _ := reader.FieldPos(0)
+		if len(rows) == MaxImportRows {
+			return nil, tooManyImportRows()
+		}
+
+		if err != nil {
+			rows = append(rows, DestinationImportRow{Line: line, Errors: []apperrors.FieldError{{
+				Rule:    "columns",
+				Message: fmt.Sprintf("expected %d fields, found %d", len(header), len(fields)),
+			}}})
+			continue
+		}
+		rows = append(rows, csvImportRow(line, columns, fields))
+	}
+	return rows, nil
+}
+
+func csvImportRow(line int, columns map[string]int, fields []string) DestinationImportRow {
+	value := func(name string) string {
+		if i, ok := columns[name]; ok {
+			return strings.TrimSpace(fields[i])
+		}
+		return ""
+	}
+
+	var fieldErrors []apperrors.FieldError
+	record := destinationImportRecord{
+		Name:        value("name"),
+		Location:    value("location"),
+		Country:     value("country"),
+		Description: value("description"),
+		ImageUrl:    value("image_url"),
+	}
+
+	if s := value("visitors_last_year"); s != "" {
+		visitors, err := strconv.Atoi(s)
+		if err != nil {
+			fieldErrors = append(fieldErrors, importTypeError("visitors_last_year", "a whole number"))
+		}
+		record.VisitorsLastYear = visitors
+	}
+	if s := value("is_private"); s != "" {
+		isPrivate, err := strconv.ParseBool(s)
+		if err != nil {
+			fieldErrors = append(fieldErrors, importTypeError("is_private", "true or false"))
+		}
+		record.IsPrivate = isPrivate
+	}
+	for _, coordinate := range []struct {
+		name   string
+		target **float64
+	}{{"latitude", &record.Latitude}, {"longitude", &record.Longitude}} {
+		if s := value(coordinate.name); s != "" {
+			number, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				fieldErrors = append(fieldErrors, importTypeError(coordinate.name, "a number"))
+				continue
+			}
+			*coordinate.target = &number
+		}
+	}
+
+	row := record.row(line)
+	row.Errors = fieldErrors
+	return row
+}
+
+func parseImportJSONL(r io.Reader) ([]DestinationImportRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxImportLine)
+
+	rows := make([]DestinationImportRow, 0)
+	line := 0
+	for scanner.Scan() {
+		line++
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		if len(rows) == MaxImportRows {
+			return nil, tooManyImportRows()
+		}
+
+		var record destinationImportRecord
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&record); err != nil {
+			rows = append(rows, DestinationImportRow{Line: line, Errors: []apperrors.FieldError{jsonImportError(err)}})
+			continue
+		}
+		rows = append(rows, record.row(line))
+	}
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return nil, apperrors.Validation(fmt.Sprintf("line %d is longer than %d bytes", line+1, maxImportLine))
+		}
+		return nil, importFileError(err)
+	}
+	return rows, nil
+}
+
+// jsonImportError describes a record that could not be decoded without exposing Go type names.
+func jsonImportError(err error) apperrors.FieldError {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		switch typeErr.Type.Kind() {
+		case reflect.Int:
+			return importTypeError(typeErr.Field, "a whole number")
+		case reflect.Bool:
+			return importTypeError(typeErr.Field, "true or false")
+		case reflect.Float64, reflect.Pointer:
+			return importTypeError(typeErr.Field, "a number")
+		default:
+			return importTypeError(typeErr.Field, "a string")
+		}
+	}
+	return apperrors.FieldError{Rule: "json", Message: strings.TrimPrefix(err.Error(), "json: ")}
+}
+
+func knownImportColumn(name string) bool {
+	for _, column := range importColumns {
+		if column == name {
+			return true
+		}
+	}
+	return false
+}
+
+func importTypeError(field string, expected string) apperrors.FieldError {
+	return apperrors.FieldError{Field: field, Rule: "type", Message: "must be " + expected}
+}
+
+func tooManyImportRows() error {
+	return apperrors.Validation(fmt.Sprintf("an import is limited to %d rows", MaxImportRows))
+}
+
+// importFileError reports a file that could not be parsed at all. Read errors that are not
+// about the content are passed on unchanged.
+func importFileError(err error) error {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return &apperrors.Error{Kind: apperrors.KindValidation, Message: "malformed CSV: " + err.Error(), Err: err}
+	}
+	return err
+}
@@ -1,23 +1,28 @@
 package services
 
 import (
+	"Trip-Trove-API/domain/apperrors"
 	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/events"
 	"Trip-Trove-API/domain/repositories"
-	"errors"
 	"fmt"
 )
 
 type ILocationService interface {
 	AllLocations() ([]entities.Location, error)
+	SearchLocations(term string, limit int) ([]entities.LocationSearchResult, error)
 	LocationByID(idStr string) (*entities.Location, error)
-	CreateLocation(location entities.Location) (entities.Location, error)
-	DeleteLocation(idStr string) (entities.Location, error)
-	UpdateLocation(idStr string, location entities.Location) (entities.Location, error)
+	CreateLocation(location entities.Location, actor entities.Actor) (entities.Location, error)
+	DeleteLocation(idStr string, actor entities.Actor) (entities.Location, error)
+	UpdateLocation(idStr string, location entities.Location, actor entities.Actor) (entities.Location, error)
 }
 
+var ErrLocationHasOthersDestinations = apperrors.Conflict("the location has destinations you may not delete")
+
 type LocationService struct {
-	Repo            repositories.LocationRepository
-	DestinationRepo repositories.DestinationRepository
+	Repo       repositories.LocationRepository
+	UnitOfWork repositories.UnitOfWork
+	Events     events.Publisher
 }
 
 func (service *LocationService) AllLocations() ([]entities.Location, error) {
@@ -28,10 +33,23 @@ func (service *LocationService) AllLocations() ([]entities.Location, error) {
 	return locations, nil
 }
 
+func (service *LocationService) SearchLocations(term string, limit int) ([]entities.LocationSearchResult, error) {
+	term, limit, err := normalizeSearch(term, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := service.Repo.SearchLocations(term, limit)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
 func (service *LocationService) LocationByID(idStr string) (*entities.Location, error) {
 	var id uint
 	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
-		return nil, errors.New("invalid ID format")
+		return nil, apperrors.ErrInvalidID
 	}
 
 	location, err := service.Repo.LocationByID(id)
@@ -42,40 +60,82 @@ func (service *LocationService) LocationByID(idStr string) (*entities.Location,
 	return location, nil
 }
 
-func (service *LocationService) CreateLocation(location entities.Location) (entities.Location, error) {
+func (service *LocationService) CreateLocation(location entities.Location, actor entities.Actor) (entities.Location, error) {
+	if !actor.Can(entities.LocationsWrite) {
+		return entities.Location{}, ErrForbidden
+	}
+
 	location, err := service.Repo.CreateLocation(location)
 	if err != nil {
 		return entities.Location{}, err
 	}
+	service.publish(events.LocationCreated, location, actor)
 	return location, nil
 }
 
-func (service *LocationService) DeleteLocation(idStr string) (entities.Location, error) {
+func (service *LocationService) DeleteLocation(idStr string, actor entities.Actor) (entities.Location, error) {
 	var id uint
 	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
-		return entities.Location{}, errors.New("invalid ID format")
+		return entities.Location{}, apperrors.ErrInvalidID
 	}
-
-	if err := service.DestinationRepo.DeleteDestinationsByLocationID(id); err != nil {
-		return entities.Location{}, err
+	if !actor.Can(entities.LocationsDelete) {
+		return entities.Location{}, ErrForbidden
 	}
 
-	location, err := service.Repo.DeleteLocation(id)
+	var location entities.Location
+	var destinations []entities.Destination
+	err := service.UnitOfWork.Do(func(repos repositories.Repositories) error {
+		var err error
+		destinations, err = repos.Destinations.DestinationsForLocation(id)
+		if err != nil {
+			return err
+		}
+		// The cascade may only remove what DeleteDestination would let the actor remove.
+		for _, destination := range destinations {
+			if !actor.Can(entities.DestinationsDelete) || !destination.EditableBy(actor) {
+				return ErrLocationHasOthersDestinations
+			}
+		}
+		if err := repos.Destinations.DeleteDestinationsByLocationID(id); err != nil {
+			return err
+		}
+
+		location, err = repos.Locations.DeleteLocation(id)
+		return err
+	})
 	if err != nil {
 		return entities.Location{}, err
 	}
+
+	// The destinations went with the location; consumers hear about each of them first.
+	if service.Events != nil {
+		for _, destination := range destinations {
+			service.Events.Publish(events.NewDestinationEvent(events.DestinationDeleted, destination, actor))
+		}
+	}
+	service.publish(events.LocationDeleted, location, actor)
 	return location, nil
 }
 
-func (service *LocationService) UpdateLocation(idStr string, location entities.Location) (entities.Location, error) {
+func (service *LocationService) UpdateLocation(idStr string, location entities.Location, actor entities.Actor) (entities.Location, error) {
 	var id uint
 	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
-		return entities.Location{}, errors.New("invalid ID format")
+		return entities.Location{}, apperrors.ErrInvalidID
+	}
+	if !actor.Can(entities.LocationsWrite) {
+		return entities.Location{}, ErrForbidden
 	}
 
 	location, err := service.Repo.UpdateLocation(id, location)
 	if err != nil {
 		return entities.Location{}, err
 	}
+	service.publish(events.LocationUpdated, location, actor)
 	return location, nil
 }
+
+func (service *LocationService) publish(eventType events.Type, location entities.Location, actor entities.Actor) {
+	if service.Events != nil {
+		service.Events.Publish(events.NewLocationEvent(eventType, location, actor))
+	}
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/repositories"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrOwnRoleChange      = apperrors.Forbidden("you cannot change your own role")
+	ErrAdminAlreadyExists = apperrors.Conflict("an admin already exists; grant roles through the API instead")
+)
+
+// ChangeRole sets the role of the user idStr and records who changed it and why. It needs the
+// users:roles permission, which the policy always grants Admins. Nobody may change their own
+// role, so there is always at least one Admin left, nor grant or take away a role with a
+// permission they lack themselves. A user whose new role loses a permission is logged out
+// everywhere so their tokens stop carrying the old role.
+func (service *UserService) ChangeRole(idStr string, role entities.AccessType, reason string, actor entities.Actor) (entities.User, error) {
+	var id uint
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return entities.User{}, apperrors.ErrInvalidID
+	}
+	if !actor.Can(entities.UsersRoles) {
+		return entities.User{}, ErrForbidden
+	}
+	if id == actor.UserID {
+		return entities.User{}, ErrOwnRoleChange
+	}
+	policy := entities.ActivePolicy()
+	if !policy.Includes(actor.Role, role) {
+		return entities.User{}, ErrForbidden
+	}
+
+	var user *entities.User
+	var oldRole entities.AccessType
+	err := service.UnitOfWork.Do(func(repos repositories.Repositories) error {
+		var err error
+		user, err = repos.Users.UserByID(id)
+		if err != nil {
+			return err
+		}
+		oldRole = user.Role
+		if !policy.Includes(actor.Role, oldRole) {
+			return ErrForbidden
+		}
+		if oldRole == role {
+			return nil
+		}
+
+		changedByID := actor.UserID
+		if err := setRole(repos.Users, *user, role, &changedByID, reason); err != nil {
+			return err
+		}
+		user.Role = role
+		return nil
+	})
+	if err != nil {
+		return entities.User{}, err
+	}
+
+	if !policy.Includes(role, oldRole) {
+		if err := service.endSessions(id); err != nil {
+			return entities.User{}, err
+		}
+	}
+	return *user, nil
+}
+
+func (service *UserService) RoleChanges(idStr string) ([]entities.RoleChange, error) {
+	var id uint
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return nil, apperrors.ErrInvalidID
+	}
+
+	if _, err := service.Repo.UserByID(id); err != nil {
+		return nil, err
+	}
+	return service.Repo.RoleChanges(id)
+}
+
+// BootstrapAdmin makes the first Admin of a database. The user with the email of admin is
+// promoted, or admin is registered with a verified email when there is none. It fails once
+// any Admin exists. created reports whether a new user was registered.
+func (service *UserService) BootstrapAdmin(admin entities.User) (user entities.User, created bool, err error) {
+	err = service.UnitOfWork.Do(func(repos repositories.Repositories) error {
+		admins, err := repos.Users.CountUsersByRole(entities.Admin)
+		if err != nil {
+			return err
+		}
+		if admins > 0 {
+			return ErrAdminAlreadyExists
+		}
+
+		existing, err := repos.Users.UserByEmail(admin.Email)
+		switch {
+		case err == nil:
+			user = *existing
+		case apperrors.KindOf(err) == apperrors.KindNotFound:
+			user, err = repos.Users.Register(admin)
+			if err != nil {
+				return err
+			}
+			verifiedAt := time.Now()
+			if err := repos.Users.SetEmailVerifiedAt(user.ID, &verifiedAt); err != nil {
+				return err
+			}
+			user.EmailVerifiedAt = &verifiedAt
+			created = true
+		default:
+			return err
+		}
+
+		if err := setRole(repos.Users, user, entities.Admin, nil, "bootstrap"); err != nil {
+			return err
+		}
+		user.Role = entities.Admin
+		return nil
+	})
+	if err != nil {
+		return entities.User{}, false, err
+	}
+	return user, created, nil
+}
+
+func setRole(users repositories.UserRepository, user entities.User, role entities.AccessType, changedByID *uint, reason string) error {
+	if err := users.UpdateRole(user.ID, role); err != nil {
+		return err
+	}
+	_, err := users.CreateRoleChange(entities.RoleChange{
+		UserID:      user.ID,
+		ChangedByID: changedByID,
+		OldRole:     user.Role,
+		NewRole:     role,
+		Reason:      reason,
+	})
+	return err
+}
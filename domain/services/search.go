@@ -0,0 +1,23 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+func normalizeSearch(term string, limit int) (string, int, error) {
+	term = strings.TrimSpace(term)
+	if utf8.RuneCountInString(term) < MinSearchTermRunes {
+		return "", 0, fmt.Errorf("%w: search term must be at least %d characters", ErrInvalidQuery, MinSearchTermRunes)
+	}
+
+	if limit == 0 {
+		limit = DefaultSearchLimit
+	}
+	if limit < 1 || limit > MaxSearchLimit {
+		return "", 0, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidQuery, MaxSearchLimit)
+	}
+
+	return term, limit, nil
+}
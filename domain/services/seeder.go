@@ -0,0 +1,194 @@
+package services
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/repositories"
+	"fmt"
+	"github.com/jaswdr/faker"
+	"golang.org/x/crypto/bcrypt"
+	"math/rand"
+	"time"
+)
+
+const (
+	DefaultSeed             = 1
+	DefaultSeedBatchSize    = 500
+	DefaultSeedUserPassword = "Seed-password-1"
+)
+
+// SeedOptions describes what Seeder.Seed inserts. The same options always produce the
+// same data.
+type SeedOptions struct {
+	Locations               int
+	DestinationsPerLocation int
+	UsersPerRole            int
+	Seed                    int64
+	BatchSize               int
+	Password                string
+}
+
+type SeedResult struct {
+	Locations    int
+	Destinations int
+	Users        int
+}
+
+// Seeder bulk-inserts fake locations, destinations and users. Every batch is written in
+// its own unit of work, so a failure keeps the batches already written.
+type Seeder struct {
+	UnitOfWork repositories.UnitOfWork
+}
+
+// Birth dates are fixed rather than relative to today so reruns stay identical.
+var (
+	adultsBornFrom  = time.Date(1950, 1, 1, 0, 0, 0, 0, time.UTC)
+	adultsBornUntil = time.Date(2005, 12, 31, 0, 0, 0, 0, time.UTC)
+)
+
+var seedRoles = []struct {
+	name string
+	role entities.AccessType
+}{
+	{"admin", entities.Admin},
+	{"manager", entities.Manager},
+	{"user", entities.NormalUser},
+}
+
+func (seeder *Seeder) Seed(options SeedOptions) (SeedResult, error) {
+	if options.Locations < 0 || options.DestinationsPerLocation < 0 || options.UsersPerRole < 0 || options.BatchSize < 0 {
+		return SeedResult{}, fmt.Errorf("%w: counts must not be negative", ErrInvalidQuery)
+	}
+	if options.BatchSize == 0 {
+		options.BatchSize = DefaultSeedBatchSize
+	}
+	if options.Password == "" {
+		options.Password = DefaultSeedUserPassword
+	}
+
+	f := faker.NewWithSeed(rand.NewSource(options.Seed))
+	names := uniqueNames{}
+	var result SeedResult
+
+	users, err := seeder.seedUsers(f, options)
+	if err != nil {
+		return result, err
+	}
+	result.Users = users
+
+	for written := 0; written < options.Locations; written += options.BatchSize {
+		batch := min(options.BatchSize, options.Locations-written)
+
+		locations := make([]entities.Location, 0, batch)
+		for i := 0; i < batch; i++ {
+			location := FakeLocation(f)
+			location.Name = names.claim("location", location.Name, 30)
+			locations = append(locations, location)
+		}
+
+		destinations := 0
+		err := seeder.UnitOfWork.Do(func(repos repositories.Repositories) error {
+			created, err := repos.Locations.CreateLocations(locations)
+			if err != nil {
+				return err
+			}
+
+			pending := make([]entities.Destination, 0, options.BatchSize)
+			for _, location := range created {
+				for i := 0; i < options.DestinationsPerLocation; i++ {
+					destination := FakeDestination(f, location.ID)
+					destination.Name = names.claim("destination", destination.Name, 50)
+					pending = append(pending, destination)
+
+					if len(pending) == options.BatchSize {
+						if _, err := repos.Destinations.CreateDestinations(pending); err != nil {
+							return err
+						}
+						destinations += len(pending)
+						pending = pending[:0]
+					}
+				}
+			}
+			if len(pending) > 0 {
+				if _, err := repos.Destinations.CreateDestinations(pending); err != nil {
+					return err
+				}
+				destinations += len(pending)
+			}
+			return nil
+		})
+		if err != nil {
+			return result, err
+		}
+
+		result.Locations += batch
+		result.Destinations += destinations
+	}
+
+	return result, nil
+}
+
+// seedUsers creates admin1, manager1, user1 and so on, all sharing one password so it is
+// only hashed once.
+func (seeder *Seeder) seedUsers(f faker.Faker, options SeedOptions) (int, error) {
+	if options.UsersPerRole == 0 {
+		return 0, nil
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(options.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return 0, err
+	}
+
+	// Seeded addresses are not real mailboxes, so they start out verified.
+	verifiedAt := time.Now()
+	users := make([]entities.User, 0, len(seedRoles)*options.UsersPerRole)
+	for roleIndex, seedRole := range seedRoles {
+		for i := 1; i <= options.UsersPerRole; i++ {
+			username := fmt.Sprintf("%s%d", seedRole.name, i)
+			users = append(users, entities.User{
+				Username:        username,
+				Password:        string(hashedPassword),
+				Email:           username + "@example.com",
+				FirstName:       f.Person().FirstName(),
+				LastName:        f.Person().LastName(),
+				PhoneNumber:     fmt.Sprintf("+4070%d%06d", roleIndex, i),
+				DateOfBirth:     f.Time().TimeBetween(adultsBornFrom, adultsBornUntil).Format(entities.DateLayout),
+				Address:         f.Address().Address(),
+				Role:            seedRole.role,
+				EmailVerifiedAt: &verifiedAt,
+			})
+		}
+	}
+
+	written := 0
+	for start := 0; start < len(users); start += options.BatchSize {
+		batch := users[start:min(start+options.BatchSize, len(users))]
+		err := seeder.UnitOfWork.Do(func(repos repositories.Repositories) error {
+			_, err := repos.Users.CreateUsers(batch)
+			return err
+		})
+		if err != nil {
+			return written, err
+		}
+		written += len(batch)
+	}
+	return written, nil
+}
+
+// uniqueNames suffixes names that were already handed out, since fake names repeat and
+// location and destination names are unique.
+type uniqueNames map[string]int
+
+func (names uniqueNames) claim(kind string, name string, maxLength int) string {
+	key := kind + "\x00" + name
+	names[key]++
+	if names[key] == 1 {
+		return name
+	}
+
+	suffix := fmt.Sprintf(" %d", names[key])
+	if len(name)+len(suffix) > maxLength {
+		name = name[:maxLength-len(suffix)]
+	}
+	return names.claim(kind, name+suffix, maxLength)
+}
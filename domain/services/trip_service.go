@@ -0,0 +1,351 @@
+package services
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/repositories"
+	"fmt"
+	"sort"
+	"time"
+)
+
+type ITripService interface {
+	AllTrips(actor entities.Actor) ([]entities.Trip, error)
+	TripByID(idStr string, actor entities.Actor) (*entities.Trip, error)
+	CreateTrip(trip entities.Trip, actor entities.Actor) (entities.Trip, error)
+	UpdateTrip(idStr string, trip entities.Trip, actor entities.Actor) (entities.Trip, error)
+	DeleteTrip(idStr string, actor entities.Actor) (entities.Trip, error)
+	AddStop(idStr string, stop entities.TripStop, actor entities.Actor) (entities.TripStop, error)
+	DeleteStop(idStr string, stopIDStr string, actor entities.Actor) (entities.TripStop, error)
+	ReorderStops(idStr string, order []entities.TripStopOrder, actor entities.Actor) (*entities.Trip, error)
+	TripSummary(idStr string, actor entities.Actor) (*TripSummary, error)
+}
+
+type TripService struct {
+	Repo            repositories.TripRepository
+	DestinationRepo repositories.DestinationRepository
+	LocationRepo    repositories.LocationRepository
+}
+
+type TripLocation struct {
+	ID      uint   `json:"id"`
+	Name    string `json:"name"`
+	Country string `json:"country"`
+}
+
+type TripSummary struct {
+	TripID       uint           `json:"trip_id"`
+	Name         string         `json:"name"`
+	StartDate    string         `json:"start_date"`
+	EndDate      string         `json:"end_date"`
+	Days         int            `json:"days"`
+	StopCount    int            `json:"stop_count"`
+	Countries    []string       `json:"countries"`
+	Locations    []TripLocation `json:"locations"`
+	Destinations []uint         `json:"destinations"`
+}
+
+var ErrInvalidTrip = apperrors.Validation("invalid trip")
+
+var _ ITripService = &TripService{}
+
+func (service *TripService) AllTrips(actor entities.Actor) ([]entities.Trip, error) {
+	var trips []entities.Trip
+	var err error
+
+	if actor.Can(entities.TripsManageAny) {
+		trips, err = service.Repo.AllTrips()
+	} else {
+		trips, err = service.Repo.TripsByOwner(actor.UserID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if trips == nil {
+		trips = []entities.Trip{}
+	}
+	return trips, nil
+}
+
+func (service *TripService) TripByID(idStr string, actor entities.Actor) (*entities.Trip, error) {
+	var id uint
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return nil, apperrors.ErrInvalidID
+	}
+
+	trip, err := service.Repo.TripByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if trip.OwnerID != actor.UserID && !actor.Can(entities.TripsManageAny) {
+		return nil, apperrors.NotFound("trip not found")
+	}
+
+	return trip, nil
+}
+
+func (service *TripService) CreateTrip(trip entities.Trip, actor entities.Actor) (entities.Trip, error) {
+	if err := RequireVerifiedEmail(actor); err != nil {
+		return entities.Trip{}, err
+	}
+
+	days, err := tripDays(trip)
+	if err != nil {
+		return entities.Trip{}, err
+	}
+
+	positions := make(map[int]int)
+	for i := range trip.Stops {
+		stop := &trip.Stops[i]
+		if err := service.validateStop(*stop, days, actor); err != nil {
+			return entities.Trip{}, err
+		}
+		stop.ID = 0
+		stop.Position = positions[stop.Day]
+		positions[stop.Day]++
+	}
+
+	trip.ID = 0
+	trip.OwnerID = actor.UserID
+
+	trip, err = service.Repo.CreateTrip(trip)
+	if err != nil {
+		return entities.Trip{}, err
+	}
+	return trip, nil
+}
+
+func (service *TripService) UpdateTrip(idStr string, trip entities.Trip, actor entities.Actor) (entities.Trip, error) {
+	existing, err := service.TripByID(idStr, actor)
+	if err != nil {
+		return entities.Trip{}, err
+	}
+
+	days, err := tripDays(trip)
+	if err != nil {
+		return entities.Trip{}, err
+	}
+	for _, stop := range existing.Stops {
+		if stop.Day > days {
+			return entities.Trip{}, fmt.Errorf("%w: stop %d is on day %d, after the new end date", ErrInvalidTrip, stop.ID, stop.Day)
+		}
+	}
+
+	updated, err := service.Repo.UpdateTrip(existing.ID, trip)
+	if err != nil {
+		return entities.Trip{}, err
+	}
+	return updated, nil
+}
+
+func (service *TripService) DeleteTrip(idStr string, actor entities.Actor) (entities.Trip, error) {
+	existing, err := service.TripByID(idStr, actor)
+	if err != nil {
+		return entities.Trip{}, err
+	}
+
+	trip, err := service.Repo.DeleteTrip(existing.ID)
+	if err != nil {
+		return entities.Trip{}, err
+	}
+	return trip, nil
+}
+
+func (service *TripService) AddStop(idStr string, stop entities.TripStop, actor entities.Actor) (entities.TripStop, error) {
+	if err := RequireVerifiedEmail(actor); err != nil {
+		return entities.TripStop{}, err
+	}
+
+	trip, err := service.TripByID(idStr, actor)
+	if err != nil {
+		return entities.TripStop{}, err
+	}
+
+	days, err := tripDays(*trip)
+	if err != nil {
+		return entities.TripStop{}, err
+	}
+	if err := service.validateStop(stop, days, actor); err != nil {
+		return entities.TripStop{}, err
+	}
+
+	stop.ID = 0
+	stop.TripID = trip.ID
+	stop.Position = 0
+	for _, existing := range trip.Stops {
+		if existing.Day == stop.Day && existing.Position >= stop.Position {
+			stop.Position = existing.Position + 1
+		}
+	}
+
+	stop, err = service.Repo.AddStop(stop)
+	if err != nil {
+		return entities.TripStop{}, err
+	}
+	return stop, nil
+}
+
+func (service *TripService) DeleteStop(idStr string, stopIDStr string, actor entities.Actor) (entities.TripStop, error) {
+	trip, err := service.TripByID(idStr, actor)
+	if err != nil {
+		return entities.TripStop{}, err
+	}
+
+	var stopID uint
+	if _, err := fmt.Sscanf(stopIDStr, "%d", &stopID); err != nil {
+		return entities.TripStop{}, apperrors.ErrInvalidID
+	}
+
+	stop, err := service.Repo.DeleteStop(trip.ID, stopID)
+	if err != nil {
+		return entities.TripStop{}, err
+	}
+	return stop, nil
+}
+
+func (service *TripService) ReorderStops(idStr string, order []entities.TripStopOrder, actor entities.Actor) (*entities.Trip, error) {
+	trip, err := service.TripByID(idStr, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	days, err := tripDays(*trip)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(order) != len(trip.Stops) {
+		return nil, fmt.Errorf("%w: the new order must list every stop of the trip exactly once", ErrInvalidTrip)
+	}
+	known := make(map[uint]bool, len(trip.Stops))
+	for _, stop := range trip.Stops {
+		known[stop.ID] = true
+	}
+
+	positions := make(map[int]int)
+	stops := make([]entities.TripStop, 0, len(order))
+	for _, item := range order {
+		if !known[item.StopID] {
+			return nil, fmt.Errorf("%w: the new order must list every stop of the trip exactly once", ErrInvalidTrip)
+		}
+		delete(known, item.StopID)
+
+		if item.Day < 1 || item.Day > days {
+			return nil, fmt.Errorf("%w: day %d is outside the trip dates", ErrInvalidTrip, item.Day)
+		}
+
+		stop := entities.TripStop{Day: item.Day, Position: positions[item.Day]}
+		stop.ID = item.StopID
+		stops = append(stops, stop)
+		positions[item.Day]++
+	}
+
+	if err := service.Repo.UpdateStopOrder(trip.ID, stops); err != nil {
+		return nil, err
+	}
+
+	return service.Repo.TripByID(trip.ID)
+}
+
+func (service *TripService) TripSummary(idStr string, actor entities.Actor) (*TripSummary, error) {
+	trip, err := service.TripByID(idStr, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	days, err := tripDays(*trip)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &TripSummary{
+		TripID:       trip.ID,
+		Name:         trip.Name,
+		StartDate:    trip.StartDate,
+		EndDate:      trip.EndDate,
+		Days:         days,
+		StopCount:    len(trip.Stops),
+		Countries:    []string{},
+		Locations:    []TripLocation{},
+		Destinations: []uint{},
+	}
+
+	seenDestinations := make(map[uint]bool)
+	seenLocations := make(map[uint]bool)
+	seenCountries := make(map[string]bool)
+
+	for _, stop := range trip.Stops {
+		if seenDestinations[stop.DestinationID] {
+			continue
+		}
+		seenDestinations[stop.DestinationID] = true
+
+		destination, err := service.DestinationRepo.DestinationByID(stop.DestinationID)
+		if err != nil {
+			// Destinations can be deleted after being planned; the summary skips them.
+			if apperrors.KindOf(err) == apperrors.KindNotFound {
+				continue
+			}
+			return nil, err
+		}
+		if !destination.VisibleTo(actor) {
+			continue
+		}
+		summary.Destinations = append(summary.Destinations, destination.ID)
+
+		if seenLocations[destination.LocationID] {
+			continue
+		}
+		seenLocations[destination.LocationID] = true
+
+		location, err := service.LocationRepo.LocationByID(destination.LocationID)
+		if err != nil {
+			if apperrors.KindOf(err) == apperrors.KindNotFound {
+				continue
+			}
+			return nil, err
+		}
+		summary.Locations = append(summary.Locations, TripLocation{ID: location.ID, Name: location.Name, Country: location.Country})
+
+		if !seenCountries[location.Country] {
+			seenCountries[location.Country] = true
+			summary.Countries = append(summary.Countries, location.Country)
+		}
+	}
+
+	sort.Strings(summary.Countries)
+
+	return summary, nil
+}
+
+func (service *TripService) validateStop(stop entities.TripStop, days int, actor entities.Actor) error {
+	if stop.Day < 1 || stop.Day > days {
+		return fmt.Errorf("%w: day %d is outside the trip dates", ErrInvalidTrip, stop.Day)
+	}
+
+	destination, err := service.DestinationRepo.DestinationByID(stop.DestinationID)
+	if err != nil || !destination.VisibleTo(actor) {
+		return fmt.Errorf("%w: destination %d does not exist", ErrInvalidTrip, stop.DestinationID)
+	}
+
+	return nil
+}
+
+func tripDays(trip entities.Trip) (int, error) {
+	start, err := time.Parse(entities.DateLayout, trip.StartDate)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid start date", ErrInvalidTrip)
+	}
+	end, err := time.Parse(entities.DateLayout, trip.EndDate)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid end date", ErrInvalidTrip)
+	}
+
+	if end.Before(start) {
+		return 0, fmt.Errorf("%w: end date is before start date", ErrInvalidTrip)
+	}
+
+	return int(end.Sub(start).Hours()/24) + 1, nil
+}
@@ -1,16 +1,29 @@
 package services
 
 import (
+	"Trip-Trove-API/domain/apperrors"
 	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/mail"
 	"Trip-Trove-API/domain/repositories"
-	"errors"
+	"Trip-Trove-API/utils"
 	"fmt"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+	"time"
 )
 
 type UserService struct {
-	Repo repositories.UserRepository
+	Repo       repositories.UserRepository
+	TokenRepo  repositories.TokenRepository
+	Jwt        utils.JwtWrapper
+	UnitOfWork repositories.UnitOfWork
+	Mailer     mail.Mailer
+	// LinkBaseURL is where the links in account emails point, e.g. the web app's origin.
+	LinkBaseURL string
 }
 
+var ErrInvalidRefreshToken = apperrors.Unauthorized("invalid refresh token")
+
 func (service *UserService) AllUsers() ([]entities.User, error) {
 	users, err := service.Repo.AllUsers()
 	if err != nil {
@@ -22,7 +35,7 @@ func (service *UserService) AllUsers() ([]entities.User, error) {
 func (service *UserService) UserByID(idStr string) (*entities.User, error) {
 	var id uint
 	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
-		return nil, errors.New("invalid ID format")
+		return nil, apperrors.ErrInvalidID
 	}
 
 	user, err := service.Repo.UserByID(id)
@@ -34,25 +47,172 @@ func (service *UserService) UserByID(idStr string) (*entities.User, error) {
 }
 
 func (service *UserService) Register(user entities.User) (entities.User, error) {
+	user.EmailVerifiedAt = nil
 	user, err := service.Repo.Register(user)
 	if err != nil {
 		return entities.User{}, err
 	}
+	service.sendEmailVerificationQuietly(user)
 	return user, nil
 }
 
 func (service *UserService) Login(loginData entities.LoginRequest) (entities.LoginResponse, error) {
-	response, err := service.Repo.Login(loginData)
+	user, err := service.Repo.Authenticate(loginData)
+	if err != nil {
+		return entities.LoginResponse{}, err
+	}
+
+	familyID, err := utils.RandomToken(16)
+	if err != nil {
+		return entities.LoginResponse{}, err
+	}
+
+	return service.issueTokens(*user, familyID)
+}
+
+func (service *UserService) Refresh(refreshToken string) (entities.LoginResponse, error) {
+	stored, err := service.TokenRepo.RefreshTokenByHash(utils.HashToken(refreshToken))
+	if err != nil {
+		return entities.LoginResponse{}, ErrInvalidRefreshToken
+	}
+
+	now := time.Now()
+	if stored.RevokedAt != nil {
+		return entities.LoginResponse{}, service.refreshTokenReused(stored.FamilyID)
+	}
+	if !stored.IsActive(now) {
+		return entities.LoginResponse{}, ErrInvalidRefreshToken
+	}
+
+	user, err := service.Repo.UserByID(stored.UserID)
+	if err != nil {
+		return entities.LoginResponse{}, ErrInvalidRefreshToken
+	}
+
+	claimed, err := service.TokenRepo.RevokeRefreshToken(stored.ID)
 	if err != nil {
 		return entities.LoginResponse{}, err
 	}
-	return response, nil
+	if !claimed {
+		// Another request rotated the token after it was read: it was presented twice.
+		return entities.LoginResponse{}, service.refreshTokenReused(stored.FamilyID)
+	}
+
+	return service.issueTokens(*user, stored.FamilyID)
+}
+
+func (service *UserService) Logout(userID uint, accessTokenID string, accessExpiresAt int64, refreshToken string) error {
+	if accessTokenID != "" {
+		revoked := entities.RevokedToken{TokenID: accessTokenID, UserID: userID, ExpiresAt: time.Unix(accessExpiresAt, 0)}
+		if err := service.TokenRepo.RevokeAccessTokens([]entities.RevokedToken{revoked}); err != nil {
+			return err
+		}
+	}
+
+	if refreshToken != "" {
+		stored, err := service.TokenRepo.RefreshTokenByHash(utils.HashToken(refreshToken))
+		if err == nil && stored.UserID == userID {
+			if err := service.revokeFamily(stored.FamilyID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return service.TokenRepo.DeleteExpiredRevocations(time.Now())
+}
+
+func (service *UserService) RevokeAllSessions(idStr string) error {
+	var id uint
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return apperrors.ErrInvalidID
+	}
+
+	if _, err := service.Repo.UserByID(id); err != nil {
+		return err
+	}
+
+	return service.endSessions(id)
+}
+
+// endSessions revokes every refresh token of userID and denies the access tokens issued
+// with them.
+func (service *UserService) endSessions(userID uint) error {
+	tokens, err := service.TokenRepo.RevokeUserRefreshTokens(userID)
+	if err != nil {
+		return err
+	}
+	return service.denyAccessTokens(tokens)
+}
+
+// refreshTokenReused ends the session of a rotated token that was presented again, since that
+// means it was copied.
+func (service *UserService) refreshTokenReused(familyID string) error {
+	if err := service.revokeFamily(familyID); err != nil {
+		return err
+	}
+	return ErrInvalidRefreshToken
+}
+
+func (service *UserService) revokeFamily(familyID string) error {
+	tokens, err := service.TokenRepo.RevokeRefreshTokenFamily(familyID)
+	if err != nil {
+		return err
+	}
+	return service.denyAccessTokens(tokens)
+}
+
+func (service *UserService) denyAccessTokens(tokens []entities.RefreshToken) error {
+	now := time.Now()
+	var revoked []entities.RevokedToken
+	for _, token := range tokens {
+		if token.AccessExpiresAt.After(now) {
+			revoked = append(revoked, entities.RevokedToken{TokenID: token.AccessTokenID, UserID: token.UserID, ExpiresAt: token.AccessExpiresAt})
+		}
+	}
+	return service.TokenRepo.RevokeAccessTokens(revoked)
+}
+
+func (service *UserService) issueTokens(user entities.User, familyID string) (entities.LoginResponse, error) {
+	accessTokenID, err := utils.RandomToken(16)
+	if err != nil {
+		return entities.LoginResponse{}, err
+	}
+
+	signedToken, expiresAt, err := service.Jwt.GenerateToken(user, accessTokenID)
+	if err != nil {
+		return entities.LoginResponse{}, err
+	}
+
+	refreshToken, err := utils.RandomToken(32)
+	if err != nil {
+		return entities.LoginResponse{}, err
+	}
+
+	_, err = service.TokenRepo.CreateRefreshToken(entities.RefreshToken{
+		UserID:          user.ID,
+		TokenHash:       utils.HashToken(refreshToken),
+		FamilyID:        familyID,
+		AccessTokenID:   accessTokenID,
+		AccessExpiresAt: time.Unix(expiresAt, 0),
+		ExpiresAt:       service.Jwt.RefreshTokenExpiry(),
+	})
+	if err != nil {
+		return entities.LoginResponse{}, err
+	}
+
+	return entities.LoginResponse{
+		Email:        user.Email,
+		Jwt:          signedToken,
+		ExpiresAt:    expiresAt,
+		RefreshToken: refreshToken,
+		ID:           user.ID,
+	}, nil
 }
 
 func (service *UserService) DeleteUser(idStr string) (entities.User, error) {
 	var id uint
 	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
-		return entities.User{}, errors.New("invalid ID format")
+		return entities.User{}, apperrors.ErrInvalidID
 	}
 
 	user, err := service.Repo.DeleteUser(id)
@@ -65,12 +225,74 @@ func (service *UserService) DeleteUser(idStr string) (entities.User, error) {
 func (service *UserService) UpdateUser(idStr string, user entities.User) (entities.User, error) {
 	var id uint
 	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
-		return entities.User{}, errors.New("invalid ID format")
+		return entities.User{}, apperrors.ErrInvalidID
+	}
+
+	// Roles are only changed through ChangeRole, which keeps an audit record.
+	user.Role = entities.NormalUser
+
+	return service.updateUser(id, user)
+}
+
+// UpdateProfile lets a user edit their own profile. The role and password are never taken
+// from profile; ChangePassword is the only way to set a new password.
+func (service *UserService) UpdateProfile(userID uint, profile entities.User) (entities.User, error) {
+	profile.Password = ""
+	profile.Role = entities.NormalUser
+
+	return service.updateUser(userID, profile)
+}
+
+// updateUser applies the update and, when it changed the email address, marks it unverified
+// and mails a verification link to the new one.
+func (service *UserService) updateUser(id uint, update entities.User) (entities.User, error) {
+	before, err := service.Repo.UserByID(id)
+	if err != nil {
+		return entities.User{}, err
 	}
 
-	user, err := service.Repo.UpdateUser(id, user)
+	user, err := service.Repo.UpdateUser(id, update)
 	if err != nil {
 		return entities.User{}, err
 	}
+
+	if !strings.EqualFold(user.Email, before.Email) {
+		if err := service.Repo.SetEmailVerifiedAt(id, nil); err != nil {
+			return entities.User{}, err
+		}
+		user.EmailVerifiedAt = nil
+		service.sendEmailVerificationQuietly(user)
+	}
 	return user, nil
 }
+
+var (
+	ErrWrongPassword     = apperrors.Validation("current password is incorrect", apperrors.FieldError{Field: "current_password", Rule: "match", Message: "does not match the current password"})
+	ErrPasswordUnchanged = apperrors.Validation("new password must differ from the current one", apperrors.FieldError{Field: "new_password", Rule: "nefield", Param: "current_password", Message: "must differ from the current password"})
+)
+
+// ChangePassword replaces the password of userID once currentPassword has been checked and,
+// like a reset, ends every session, so whoever learned the old password is logged out too.
+// The caller validates the strength of newPassword.
+func (service *UserService) ChangePassword(userID uint, currentPassword string, newPassword string) error {
+	user, err := service.Repo.UserByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(currentPassword)); err != nil {
+		return ErrWrongPassword
+	}
+	if currentPassword == newPassword {
+		return ErrPasswordUnchanged
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	if err := service.Repo.UpdatePassword(userID, string(hashedPassword)); err != nil {
+		return err
+	}
+	return service.endSessions(userID)
+}
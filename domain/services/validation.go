@@ -0,0 +1,46 @@
+package services
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/utils"
+	"github.com/go-playground/validator/v10"
+)
+
+// ValidateDestination applies the request rules shared by every way a destination can be
+// created or updated: the HTTP and WebSocket handlers and the bulk import.
+func ValidateDestination(destination entities.Destination) error {
+	return validateDestination(destination)
+}
+
+// validateDestination skips the except fields, so an import row can be checked before its
+// location has an id.
+func validateDestination(destination entities.Destination, except ...string) error {
+	validate := validator.New()
+
+	err := validate.RegisterValidation("name", utils.NameValidator)
+	if err != nil {
+		return err
+	}
+	err = validate.RegisterValidation("description", utils.DescriptionValidator)
+	if err != nil {
+		return err
+	}
+
+	if len(except) > 0 {
+		err = validate.StructExcept(destination, except...)
+	} else {
+		err = validate.Struct(destination)
+	}
+	if err != nil {
+		return apperrors.FromValidation(err)
+	}
+	return nil
+}
+
+func validateLocation(location entities.Location) error {
+	if err := validator.New().Struct(location); err != nil {
+		return apperrors.FromValidation(err)
+	}
+	return nil
+}
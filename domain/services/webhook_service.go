@@ -0,0 +1,521 @@
+package services
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/events"
+	"Trip-Trove-API/domain/repositories"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type IWebhookService interface {
+	AllWebhooks() ([]entities.Webhook, error)
+	WebhookByID(idStr string) (*entities.Webhook, error)
+	CreateWebhook(request entities.WebhookRequest) (entities.Webhook, error)
+	UpdateWebhook(idStr string, request entities.WebhookRequest) (entities.Webhook, error)
+	DeleteWebhook(idStr string) (entities.Webhook, error)
+	Deliveries(idStr string, query entities.WebhookDeliveryQuery) (*WebhookDeliveryPage, error)
+	DeliveryByID(idStr string, deliveryIDStr string) (*entities.WebhookDelivery, error)
+	ReplayDelivery(idStr string, deliveryIDStr string) (entities.WebhookDelivery, error)
+}
+
+// Headers sent with every delivery. The signature is the hex HMAC-SHA256 of
+// "<timestamp>.<body>" keyed with the webhook secret, prefixed with "v1=".
+const (
+	WebhookEventHeader     = "X-TripTrove-Event"
+	WebhookDeliveryHeader  = "X-TripTrove-Delivery"
+	WebhookTimestampHeader = "X-TripTrove-Timestamp"
+	WebhookSignatureHeader = "X-TripTrove-Signature"
+)
+
+const (
+	DefaultWebhookMaxAttempts  = 8
+	DefaultWebhookBaseDelay    = 10 * time.Second
+	DefaultWebhookMaxDelay     = time.Hour
+	DefaultWebhookPollInterval = 5 * time.Second
+	DefaultWebhookTimeout      = 10 * time.Second
+	DefaultWebhookQueueSize    = 1024
+
+	webhookBatchSize = 50
+	// webhookClaim keeps other workers off a delivery while it is attempted. It outlasts
+	// the request timeout so a claim only expires when its worker died.
+	webhookClaim = 2 * time.Minute
+)
+
+// WebhookService manages webhook endpoints and delivers events to them. As an event
+// Publisher it queues events for Run, which records a pending delivery per subscribed
+// webhook, sends them and retries failures with exponential backoff until MaxAttempts
+// is reached.
+type WebhookService struct {
+	Repo         repositories.WebhookRepository
+	Client       *http.Client
+	MaxAttempts  int
+	BaseDelay    time.Duration
+	MaxDelay     time.Duration
+	PollInterval time.Duration
+	QueueSize    int
+
+	wakeOnce  sync.Once
+	wake      chan struct{}
+	queueOnce sync.Once
+	queue     chan events.Event
+	// mu guards stopped; Publish holds it for reading while it queues an event.
+	mu      sync.RWMutex
+	stopped bool
+}
+
+type WebhookDeliveryPage struct {
+	Deliveries []entities.WebhookDelivery
+	Total      int64
+	Page       int
+	Limit      int
+}
+
+var _ IWebhookService = &WebhookService{}
+
+func (service *WebhookService) AllWebhooks() ([]entities.Webhook, error) {
+	webhooks, err := service.Repo.AllWebhooks()
+	if err != nil {
+		return nil, err
+	}
+	if webhooks == nil {
+		webhooks = []entities.Webhook{}
+	}
+	return webhooks, nil
+}
+
+func (service *WebhookService) WebhookByID(idStr string) (*entities.Webhook, error) {
+	var id uint
+	if _, err := fmt.Sscanf(idStr, "%d", &id); err != nil {
+		return nil, apperrors.ErrInvalidID
+	}
+
+	return service.Repo.WebhookByID(id)
+}
+
+func (service *WebhookService) CreateWebhook(request entities.WebhookRequest) (entities.Webhook, error) {
+	if request.Secret == "" {
+		return entities.Webhook{}, apperrors.Validation("secret is required")
+	}
+
+	webhook, err := webhookFromRequest(request)
+	if err != nil {
+		return entities.Webhook{}, err
+	}
+
+	return service.Repo.CreateWebhook(webhook)
+}
+
+// UpdateWebhook replaces the webhook with request. An empty secret keeps the current one.
+func (service *WebhookService) UpdateWebhook(idStr string, request entities.WebhookRequest) (entities.Webhook, error) {
+	existing, err := service.WebhookByID(idStr)
+	if err != nil {
+		return entities.Webhook{}, err
+	}
+
+	webhook, err := webhookFromRequest(request)
+	if err != nil {
+		return entities.Webhook{}, err
+	}
+
+	return service.Repo.UpdateWebhook(existing.ID, webhook)
+}
+
+func (service *WebhookService) DeleteWebhook(idStr string) (entities.Webhook, error) {
+	existing, err := service.WebhookByID(idStr)
+	if err != nil {
+		return entities.Webhook{}, err
+	}
+
+	return service.Repo.DeleteWebhook(existing.ID)
+}
+
+func webhookFromRequest(request entities.WebhookRequest) (entities.Webhook, error) {
+	eventTypes := make(entities.WebhookEventTypes, 0, len(request.EventTypes))
+	seen := make(map[string]bool)
+	for _, eventType := range request.EventTypes {
+		if !events.Type(eventType).Known() {
+			return entities.Webhook{}, apperrors.Validation(fmt.Sprintf("unknown event type %q", eventType))
+		}
+		if !seen[eventType] {
+			seen[eventType] = true
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+
+	active := true
+	if request.Active != nil {
+		active = *request.Active
+	}
+
+	return entities.Webhook{
+		URL:        request.URL,
+		Secret:     request.Secret,
+		EventTypes: eventTypes,
+		Active:     active,
+	}, nil
+}
+
+func (service *WebhookService) Deliveries(idStr string, query entities.WebhookDeliveryQuery) (*WebhookDeliveryPage, error) {
+	webhook, err := service.WebhookByID(idStr)
+	if err != nil {
+		return nil, err
+	}
+
+	if query.Page == 0 {
+		query.Page = 1
+	}
+	if query.Limit == 0 {
+		query.Limit = DefaultPageLimit
+	}
+	if query.Page < 1 || query.Limit < 1 || query.Limit > MaxPageLimit {
+		return nil, fmt.Errorf("%w: page must be positive and limit between 1 and %d", ErrInvalidQuery, MaxPageLimit)
+	}
+	switch query.Status {
+	case "", entities.DeliveryPending, entities.DeliverySucceeded, entities.DeliveryFailed:
+	default:
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidQuery, query.Status)
+	}
+
+	deliveries, total, err := service.Repo.DeliveriesForWebhook(webhook.ID, query)
+	if err != nil {
+		return nil, err
+	}
+	if deliveries == nil {
+		deliveries = []entities.WebhookDelivery{}
+	}
+
+	return &WebhookDeliveryPage{Deliveries: deliveries, Total: total, Page: query.Page, Limit: query.Limit}, nil
+}
+
+func (service *WebhookService) DeliveryByID(idStr string, deliveryIDStr string) (*entities.WebhookDelivery, error) {
+	webhook, err := service.WebhookByID(idStr)
+	if err != nil {
+		return nil, err
+	}
+
+	var deliveryID uint
+	if _, err := fmt.Sscanf(deliveryIDStr, "%d", &deliveryID); err != nil {
+		return nil, apperrors.ErrInvalidID
+	}
+
+	delivery, err := service.Repo.DeliveryByID(deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.WebhookID != webhook.ID {
+		return nil, apperrors.NotFound("delivery not found")
+	}
+
+	return delivery, nil
+}
+
+// ReplayDelivery sends the payload of an earlier delivery again as a new delivery, so the
+// log keeps the history of the original.
+func (service *WebhookService) ReplayDelivery(idStr string, deliveryIDStr string) (entities.WebhookDelivery, error) {
+	original, err := service.DeliveryByID(idStr, deliveryIDStr)
+	if err != nil {
+		return entities.WebhookDelivery{}, err
+	}
+
+	replay, err := service.Repo.CreateDelivery(entities.WebhookDelivery{
+		WebhookID:     original.WebhookID,
+		EventSequence: original.EventSequence,
+		EventType:     original.EventType,
+		Payload:       original.Payload,
+		Status:        entities.DeliveryPending,
+		NextAttemptAt: time.Now().UTC(),
+		ReplayOf:      &original.ID,
+	})
+	if err != nil {
+		return entities.WebhookDelivery{}, err
+	}
+
+	service.notify()
+	return replay, nil
+}
+
+// Publish queues the event for Run. Webhooks are outside parties, so events about
+// private destinations are never sent. When the queue is full, or Run has stopped, the
+// deliveries are recorded right away rather than lost.
+func (service *WebhookService) Publish(event events.Event) {
+	if !event.VisibleTo(entities.Anonymous) {
+		return
+	}
+
+	service.mu.RLock()
+	queued := false
+	if !service.stopped {
+		select {
+		case service.eventQueue() <- event:
+			queued = true
+		default:
+		}
+	}
+	service.mu.RUnlock()
+
+	if !queued {
+		service.recordDeliveries(event)
+	}
+}
+
+// recordDeliveries records a pending delivery of the event for every active webhook
+// subscribed to its type.
+func (service *WebhookService) recordDeliveries(event events.Event) {
+	webhooks, err := service.Repo.ActiveWebhooks()
+	if err != nil {
+		log.Printf("Error loading webhooks: %v", err)
+		return
+	}
+
+	var payload []byte
+	queued := false
+	for _, webhook := range webhooks {
+		if !webhook.Subscribes(string(event.Type)) {
+			continue
+		}
+		if payload == nil {
+			if payload, err = json.Marshal(event.Envelope()); err != nil {
+				log.Printf("Error encoding webhook payload: %v", err)
+				return
+			}
+		}
+
+		_, err := service.Repo.CreateDelivery(entities.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			EventSequence: event.Sequence,
+			EventType:     string(event.Type),
+			Payload:       string(payload),
+			Status:        entities.DeliveryPending,
+			NextAttemptAt: time.Now().UTC(),
+		})
+		if err != nil {
+			log.Printf("Error queueing delivery for webhook %d: %v", webhook.ID, err)
+			continue
+		}
+		queued = true
+	}
+
+	if queued {
+		service.notify()
+	}
+}
+
+// recordQueued records the deliveries of every event waiting in the queue.
+func (service *WebhookService) recordQueued() {
+	for {
+		select {
+		case event := <-service.eventQueue():
+			service.recordDeliveries(event)
+		default:
+			return
+		}
+	}
+}
+
+// Run records deliveries for published events and delivers due deliveries until ctx is
+// done. It wakes up when events or deliveries are queued and every PollInterval for
+// retries and for deliveries queued by other instances. Events published after it stops
+// are recorded by Publish.
+func (service *WebhookService) Run(ctx context.Context) {
+	defer service.stop()
+
+	for {
+		service.recordQueued()
+
+		// A full batch means more deliveries may already be due.
+		delivered := webhookBatchSize
+		for delivered == webhookBatchSize && ctx.Err() == nil {
+			delivered = service.deliverDue(ctx)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-service.eventQueue():
+			service.recordDeliveries(event)
+		case <-service.wakeChan():
+		case <-time.After(orDefault(service.PollInterval, DefaultWebhookPollInterval)):
+		}
+	}
+}
+
+func (service *WebhookService) deliverDue(ctx context.Context) int {
+	now := time.Now().UTC()
+	due, err := service.Repo.DueDeliveries(now, webhookBatchSize)
+	if err != nil {
+		log.Printf("Error loading due webhook deliveries: %v", err)
+		return 0
+	}
+
+	for _, delivery := range due {
+		if ctx.Err() != nil {
+			break
+		}
+		claimed, err := service.Repo.ClaimDelivery(delivery.ID, now, now.Add(webhookClaim))
+		if err != nil {
+			log.Printf("Error claiming webhook delivery %d: %v", delivery.ID, err)
+			continue
+		}
+		if claimed {
+			service.attempt(ctx, delivery)
+		}
+	}
+	return len(due)
+}
+
+func (service *WebhookService) attempt(ctx context.Context, delivery entities.WebhookDelivery) {
+	webhook, err := service.Repo.WebhookByID(delivery.WebhookID)
+	switch {
+	case apperrors.KindOf(err) == apperrors.KindNotFound:
+		service.giveUp(delivery, "webhook was deleted")
+		return
+	case err != nil:
+		// The claim runs out and the delivery is picked up again.
+		log.Printf("Error loading webhook %d: %v", delivery.WebhookID, err)
+		return
+	case !webhook.Active:
+		service.giveUp(delivery, "webhook is disabled")
+		return
+	}
+
+	status, err := service.send(ctx, *webhook, delivery)
+	if ctx.Err() != nil {
+		// Shutting down: the attempt does not count and the claim runs out.
+		return
+	}
+
+	delivery.Attempts++
+	delivery.ResponseStatus = status
+	now := time.Now().UTC()
+	if err == nil {
+		delivery.Status = entities.DeliverySucceeded
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+	} else {
+		delivery.LastError = err.Error()
+		if delivery.Attempts >= orDefault(service.MaxAttempts, DefaultWebhookMaxAttempts) {
+			delivery.Status = entities.DeliveryFailed
+		} else {
+			delivery.NextAttemptAt = now.Add(service.backoff(delivery.Attempts))
+		}
+	}
+
+	if err := service.Repo.SaveAttempt(delivery); err != nil {
+		log.Printf("Error saving webhook delivery %d: %v", delivery.ID, err)
+	}
+}
+
+func (service *WebhookService) giveUp(delivery entities.WebhookDelivery, reason string) {
+	delivery.Status = entities.DeliveryFailed
+	delivery.LastError = reason
+	if err := service.Repo.SaveAttempt(delivery); err != nil {
+		log.Printf("Error saving webhook delivery %d: %v", delivery.ID, err)
+	}
+}
+
+func (service *WebhookService) send(ctx context.Context, webhook entities.Webhook, delivery entities.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+	timestamp := time.Now().Unix()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(WebhookEventHeader, delivery.EventType)
+	request.Header.Set(WebhookDeliveryHeader, strconv.FormatUint(uint64(delivery.ID), 10))
+	request.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(WebhookSignatureHeader, SignWebhookPayload(webhook.Secret, timestamp, body))
+
+	response, err := service.client().Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(response.Body, 64<<10))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("endpoint responded with %d", response.StatusCode)
+	}
+	return response.StatusCode, nil
+}
+
+// SignWebhookPayload computes the signature header value receivers check deliveries against.
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// backoff doubles the delay after every failed attempt, up to MaxDelay.
+func (service *WebhookService) backoff(attempts int) time.Duration {
+	delay := orDefault(service.BaseDelay, DefaultWebhookBaseDelay)
+	maxDelay := orDefault(service.MaxDelay, DefaultWebhookMaxDelay)
+	for i := 1; i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
+
+func (service *WebhookService) client() *http.Client {
+	if service.Client != nil {
+		return service.Client
+	}
+	return &http.Client{Timeout: DefaultWebhookTimeout}
+}
+
+func (service *WebhookService) wakeChan() chan struct{} {
+	service.wakeOnce.Do(func() {
+		service.wake = make(chan struct{}, 1)
+	})
+	return service.wake
+}
+
+func (service *WebhookService) eventQueue() chan events.Event {
+	service.queueOnce.Do(func() {
+		service.queue = make(chan events.Event, orDefault(service.QueueSize, DefaultWebhookQueueSize))
+	})
+	return service.queue
+}
+
+// stop makes Publish record deliveries itself and records the events still queued.
+func (service *WebhookService) stop() {
+	service.mu.Lock()
+	service.stopped = true
+	service.mu.Unlock()
+
+	service.recordQueued()
+}
+
+func (service *WebhookService) notify() {
+	select {
+	case service.wakeChan() <- struct{}{}:
+	default:
+	}
+}
+
+func orDefault[T comparable](value T, fallback T) T {
+	var zero T
+	if value == zero {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"Trip-Trove-API/database"
+	"Trip-Trove-API/domain/events"
+	"Trip-Trove-API/domain/repositories"
+	"Trip-Trove-API/infrastructure/eventbus"
+	"context"
+	"gorm.io/gorm"
+	"log"
+	"os"
+)
+
+// openEventBus picks the bus through EVENT_BUS: local (the default) for a single instance,
+// or postgres to fan events out to every instance sharing the database. The returned
+// function stops the bus.
+func openEventBus(ctx context.Context, db *gorm.DB, repos repositories.Repositories) (events.Bus, func()) {
+	switch os.Getenv("EVENT_BUS") {
+	case "", "local":
+		return events.NewLocalBus(), func() {}
+	case "postgres":
+		if database.Driver() != database.DriverPostgres {
+			log.Fatal("EVENT_BUS=postgres needs DB_DRIVER=postgres")
+		}
+		bus := eventbus.NewPostgresBus(db, database.PostgresDSN(), repos.Events)
+		if err := bus.Start(ctx); err != nil {
+			log.Fatalf("Failed to start the event bus: %v", err)
+		}
+		return bus, func() {
+			if err := bus.Close(); err != nil {
+				log.Printf("Error closing the event bus: %v", err)
+			}
+		}
+	default:
+		log.Fatalf("Unknown EVENT_BUS %q, expected local or postgres", os.Getenv("EVENT_BUS"))
+		return nil, nil
+	}
+}
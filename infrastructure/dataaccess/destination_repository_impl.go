@@ -1,8 +1,10 @@
 package dataaccess
 
 import (
+	"Trip-Trove-API/domain/apperrors"
 	"Trip-Trove-API/domain/entities"
 	"errors"
+	"fmt"
 	"gorm.io/gorm"
 )
 
@@ -20,6 +22,26 @@ func (r *GormDestinationRepository) AllDestinations() ([]entities.Destination, e
 	return destinations, result.Error
 }
 
+const visibleDestinationsClause = "(destinations.is_private = false OR @includePrivate OR destinations.owner_id = @ownerID)"
+
+func visibilityArgs(visibility entities.DestinationVisibility) map[string]interface{} {
+	var ownerID interface{}
+	if visibility.OwnerID != nil {
+		ownerID = *visibility.OwnerID
+	}
+	return map[string]interface{}{"includePrivate": visibility.IncludePrivate, "ownerID": ownerID}
+}
+
+func withArgs(args ...map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{})
+	for _, arg := range args {
+		for key, value := range arg {
+			merged[key] = value
+		}
+	}
+	return merged
+}
+
 func (r *GormDestinationRepository) AllDestinationIDs() ([]uint, error) {
 	var destinationIDs []uint
 
@@ -30,12 +52,224 @@ func (r *GormDestinationRepository) AllDestinationIDs() ([]uint, error) {
 	return destinationIDs, nil
 }
 
+func (r *GormDestinationRepository) QueryDestinations(query entities.DestinationQuery) ([]entities.Destination, int64, error) {
+	var destinations []entities.Destination
+	var total int64
+
+	filter := query.Filter
+	db := r.Db.Model(&entities.Destination{}).Where(visibleDestinationsClause, visibilityArgs(filter.Visibility))
+
+	if filter.LocationID != nil {
+		db = db.Where("destinations.location_id = ?", *filter.LocationID)
+	}
+	if filter.Country != "" {
+		db = db.Joins("JOIN locations ON locations.id = destinations.location_id AND locations.deleted_at IS NULL").
+			Where("LOWER(locations.country) = LOWER(?)", filter.Country)
+	}
+	if filter.MinVisitors != nil {
+		db = db.Where("destinations.visitors_last_year >= ?", *filter.MinVisitors)
+	}
+	if filter.MaxVisitors != nil {
+		db = db.Where("destinations.visitors_last_year <= ?", *filter.MaxVisitors)
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	direction := "ASC"
+	if query.SortDesc {
+		direction = "DESC"
+	}
+	order := fmt.Sprintf("destinations.%s %s, destinations.id %s", query.SortBy, direction, direction)
+
+	if err := db.Order(order).Offset(query.Offset()).Limit(query.Limit).Find(&destinations).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return destinations, total, nil
+}
+
+func (r *GormDestinationRepository) SearchDestinations(term string, limit int, visibility entities.DestinationVisibility) ([]entities.DestinationSearchResult, error) {
+	type searchRow struct {
+		entities.Destination
+		LocationName string
+		Country      string
+		Rank         float64
+		Snippet      string
+	}
+
+	tsQuery := prefixTsQuery(term)
+	if tsQuery == "" {
+		return []entities.DestinationSearchResult{}, nil
+	}
+	if !isPostgres(r.Db) {
+		return r.searchDestinationsPortable(term, limit, visibility)
+	}
+
+	// The document and haystack span both tables, so no index covers them. Candidates are
+	// found through the indexes on each table's part and then checked against the whole.
+	document := `setweight(to_tsvector(@config, coalesce(destinations.name, '')), 'A') ||
+		setweight(to_tsvector(@config, coalesce(locations.name, '') || ' ' || coalesce(locations.country, '')), 'B') ||
+		setweight(to_tsvector(@config, coalesce(destinations.description, '')), 'C')`
+	haystack := `destinations.name || ' ' || locations.name || ' ' || locations.country`
+
+	var rows []searchRow
+	err := withSimilarityThreshold(r.Db, func(tx *gorm.DB) error {
+		return tx.Raw(`
+			WITH candidates AS (
+				SELECT destinations.id
+				FROM destinations
+				WHERE `+destinationDocument+` @@ to_tsquery(@config, @anyQuery) OR @term <% destinations.name
+				UNION
+				SELECT destinations.id
+				FROM locations
+				JOIN destinations ON destinations.location_id = locations.id
+				WHERE `+locationDocument+` @@ to_tsquery(@config, @anyQuery) OR @term <% `+locationHaystack+`
+			)
+			SELECT destinations.*,
+				locations.name AS location_name,
+				locations.country AS country,
+				ts_rank(`+document+`, to_tsquery(@config, @query)) + word_similarity(@term, `+haystack+`) AS rank,
+				ts_headline(@config, `+escapedHTML(`coalesce(destinations.description, '')`)+`, to_tsquery(@config, @query), @headline) AS snippet
+			FROM candidates
+			JOIN destinations ON destinations.id = candidates.id
+			JOIN locations ON locations.id = destinations.location_id AND locations.deleted_at IS NULL
+			WHERE destinations.deleted_at IS NULL
+				AND `+visibleDestinationsClause+`
+				AND (`+document+` @@ to_tsquery(@config, @query) OR @term <% destinations.name OR @term <% `+locationHaystack+`)
+			ORDER BY rank DESC, destinations.id
+			LIMIT @limit`,
+			withArgs(visibilityArgs(visibility), map[string]interface{}{
+				"config":   searchConfig,
+				"query":    tsQuery,
+				"anyQuery": anyPrefixTsQuery(term),
+				"term":     term,
+				"headline": headlineOptions,
+				"limit":    limit,
+			})).Scan(&rows).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]entities.DestinationSearchResult, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, entities.DestinationSearchResult{
+			Destination: row.Destination,
+			Location:    row.LocationName,
+			Country:     row.Country,
+			Rank:        row.Rank,
+			Snippet:     row.Snippet,
+		})
+	}
+
+	return results, nil
+}
+
+func (r *GormDestinationRepository) searchDestinationsPortable(term string, limit int, visibility entities.DestinationVisibility) ([]entities.DestinationSearchResult, error) {
+	type candidateRow struct {
+		entities.Destination
+		LocationName string
+		Country      string
+	}
+
+	words := searchWords(term)
+	db := r.Db.Table("destinations").
+		Select("destinations.*, locations.name AS location_name, locations.country AS country").
+		Joins("JOIN locations ON locations.id = destinations.location_id AND locations.deleted_at IS NULL").
+		Where("destinations.deleted_at IS NULL").
+		Where(visibleDestinationsClause, visibilityArgs(visibility))
+	for _, word := range words {
+		db = db.Where("LOWER(destinations.name || ' ' || locations.name || ' ' || locations.country || ' ' || COALESCE(destinations.description, '')) LIKE ?", "%"+word+"%")
+	}
+
+	var rows []candidateRow
+	if err := db.Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	results := make([]entities.DestinationSearchResult, 0, len(rows))
+	for _, row := range rows {
+		location := entities.Location{Name: row.LocationName, Country: row.Country}
+		if result, ok := rankDestination(words, row.Destination, location); ok {
+			results = append(results, result)
+		}
+	}
+
+	return sortDestinationResults(results, limit), nil
+}
+
+func (r *GormDestinationRepository) DestinationsNearby(latitude float64, longitude float64, radiusKm float64, limit int, visibility entities.DestinationVisibility) ([]entities.DestinationWithDistance, error) {
+	// One degree of latitude is roughly 111 km everywhere, which gives a cheap bounding
+	// box on the indexed column before the exact haversine distance is computed.
+	latitudeDelta := radiusKm / 111.0
+
+	if !isPostgres(r.Db) {
+		var candidates []entities.Destination
+		err := r.Db.Where(visibleDestinationsClause, visibilityArgs(visibility)).
+			Where("destinations.latitude BETWEEN ? AND ?", latitude-latitudeDelta, latitude+latitudeDelta).
+			Find(&candidates).Error
+		if err != nil {
+			return nil, err
+		}
+		return nearestDestinations(candidates, latitude, longitude, radiusKm, limit), nil
+	}
+
+	var destinations []entities.DestinationWithDistance
+	err := r.Db.Raw(`
+		SELECT * FROM (
+			SELECT destinations.*,
+				2 * @radius * ASIN(SQRT(
+					POWER(SIN(RADIANS(destinations.latitude - @lat) / 2), 2) +
+					COS(RADIANS(@lat)) * COS(RADIANS(destinations.latitude)) *
+					POWER(SIN(RADIANS(destinations.longitude - @lng) / 2), 2)
+				)) AS distance_km
+			FROM destinations
+			WHERE destinations.deleted_at IS NULL
+				AND `+visibleDestinationsClause+`
+				AND destinations.latitude IS NOT NULL
+				AND destinations.longitude IS NOT NULL
+				AND destinations.latitude BETWEEN @minLat AND @maxLat
+		) AS nearby
+		WHERE distance_km <= @radiusKm
+		ORDER BY distance_km, id
+		LIMIT @limit`,
+		withArgs(visibilityArgs(visibility), map[string]interface{}{
+			"radius":   entities.EarthRadiusKm,
+			"lat":      latitude,
+			"lng":      longitude,
+			"minLat":   latitude - latitudeDelta,
+			"maxLat":   latitude + latitudeDelta,
+			"radiusKm": radiusKm,
+			"limit":    limit,
+		})).Scan(&destinations).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return destinations, nil
+}
+
 func (r *GormDestinationRepository) DestinationByID(id uint) (*entities.Destination, error) {
 	var destination entities.Destination
 
 	if err := r.Db.First(&destination, "ID = ?", id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("destination not found")
+			return nil, apperrors.NotFound("destination not found")
+		}
+		return nil, err
+	}
+
+	return &destination, nil
+}
+
+func (r *GormDestinationRepository) DestinationByName(name string) (*entities.Destination, error) {
+	var destination entities.Destination
+
+	if err := r.Db.First(&destination, "name = ?", name).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NotFound("destination not found")
 		}
 		return nil, err
 	}
@@ -53,6 +287,16 @@ func (r *GormDestinationRepository) DestinationIDsForLocation(locationID uint) (
 	return destinationIDs, nil
 }
 
+func (r *GormDestinationRepository) DestinationsForLocation(locationID uint) ([]entities.Destination, error) {
+	var destinations []entities.Destination
+
+	if err := r.Db.Where("location_id = ?", locationID).Order("id").Find(&destinations).Error; err != nil {
+		return nil, err
+	}
+
+	return destinations, nil
+}
+
 func (r *GormDestinationRepository) DeleteDestinationsByLocationID(locationID uint) error {
 	if err := r.Db.Where("location_id = ?", locationID).Delete(&entities.Destination{}).Error; err != nil {
 		return err
@@ -62,17 +306,24 @@ func (r *GormDestinationRepository) DeleteDestinationsByLocationID(locationID ui
 
 func (r *GormDestinationRepository) CreateDestination(destination entities.Destination) (entities.Destination, error) {
 	if err := r.Db.Create(&destination).Error; err != nil {
-		return entities.Destination{}, err
+		return entities.Destination{}, translateWriteError(err, "a destination with this name already exists")
 	}
 	return destination, nil
 }
 
+func (r *GormDestinationRepository) CreateDestinations(destinations []entities.Destination) ([]entities.Destination, error) {
+	if err := r.Db.Create(&destinations).Error; err != nil {
+		return nil, translateWriteError(err, "a destination with this name already exists")
+	}
+	return destinations, nil
+}
+
 func (r *GormDestinationRepository) DeleteDestination(id uint) (entities.Destination, error) {
 	var destination entities.Destination
 
 	if err := r.Db.First(&destination, "ID = ?", id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return entities.Destination{}, errors.New("destination not found")
+			return entities.Destination{}, apperrors.NotFound("destination not found")
 		}
 		return entities.Destination{}, err
 	}
@@ -89,14 +340,30 @@ func (r *GormDestinationRepository) UpdateDestination(id uint, updatedDestinatio
 
 	if err := r.Db.First(&destination, "ID = ?", id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return entities.Destination{}, errors.New("destination not found")
+			return entities.Destination{}, apperrors.NotFound("destination not found")
 		}
 		return entities.Destination{}, err
 	}
 
-	if err := r.Db.Model(&destination).Updates(updatedDestination).Error; err != nil {
-		return entities.Destination{}, err
+	destination.Name = updatedDestination.Name
+	destination.LocationID = updatedDestination.LocationID
+	destination.ImageUrl = updatedDestination.ImageUrl
+	destination.Description = updatedDestination.Description
+	destination.VisitorsLastYear = updatedDestination.VisitorsLastYear
+	destination.IsPrivate = updatedDestination.IsPrivate
+	destination.Latitude = updatedDestination.Latitude
+	destination.Longitude = updatedDestination.Longitude
+
+	// Selecting the columns writes zero values too, so a destination can be made public again.
+	err := r.Db.Model(&destination).Select(destinationUpdateColumns).Updates(&destination).Error
+	if err != nil {
+		return entities.Destination{}, translateWriteError(err, "a destination with this name already exists")
 	}
 
 	return destination, nil
 }
+
+// destinationUpdateColumns are replaced by UpdateDestination. The owner stays who created it.
+var destinationUpdateColumns = []string{
+	"name", "location_id", "image_url", "description", "visitors_last_year", "is_private", "latitude", "longitude", "updated_at",
+}
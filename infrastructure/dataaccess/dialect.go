@@ -0,0 +1,9 @@
+package dataaccess
+
+import "gorm.io/gorm"
+
+// isPostgres reports whether db can run the full-text and trigonometric SQL; other
+// dialects fall back to filtering with LIKE and ranking in Go.
+func isPostgres(db *gorm.DB) bool {
+	return db.Dialector.Name() == "postgres"
+}
@@ -0,0 +1,19 @@
+package dataaccess
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"errors"
+	"gorm.io/gorm"
+)
+
+// translateWriteError maps constraint violations, which gorm reports once TranslateError
+// is enabled, to domain errors the handlers can return as 409 or 400.
+func translateWriteError(err error, conflictMessage string) error {
+	switch {
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return &apperrors.Error{Kind: apperrors.KindConflict, Message: conflictMessage, Err: err}
+	case errors.Is(err, gorm.ErrForeignKeyViolated):
+		return &apperrors.Error{Kind: apperrors.KindValidation, Message: "referenced record does not exist", Err: err}
+	}
+	return err
+}
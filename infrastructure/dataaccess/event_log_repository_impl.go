@@ -0,0 +1,55 @@
+package dataaccess
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"gorm.io/gorm"
+)
+
+type GormEventLogRepository struct {
+	Db *gorm.DB
+}
+
+func NewGormEventLogRepository(db *gorm.DB) *GormEventLogRepository {
+	return &GormEventLogRepository{Db: db}
+}
+
+func (r *GormEventLogRepository) AppendEvent(record entities.EventRecord) (entities.EventRecord, error) {
+	if err := r.Db.Create(&record).Error; err != nil {
+		return entities.EventRecord{}, err
+	}
+	return record, nil
+}
+
+func (r *GormEventLogRepository) EventsSince(sequence uint64, limit int) ([]entities.EventRecord, error) {
+	var records []entities.EventRecord
+
+	err := r.Db.Where("sequence > ?", sequence).
+		Order("sequence").
+		Limit(limit).
+		Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+func (r *GormEventLogRepository) SequenceRange() (uint64, uint64, error) {
+	var sequenceRange struct {
+		Oldest uint64
+		Latest uint64
+	}
+
+	err := r.Db.Model(&entities.EventRecord{}).
+		Select("COALESCE(MIN(sequence), 0) AS oldest, COALESCE(MAX(sequence), 0) AS latest").
+		Scan(&sequenceRange).Error
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return sequenceRange.Oldest, sequenceRange.Latest, nil
+}
+
+func (r *GormEventLogRepository) TrimEvents(keepFrom uint64) error {
+	return r.Db.Where("sequence < ?", keepFrom).Delete(&entities.EventRecord{}).Error
+}
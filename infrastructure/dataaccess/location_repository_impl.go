@@ -1,6 +1,7 @@
 package dataaccess
 
 import (
+	"Trip-Trove-API/domain/apperrors"
 	"Trip-Trove-API/domain/entities"
 	"errors"
 	"gorm.io/gorm"
@@ -30,12 +31,97 @@ func (r *GormLocationRepository) AllLocationIDs() ([]uint, error) {
 	return locationIDs, nil
 }
 
+func (r *GormLocationRepository) SearchLocations(term string, limit int) ([]entities.LocationSearchResult, error) {
+	type searchRow struct {
+		entities.Location
+		Rank    float64
+		Snippet string
+	}
+
+	tsQuery := prefixTsQuery(term)
+	if tsQuery == "" {
+		return []entities.LocationSearchResult{}, nil
+	}
+	if !isPostgres(r.Db) {
+		return r.searchLocationsPortable(term, limit)
+	}
+
+	var rows []searchRow
+	err := withSimilarityThreshold(r.Db, func(tx *gorm.DB) error {
+		return tx.Raw(`
+			SELECT locations.*,
+				ts_rank(`+locationDocument+`, to_tsquery(@config, @query)) + word_similarity(@term, `+locationHaystack+`) AS rank,
+				ts_headline(@config, `+escapedHTML(`coalesce(description, '')`)+`, to_tsquery(@config, @query), @headline) AS snippet
+			FROM locations
+			WHERE deleted_at IS NULL
+				AND (`+locationDocument+` @@ to_tsquery(@config, @query) OR @term <% `+locationHaystack+`)
+			ORDER BY rank DESC, id
+			LIMIT @limit`,
+			map[string]interface{}{
+				"config":   searchConfig,
+				"query":    tsQuery,
+				"term":     term,
+				"headline": headlineOptions,
+				"limit":    limit,
+			}).Scan(&rows).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]entities.LocationSearchResult, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, entities.LocationSearchResult{
+			Location: row.Location,
+			Rank:     row.Rank,
+			Snippet:  row.Snippet,
+		})
+	}
+
+	return results, nil
+}
+
+func (r *GormLocationRepository) searchLocationsPortable(term string, limit int) ([]entities.LocationSearchResult, error) {
+	words := searchWords(term)
+	db := r.Db.Model(&entities.Location{})
+	for _, word := range words {
+		db = db.Where("LOWER(name || ' ' || country || ' ' || COALESCE(description, '')) LIKE ?", "%"+word+"%")
+	}
+
+	var candidates []entities.Location
+	if err := db.Find(&candidates).Error; err != nil {
+		return nil, err
+	}
+
+	results := make([]entities.LocationSearchResult, 0, len(candidates))
+	for _, location := range candidates {
+		if result, ok := rankLocation(words, location); ok {
+			results = append(results, result)
+		}
+	}
+
+	return sortLocationResults(results, limit), nil
+}
+
 func (r *GormLocationRepository) LocationByID(id uint) (*entities.Location, error) {
 	var location entities.Location
 
 	if err := r.Db.First(&location, "ID = ?", id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("location not found")
+			return nil, apperrors.NotFound("location not found")
+		}
+		return nil, err
+	}
+
+	return &location, nil
+}
+
+func (r *GormLocationRepository) LocationByName(name string) (*entities.Location, error) {
+	var location entities.Location
+
+	if err := r.Db.First(&location, "name = ?", name).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NotFound("location not found")
 		}
 		return nil, err
 	}
@@ -45,17 +131,24 @@ func (r *GormLocationRepository) LocationByID(id uint) (*entities.Location, erro
 
 func (r *GormLocationRepository) CreateLocation(location entities.Location) (entities.Location, error) {
 	if err := r.Db.Create(&location).Error; err != nil {
-		return entities.Location{}, err
+		return entities.Location{}, translateWriteError(err, "a location with this name already exists")
 	}
 	return location, nil
 }
 
+func (r *GormLocationRepository) CreateLocations(locations []entities.Location) ([]entities.Location, error) {
+	if err := r.Db.Create(&locations).Error; err != nil {
+		return nil, translateWriteError(err, "a location with this name already exists")
+	}
+	return locations, nil
+}
+
 func (r *GormLocationRepository) DeleteLocation(id uint) (entities.Location, error) {
 	var location entities.Location
 
 	if err := r.Db.First(&location, "ID = ?", id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return entities.Location{}, errors.New("location not found")
+			return entities.Location{}, apperrors.NotFound("location not found")
 		}
 		return entities.Location{}, err
 	}
@@ -72,13 +165,13 @@ func (r *GormLocationRepository) UpdateLocation(id uint, updatedLocation entitie
 
 	if err := r.Db.First(&location, "ID = ?", id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return entities.Location{}, errors.New("location not found")
+			return entities.Location{}, apperrors.NotFound("location not found")
 		}
 		return entities.Location{}, err
 	}
 
 	if err := r.Db.Model(&location).Updates(updatedLocation).Error; err != nil {
-		return entities.Location{}, err
+		return entities.Location{}, translateWriteError(err, "a location with this name already exists")
 	}
 
 	return location, nil
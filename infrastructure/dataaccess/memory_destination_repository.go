@@ -0,0 +1,306 @@
+package dataaccess
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"sort"
+	"strings"
+	"time"
+)
+
+type MemoryDestinationRepository struct {
+	Store *MemoryStore
+}
+
+func NewMemoryDestinationRepository(store *MemoryStore) *MemoryDestinationRepository {
+	return &MemoryDestinationRepository{Store: store}
+}
+
+func (r *MemoryDestinationRepository) AllDestinations() ([]entities.Destination, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	return r.sorted(func(entities.Destination) bool { return true }), nil
+}
+
+func (r *MemoryDestinationRepository) AllDestinationIDs() ([]uint, error) {
+	destinations, _ := r.AllDestinations()
+	return destinationIDs(destinations), nil
+}
+
+func (r *MemoryDestinationRepository) QueryDestinations(query entities.DestinationQuery) ([]entities.Destination, int64, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	filter := query.Filter
+	matches := r.sorted(func(destination entities.Destination) bool {
+		if !visibleWith(destination, filter.Visibility) {
+			return false
+		}
+		if filter.LocationID != nil && destination.LocationID != *filter.LocationID {
+			return false
+		}
+		if filter.Country != "" {
+			location, ok := r.Store.tables.locations[destination.LocationID]
+			if !ok || !strings.EqualFold(location.Country, filter.Country) {
+				return false
+			}
+		}
+		if filter.MinVisitors != nil && destination.VisitorsLastYear < *filter.MinVisitors {
+			return false
+		}
+		if filter.MaxVisitors != nil && destination.VisitorsLastYear > *filter.MaxVisitors {
+			return false
+		}
+		return true
+	})
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		less, equal := compareDestinations(matches[i], matches[j], query.SortBy)
+		if equal {
+			less = matches[i].ID < matches[j].ID
+		}
+		if query.SortDesc {
+			return !less
+		}
+		return less
+	})
+
+	total := int64(len(matches))
+	start := query.Offset()
+	if start > len(matches) {
+		start = len(matches)
+	}
+	end := start + query.Limit
+	if end > len(matches) {
+		end = len(matches)
+	}
+
+	return matches[start:end], total, nil
+}
+
+func compareDestinations(a entities.Destination, b entities.Destination, field entities.DestinationSortField) (less bool, equal bool) {
+	switch field {
+	case entities.SortByName:
+		return a.Name < b.Name, a.Name == b.Name
+	case entities.SortByVisitorsLastYear:
+		return a.VisitorsLastYear < b.VisitorsLastYear, a.VisitorsLastYear == b.VisitorsLastYear
+	default:
+		return a.CreatedAt.Before(b.CreatedAt), a.CreatedAt.Equal(b.CreatedAt)
+	}
+}
+
+func (r *MemoryDestinationRepository) SearchDestinations(term string, limit int, visibility entities.DestinationVisibility) ([]entities.DestinationSearchResult, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	words := searchWords(term)
+	if len(words) == 0 {
+		return []entities.DestinationSearchResult{}, nil
+	}
+
+	results := make([]entities.DestinationSearchResult, 0)
+	for _, destination := range r.Store.tables.destinations {
+		if !visibleWith(destination, visibility) {
+			continue
+		}
+		location, ok := r.Store.tables.locations[destination.LocationID]
+		if !ok {
+			continue
+		}
+		if result, ok := rankDestination(words, destination, location); ok {
+			results = append(results, result)
+		}
+	}
+
+	return sortDestinationResults(results, limit), nil
+}
+
+func (r *MemoryDestinationRepository) DestinationsNearby(latitude float64, longitude float64, radiusKm float64, limit int, visibility entities.DestinationVisibility) ([]entities.DestinationWithDistance, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	candidates := r.sorted(func(destination entities.Destination) bool {
+		return visibleWith(destination, visibility)
+	})
+
+	return nearestDestinations(candidates, latitude, longitude, radiusKm, limit), nil
+}
+
+func (r *MemoryDestinationRepository) DestinationByID(id uint) (*entities.Destination, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	destination, ok := r.Store.tables.destinations[id]
+	if !ok {
+		return nil, apperrors.NotFound("destination not found")
+	}
+	return &destination, nil
+}
+
+func (r *MemoryDestinationRepository) DestinationByName(name string) (*entities.Destination, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	for _, destination := range r.Store.tables.destinations {
+		if destination.Name == name {
+			return &destination, nil
+		}
+	}
+	return nil, apperrors.NotFound("destination not found")
+}
+
+func (r *MemoryDestinationRepository) DestinationIDsForLocation(locationID uint) ([]uint, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	return destinationIDs(r.sorted(func(destination entities.Destination) bool {
+		return destination.LocationID == locationID
+	})), nil
+}
+
+func (r *MemoryDestinationRepository) DestinationsForLocation(locationID uint) ([]entities.Destination, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	return r.sorted(func(destination entities.Destination) bool {
+		return destination.LocationID == locationID
+	}), nil
+}
+
+func (r *MemoryDestinationRepository) DeleteDestinationsByLocationID(locationID uint) error {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	for id, destination := range r.Store.tables.destinations {
+		if destination.LocationID == locationID {
+			delete(r.Store.tables.destinations, id)
+		}
+	}
+	return nil
+}
+
+func (r *MemoryDestinationRepository) CreateDestination(destination entities.Destination) (entities.Destination, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	if r.nameTaken(destination.Name, 0) {
+		return entities.Destination{}, apperrors.Conflict("a destination with this name already exists")
+	}
+
+	destination.ID = r.Store.nextID("destinations")
+	destination.CreatedAt = time.Now()
+	destination.UpdatedAt = destination.CreatedAt
+	r.Store.tables.destinations[destination.ID] = destination
+
+	return destination, nil
+}
+
+func (r *MemoryDestinationRepository) CreateDestinations(destinations []entities.Destination) ([]entities.Destination, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	created := make([]entities.Destination, 0, len(destinations))
+	for _, destination := range destinations {
+		if r.nameTaken(destination.Name, 0) {
+			// A single statement would have inserted none of them.
+			for _, inserted := range created {
+				delete(r.Store.tables.destinations, inserted.ID)
+			}
+			return nil, apperrors.Conflict("a destination with this name already exists")
+		}
+
+		destination.ID = r.Store.nextID("destinations")
+		destination.CreatedAt = time.Now()
+		destination.UpdatedAt = destination.CreatedAt
+		r.Store.tables.destinations[destination.ID] = destination
+		created = append(created, destination)
+	}
+
+	return created, nil
+}
+
+func (r *MemoryDestinationRepository) DeleteDestination(id uint) (entities.Destination, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	destination, ok := r.Store.tables.destinations[id]
+	if !ok {
+		return entities.Destination{}, apperrors.NotFound("destination not found")
+	}
+
+	delete(r.Store.tables.destinations, id)
+	return destination, nil
+}
+
+// UpdateDestination only copies non-zero fields, the same way gorm's Updates does with a struct.
+func (r *MemoryDestinationRepository) UpdateDestination(id uint, updatedDestination entities.Destination) (entities.Destination, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	destination, ok := r.Store.tables.destinations[id]
+	if !ok {
+		return entities.Destination{}, apperrors.NotFound("destination not found")
+	}
+	if r.nameTaken(updatedDestination.Name, id) {
+		return entities.Destination{}, apperrors.Conflict("a destination with this name already exists")
+	}
+
+	destination.Name = updatedDestination.Name
+	destination.LocationID = updatedDestination.LocationID
+	destination.ImageUrl = updatedDestination.ImageUrl
+	destination.Description = updatedDestination.Description
+	destination.VisitorsLastYear = updatedDestination.VisitorsLastYear
+	destination.IsPrivate = updatedDestination.IsPrivate
+	destination.Latitude = updatedDestination.Latitude
+	destination.Longitude = updatedDestination.Longitude
+	destination.UpdatedAt = time.Now()
+
+	r.Store.tables.destinations[id] = destination
+	return destination, nil
+}
+
+func (r *MemoryDestinationRepository) nameTaken(name string, exceptID uint) bool {
+	for id, destination := range r.Store.tables.destinations {
+		if id != exceptID && destination.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// sorted returns the destinations accepted by keep ordered by id. Callers hold the lock.
+func (r *MemoryDestinationRepository) sorted(keep func(entities.Destination) bool) []entities.Destination {
+	destinations := make([]entities.Destination, 0)
+	for _, destination := range r.Store.tables.destinations {
+		if keep(destination) {
+			destinations = append(destinations, destination)
+		}
+	}
+	sort.Slice(destinations, func(i, j int) bool {
+		return destinations[i].ID < destinations[j].ID
+	})
+	return destinations
+}
+
+func visibleWith(destination entities.Destination, visibility entities.DestinationVisibility) bool {
+	if !destination.IsPrivate || visibility.IncludePrivate {
+		return true
+	}
+	return visibility.OwnerID != nil && destination.OwnerID != nil && *visibility.OwnerID == *destination.OwnerID
+}
+
+func destinationIDs(destinations []entities.Destination) []uint {
+	ids := make([]uint, 0, len(destinations))
+	for _, destination := range destinations {
+		ids = append(ids, destination.ID)
+	}
+	return ids
+}
+
+func setIfNotZero[T comparable](target *T, value T) {
+	var zero T
+	if value != zero {
+		*target = value
+	}
+}
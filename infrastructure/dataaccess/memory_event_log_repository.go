@@ -0,0 +1,73 @@
+package dataaccess
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"sort"
+)
+
+type MemoryEventLogRepository struct {
+	Store *MemoryStore
+}
+
+func NewMemoryEventLogRepository(store *MemoryStore) *MemoryEventLogRepository {
+	return &MemoryEventLogRepository{Store: store}
+}
+
+func (r *MemoryEventLogRepository) AppendEvent(record entities.EventRecord) (entities.EventRecord, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	record.Sequence = uint64(r.Store.nextID("event_log"))
+	r.Store.tables.eventLog[record.Sequence] = record
+
+	return record, nil
+}
+
+func (r *MemoryEventLogRepository) EventsSince(sequence uint64, limit int) ([]entities.EventRecord, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	records := make([]entities.EventRecord, 0)
+	for _, record := range r.Store.tables.eventLog {
+		if record.Sequence > sequence {
+			records = append(records, record)
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Sequence < records[j].Sequence
+	})
+	if len(records) > limit {
+		records = records[:limit]
+	}
+
+	return records, nil
+}
+
+func (r *MemoryEventLogRepository) SequenceRange() (uint64, uint64, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	var oldest, latest uint64
+	for sequence := range r.Store.tables.eventLog {
+		if oldest == 0 || sequence < oldest {
+			oldest = sequence
+		}
+		if sequence > latest {
+			latest = sequence
+		}
+	}
+
+	return oldest, latest, nil
+}
+
+func (r *MemoryEventLogRepository) TrimEvents(keepFrom uint64) error {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	for sequence := range r.Store.tables.eventLog {
+		if sequence < keepFrom {
+			delete(r.Store.tables.eventLog, sequence)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package dataaccess
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"sort"
+	"time"
+)
+
+type MemoryLocationRepository struct {
+	Store *MemoryStore
+}
+
+func NewMemoryLocationRepository(store *MemoryStore) *MemoryLocationRepository {
+	return &MemoryLocationRepository{Store: store}
+}
+
+func (r *MemoryLocationRepository) AllLocations() ([]entities.Location, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	return r.sorted(), nil
+}
+
+func (r *MemoryLocationRepository) AllLocationIDs() ([]uint, error) {
+	locations, _ := r.AllLocations()
+
+	ids := make([]uint, 0, len(locations))
+	for _, location := range locations {
+		ids = append(ids, location.ID)
+	}
+	return ids, nil
+}
+
+func (r *MemoryLocationRepository) SearchLocations(term string, limit int) ([]entities.LocationSearchResult, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	words := searchWords(term)
+	if len(words) == 0 {
+		return []entities.LocationSearchResult{}, nil
+	}
+
+	results := make([]entities.LocationSearchResult, 0)
+	for _, location := range r.Store.tables.locations {
+		if result, ok := rankLocation(words, location); ok {
+			results = append(results, result)
+		}
+	}
+
+	return sortLocationResults(results, limit), nil
+}
+
+func (r *MemoryLocationRepository) LocationByID(id uint) (*entities.Location, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	location, ok := r.Store.tables.locations[id]
+	if !ok {
+		return nil, apperrors.NotFound("location not found")
+	}
+	return &location, nil
+}
+
+func (r *MemoryLocationRepository) LocationByName(name string) (*entities.Location, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	for _, location := range r.Store.tables.locations {
+		if location.Name == name {
+			return &location, nil
+		}
+	}
+	return nil, apperrors.NotFound("location not found")
+}
+
+func (r *MemoryLocationRepository) CreateLocation(location entities.Location) (entities.Location, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	if r.nameTaken(location.Name, 0) {
+		return entities.Location{}, apperrors.Conflict("a location with this name already exists")
+	}
+
+	location.ID = r.Store.nextID("locations")
+	location.CreatedAt = time.Now()
+	location.UpdatedAt = location.CreatedAt
+	r.Store.tables.locations[location.ID] = location
+
+	return location, nil
+}
+
+func (r *MemoryLocationRepository) CreateLocations(locations []entities.Location) ([]entities.Location, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	created := make([]entities.Location, 0, len(locations))
+	for _, location := range locations {
+		if r.nameTaken(location.Name, 0) {
+			// A single statement would have inserted none of them.
+			for _, inserted := range created {
+				delete(r.Store.tables.locations, inserted.ID)
+			}
+			return nil, apperrors.Conflict("a location with this name already exists")
+		}
+
+		location.ID = r.Store.nextID("locations")
+		location.CreatedAt = time.Now()
+		location.UpdatedAt = location.CreatedAt
+		r.Store.tables.locations[location.ID] = location
+		created = append(created, location)
+	}
+
+	return created, nil
+}
+
+// UpdateLocation only copies non-zero fields, the same way gorm's Updates does with a struct.
+func (r *MemoryLocationRepository) UpdateLocation(id uint, updatedLocation entities.Location) (entities.Location, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	location, ok := r.Store.tables.locations[id]
+	if !ok {
+		return entities.Location{}, apperrors.NotFound("location not found")
+	}
+	if updatedLocation.Name != "" && r.nameTaken(updatedLocation.Name, id) {
+		return entities.Location{}, apperrors.Conflict("a location with this name already exists")
+	}
+
+	setIfNotZero(&location.Name, updatedLocation.Name)
+	setIfNotZero(&location.Country, updatedLocation.Country)
+	setIfNotZero(&location.Description, updatedLocation.Description)
+	setIfNotZero(&location.Latitude, updatedLocation.Latitude)
+	setIfNotZero(&location.Longitude, updatedLocation.Longitude)
+	location.UpdatedAt = time.Now()
+
+	r.Store.tables.locations[id] = location
+	return location, nil
+}
+
+func (r *MemoryLocationRepository) DeleteLocation(id uint) (entities.Location, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	location, ok := r.Store.tables.locations[id]
+	if !ok {
+		return entities.Location{}, apperrors.NotFound("location not found")
+	}
+
+	delete(r.Store.tables.locations, id)
+	return location, nil
+}
+
+func (r *MemoryLocationRepository) nameTaken(name string, exceptID uint) bool {
+	for id, location := range r.Store.tables.locations {
+		if id != exceptID && location.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *MemoryLocationRepository) sorted() []entities.Location {
+	locations := make([]entities.Location, 0, len(r.Store.tables.locations))
+	for _, location := range r.Store.tables.locations {
+		locations = append(locations, location)
+	}
+	sort.Slice(locations, func(i, j int) bool {
+		return locations[i].ID < locations[j].ID
+	})
+	return locations
+}
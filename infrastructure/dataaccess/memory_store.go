@@ -0,0 +1,138 @@
+package dataaccess
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/repositories"
+	"sync"
+)
+
+// MemoryStore holds the tables of the in-memory backend. It is meant for local development
+// and tests: nothing is persisted and every repository shares one lock.
+type MemoryStore struct {
+	mu     sync.RWMutex
+	unitMu sync.Mutex
+	tables memoryTables
+}
+
+type memoryTables struct {
+	nextIDs       map[string]uint
+	destinations  map[uint]entities.Destination
+	locations     map[uint]entities.Location
+	users         map[uint]entities.User
+	roleChanges   map[uint]entities.RoleChange
+	refreshTokens map[uint]entities.RefreshToken
+	revokedTokens map[string]entities.RevokedToken
+	userTokens    map[uint]entities.UserToken
+	trips         map[uint]entities.Trip
+	tripStops     map[uint]entities.TripStop
+	eventLog      map[uint64]entities.EventRecord
+
+	webhooks          map[uint]entities.Webhook
+	webhookDeliveries map[uint]entities.WebhookDelivery
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{tables: newMemoryTables()}
+}
+
+func newMemoryTables() memoryTables {
+	return memoryTables{
+		nextIDs:       make(map[string]uint),
+		destinations:  make(map[uint]entities.Destination),
+		locations:     make(map[uint]entities.Location),
+		users:         make(map[uint]entities.User),
+		roleChanges:   make(map[uint]entities.RoleChange),
+		refreshTokens: make(map[uint]entities.RefreshToken),
+		revokedTokens: make(map[string]entities.RevokedToken),
+		userTokens:    make(map[uint]entities.UserToken),
+		trips:         make(map[uint]entities.Trip),
+		tripStops:     make(map[uint]entities.TripStop),
+		eventLog:      make(map[uint64]entities.EventRecord),
+
+		webhooks:          make(map[uint]entities.Webhook),
+		webhookDeliveries: make(map[uint]entities.WebhookDelivery),
+	}
+}
+
+// nextID hands out ids per table, like a Postgres sequence. Callers hold the write lock.
+func (s *MemoryStore) nextID(table string) uint {
+	s.tables.nextIDs[table]++
+	return s.tables.nextIDs[table]
+}
+
+func (s *MemoryStore) snapshot() memoryTables {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return memoryTables{
+		nextIDs:       copyMap(s.tables.nextIDs),
+		destinations:  copyMap(s.tables.destinations),
+		locations:     copyMap(s.tables.locations),
+		users:         copyMap(s.tables.users),
+		roleChanges:   copyMap(s.tables.roleChanges),
+		refreshTokens: copyMap(s.tables.refreshTokens),
+		revokedTokens: copyMap(s.tables.revokedTokens),
+		userTokens:    copyMap(s.tables.userTokens),
+		trips:         copyMap(s.tables.trips),
+		tripStops:     copyMap(s.tables.tripStops),
+		eventLog:      copyMap(s.tables.eventLog),
+
+		webhooks:          copyMap(s.tables.webhooks),
+		webhookDeliveries: copyMap(s.tables.webhookDeliveries),
+	}
+}
+
+func (s *MemoryStore) restore(tables memoryTables) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tables = tables
+}
+
+func copyMap[K comparable, V any](source map[K]V) map[K]V {
+	copied := make(map[K]V, len(source))
+	for key, value := range source {
+		copied[key] = value
+	}
+	return copied
+}
+
+func NewMemoryRepositories(store *MemoryStore) repositories.Repositories {
+	return repositories.Repositories{
+		Destinations: NewMemoryDestinationRepository(store),
+		Locations:    NewMemoryLocationRepository(store),
+		Users:        NewMemoryUserRepository(store),
+		Tokens:       NewMemoryTokenRepository(store),
+		Trips:        NewMemoryTripRepository(store),
+		Events:       NewMemoryEventLogRepository(store),
+		Webhooks:     NewMemoryWebhookRepository(store),
+	}
+}
+
+type MemoryUnitOfWork struct {
+	Store *MemoryStore
+}
+
+func NewMemoryUnitOfWork(store *MemoryStore) *MemoryUnitOfWork {
+	return &MemoryUnitOfWork{Store: store}
+}
+
+// Do runs units one at a time and rolls back by restoring a snapshot taken before fn ran.
+// Writes made outside a unit while it runs are lost if the unit rolls back.
+func (u *MemoryUnitOfWork) Do(fn func(repos repositories.Repositories) error) (err error) {
+	u.Store.unitMu.Lock()
+	defer u.Store.unitMu.Unlock()
+
+	before := u.Store.snapshot()
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			u.Store.restore(before)
+			panic(recovered)
+		}
+		if err != nil {
+			u.Store.restore(before)
+		}
+	}()
+
+	return fn(NewMemoryRepositories(u.Store))
+}
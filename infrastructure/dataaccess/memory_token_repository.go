@@ -0,0 +1,184 @@
+package dataaccess
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"sort"
+	"time"
+)
+
+type MemoryTokenRepository struct {
+	Store *MemoryStore
+}
+
+func NewMemoryTokenRepository(store *MemoryStore) *MemoryTokenRepository {
+	return &MemoryTokenRepository{Store: store}
+}
+
+func (r *MemoryTokenRepository) CreateRefreshToken(token entities.RefreshToken) (entities.RefreshToken, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	for _, existing := range r.Store.tables.refreshTokens {
+		if existing.TokenHash == token.TokenHash {
+			return entities.RefreshToken{}, apperrors.Conflict("refresh token already exists")
+		}
+	}
+
+	token.ID = r.Store.nextID("refresh_tokens")
+	token.CreatedAt = time.Now()
+	token.UpdatedAt = token.CreatedAt
+	r.Store.tables.refreshTokens[token.ID] = token
+
+	return token, nil
+}
+
+func (r *MemoryTokenRepository) RefreshTokenByHash(hash string) (*entities.RefreshToken, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	for _, token := range r.Store.tables.refreshTokens {
+		if token.TokenHash == hash {
+			return &token, nil
+		}
+	}
+	return nil, apperrors.NotFound("refresh token not found")
+}
+
+func (r *MemoryTokenRepository) RevokeRefreshToken(id uint) (bool, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	token, ok := r.Store.tables.refreshTokens[id]
+	if !ok || token.RevokedAt != nil {
+		return false, nil
+	}
+	revokedAt := time.Now()
+	token.RevokedAt = &revokedAt
+	r.Store.tables.refreshTokens[id] = token
+	return true, nil
+}
+
+func (r *MemoryTokenRepository) RevokeRefreshTokenFamily(familyID string) ([]entities.RefreshToken, error) {
+	return r.revokeRefreshTokens(func(token entities.RefreshToken) bool {
+		return token.FamilyID == familyID
+	})
+}
+
+func (r *MemoryTokenRepository) RevokeUserRefreshTokens(userID uint) ([]entities.RefreshToken, error) {
+	return r.revokeRefreshTokens(func(token entities.RefreshToken) bool {
+		return token.UserID == userID
+	})
+}
+
+func (r *MemoryTokenRepository) revokeRefreshTokens(match func(entities.RefreshToken) bool) ([]entities.RefreshToken, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	revokedAt := time.Now()
+	revoked := make([]entities.RefreshToken, 0)
+	for id, token := range r.Store.tables.refreshTokens {
+		if token.RevokedAt != nil || !match(token) {
+			continue
+		}
+		// Like the Gorm repository, callers get the tokens as they were before revocation.
+		revoked = append(revoked, token)
+		token.RevokedAt = &revokedAt
+		r.Store.tables.refreshTokens[id] = token
+	}
+	sort.Slice(revoked, func(i, j int) bool {
+		return revoked[i].ID < revoked[j].ID
+	})
+
+	return revoked, nil
+}
+
+func (r *MemoryTokenRepository) RevokeAccessTokens(tokens []entities.RevokedToken) error {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	for _, token := range tokens {
+		if _, ok := r.Store.tables.revokedTokens[token.TokenID]; ok {
+			continue
+		}
+		token.CreatedAt = time.Now()
+		r.Store.tables.revokedTokens[token.TokenID] = token
+	}
+	return nil
+}
+
+func (r *MemoryTokenRepository) IsAccessTokenRevoked(tokenID string) (bool, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	_, ok := r.Store.tables.revokedTokens[tokenID]
+	return ok, nil
+}
+
+func (r *MemoryTokenRepository) DeleteExpiredRevocations(before time.Time) error {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	for tokenID, token := range r.Store.tables.revokedTokens {
+		if token.ExpiresAt.Before(before) {
+			delete(r.Store.tables.revokedTokens, tokenID)
+		}
+	}
+	return nil
+}
+
+func (r *MemoryTokenRepository) CreateUserToken(token entities.UserToken) (entities.UserToken, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	for _, existing := range r.Store.tables.userTokens {
+		if existing.TokenHash == token.TokenHash {
+			return entities.UserToken{}, apperrors.Conflict("token already exists")
+		}
+	}
+
+	token.ID = r.Store.nextID("user_tokens")
+	token.CreatedAt = time.Now()
+	token.UpdatedAt = token.CreatedAt
+	r.Store.tables.userTokens[token.ID] = token
+
+	return token, nil
+}
+
+func (r *MemoryTokenRepository) UserTokenByHash(hash string) (*entities.UserToken, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	for _, token := range r.Store.tables.userTokens {
+		if token.TokenHash == hash {
+			return &token, nil
+		}
+	}
+	return nil, apperrors.NotFound("token not found")
+}
+
+func (r *MemoryTokenRepository) UseUserToken(id uint) (bool, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	token, ok := r.Store.tables.userTokens[id]
+	if !ok || token.UsedAt != nil {
+		return false, nil
+	}
+	usedAt := time.Now()
+	token.UsedAt = &usedAt
+	r.Store.tables.userTokens[id] = token
+	return true, nil
+}
+
+func (r *MemoryTokenRepository) DeleteUserTokens(userID uint, purpose entities.UserTokenPurpose) error {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	for id, token := range r.Store.tables.userTokens {
+		if token.UserID == userID && token.Purpose == purpose {
+			delete(r.Store.tables.userTokens, id)
+		}
+	}
+	return nil
+}
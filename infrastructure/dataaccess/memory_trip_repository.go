@@ -0,0 +1,187 @@
+package dataaccess
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"sort"
+	"time"
+)
+
+type MemoryTripRepository struct {
+	Store *MemoryStore
+}
+
+func NewMemoryTripRepository(store *MemoryStore) *MemoryTripRepository {
+	return &MemoryTripRepository{Store: store}
+}
+
+func (r *MemoryTripRepository) AllTrips() ([]entities.Trip, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	return r.trips(func(entities.Trip) bool { return true }), nil
+}
+
+func (r *MemoryTripRepository) TripsByOwner(ownerID uint) ([]entities.Trip, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	return r.trips(func(trip entities.Trip) bool { return trip.OwnerID == ownerID }), nil
+}
+
+func (r *MemoryTripRepository) TripByID(id uint) (*entities.Trip, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	trip, ok := r.Store.tables.trips[id]
+	if !ok {
+		return nil, apperrors.NotFound("trip not found")
+	}
+	trip.Stops = r.stops(id)
+	return &trip, nil
+}
+
+func (r *MemoryTripRepository) CreateTrip(trip entities.Trip) (entities.Trip, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	trip.ID = r.Store.nextID("trips")
+	trip.CreatedAt = time.Now()
+	trip.UpdatedAt = trip.CreatedAt
+
+	for i := range trip.Stops {
+		stop := &trip.Stops[i]
+		stop.ID = r.Store.nextID("trip_stops")
+		stop.TripID = trip.ID
+		stop.CreatedAt = trip.CreatedAt
+		stop.UpdatedAt = trip.CreatedAt
+		r.Store.tables.tripStops[stop.ID] = *stop
+	}
+
+	stored := trip
+	stored.Stops = nil
+	r.Store.tables.trips[trip.ID] = stored
+
+	return trip, nil
+}
+
+func (r *MemoryTripRepository) UpdateTrip(id uint, updatedTrip entities.Trip) (entities.Trip, error) {
+	r.Store.mu.Lock()
+	trip, ok := r.Store.tables.trips[id]
+	if ok {
+		trip.Name = updatedTrip.Name
+		trip.StartDate = updatedTrip.StartDate
+		trip.EndDate = updatedTrip.EndDate
+		trip.UpdatedAt = time.Now()
+		r.Store.tables.trips[id] = trip
+	}
+	r.Store.mu.Unlock()
+
+	if !ok {
+		return entities.Trip{}, apperrors.NotFound("trip not found")
+	}
+
+	updated, err := r.TripByID(id)
+	if err != nil {
+		return entities.Trip{}, err
+	}
+	return *updated, nil
+}
+
+func (r *MemoryTripRepository) DeleteTrip(id uint) (entities.Trip, error) {
+	trip, err := r.TripByID(id)
+	if err != nil {
+		return entities.Trip{}, err
+	}
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	for stopID, stop := range r.Store.tables.tripStops {
+		if stop.TripID == id {
+			delete(r.Store.tables.tripStops, stopID)
+		}
+	}
+	delete(r.Store.tables.trips, id)
+
+	return *trip, nil
+}
+
+func (r *MemoryTripRepository) AddStop(stop entities.TripStop) (entities.TripStop, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	stop.ID = r.Store.nextID("trip_stops")
+	stop.CreatedAt = time.Now()
+	stop.UpdatedAt = stop.CreatedAt
+	r.Store.tables.tripStops[stop.ID] = stop
+
+	return stop, nil
+}
+
+func (r *MemoryTripRepository) DeleteStop(tripID uint, stopID uint) (entities.TripStop, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	stop, ok := r.Store.tables.tripStops[stopID]
+	if !ok || stop.TripID != tripID {
+		return entities.TripStop{}, apperrors.NotFound("stop not found")
+	}
+
+	delete(r.Store.tables.tripStops, stopID)
+	return stop, nil
+}
+
+func (r *MemoryTripRepository) UpdateStopOrder(tripID uint, stops []entities.TripStop) error {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	for _, update := range stops {
+		stop, ok := r.Store.tables.tripStops[update.ID]
+		if !ok || stop.TripID != tripID {
+			continue
+		}
+		stop.Day = update.Day
+		stop.Position = update.Position
+		stop.UpdatedAt = time.Now()
+		r.Store.tables.tripStops[stop.ID] = stop
+	}
+	return nil
+}
+
+// trips returns the matching trips with their stops, ordered like the Gorm repository.
+func (r *MemoryTripRepository) trips(keep func(entities.Trip) bool) []entities.Trip {
+	trips := make([]entities.Trip, 0)
+	for _, trip := range r.Store.tables.trips {
+		if keep(trip) {
+			trip.Stops = r.stops(trip.ID)
+			trips = append(trips, trip)
+		}
+	}
+	sort.Slice(trips, func(i, j int) bool {
+		if trips[i].StartDate != trips[j].StartDate {
+			return trips[i].StartDate < trips[j].StartDate
+		}
+		return trips[i].ID < trips[j].ID
+	})
+	return trips
+}
+
+func (r *MemoryTripRepository) stops(tripID uint) []entities.TripStop {
+	stops := make([]entities.TripStop, 0)
+	for _, stop := range r.Store.tables.tripStops {
+		if stop.TripID == tripID {
+			stops = append(stops, stop)
+		}
+	}
+	sort.Slice(stops, func(i, j int) bool {
+		if stops[i].Day != stops[j].Day {
+			return stops[i].Day < stops[j].Day
+		}
+		if stops[i].Position != stops[j].Position {
+			return stops[i].Position < stops[j].Position
+		}
+		return stops[i].ID < stops[j].ID
+	})
+	return stops
+}
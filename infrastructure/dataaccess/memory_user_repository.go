@@ -0,0 +1,275 @@
+package dataaccess
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"golang.org/x/crypto/bcrypt"
+	"sort"
+	"time"
+)
+
+type MemoryUserRepository struct {
+	Store *MemoryStore
+}
+
+func NewMemoryUserRepository(store *MemoryStore) *MemoryUserRepository {
+	return &MemoryUserRepository{Store: store}
+}
+
+var errUserTaken = apperrors.Conflict("username, email or phone number is already in use")
+
+func (r *MemoryUserRepository) AllUsers() ([]entities.User, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	users := make([]entities.User, 0, len(r.Store.tables.users))
+	for _, user := range r.Store.tables.users {
+		users = append(users, user)
+	}
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].ID < users[j].ID
+	})
+	return users, nil
+}
+
+func (r *MemoryUserRepository) AllUserIDs() ([]uint, error) {
+	users, _ := r.AllUsers()
+
+	ids := make([]uint, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+	return ids, nil
+}
+
+func (r *MemoryUserRepository) UserByID(id uint) (*entities.User, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	user, ok := r.Store.tables.users[id]
+	if !ok {
+		return nil, apperrors.NotFound("user not found")
+	}
+	return &user, nil
+}
+
+func (r *MemoryUserRepository) UserByEmail(email string) (*entities.User, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	for _, user := range r.Store.tables.users {
+		if user.Email == email {
+			return &user, nil
+		}
+	}
+	return nil, apperrors.NotFound("user not found")
+}
+
+func (r *MemoryUserRepository) Register(user entities.User) (entities.User, error) {
+	user.Role = entities.NormalUser
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return entities.User{}, err
+	}
+	user.Password = string(hashedPassword)
+
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	if r.taken(user, 0) {
+		return entities.User{}, errUserTaken
+	}
+
+	user.ID = r.Store.nextID("users")
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = user.CreatedAt
+	r.Store.tables.users[user.ID] = user
+
+	return user, nil
+}
+
+func (r *MemoryUserRepository) CreateUsers(users []entities.User) ([]entities.User, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	created := make([]entities.User, 0, len(users))
+	for _, user := range users {
+		if r.taken(user, 0) {
+			// A single statement would have inserted none of them.
+			for _, inserted := range created {
+				delete(r.Store.tables.users, inserted.ID)
+			}
+			return nil, errUserTaken
+		}
+
+		user.ID = r.Store.nextID("users")
+		user.CreatedAt = time.Now()
+		user.UpdatedAt = user.CreatedAt
+		r.Store.tables.users[user.ID] = user
+		created = append(created, user)
+	}
+
+	return created, nil
+}
+
+func (r *MemoryUserRepository) Authenticate(loginData entities.LoginRequest) (*entities.User, error) {
+	r.Store.mu.RLock()
+	var found *entities.User
+	for _, user := range r.Store.tables.users {
+		if user.Email == loginData.Email {
+			user := user
+			found = &user
+			break
+		}
+	}
+	r.Store.mu.RUnlock()
+
+	if found == nil {
+		return nil, errInvalidCredentials
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(found.Password), []byte(loginData.Password)); err != nil {
+		return nil, errInvalidCredentials
+	}
+
+	return found, nil
+}
+
+func (r *MemoryUserRepository) DeleteUser(id uint) (entities.User, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	user, ok := r.Store.tables.users[id]
+	if !ok {
+		return entities.User{}, apperrors.NotFound("user not found")
+	}
+
+	delete(r.Store.tables.users, id)
+	return user, nil
+}
+
+// UpdateUser only copies non-zero fields, the same way gorm's Updates does with a struct.
+func (r *MemoryUserRepository) UpdateUser(id uint, updatedUser entities.User) (entities.User, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	user, ok := r.Store.tables.users[id]
+	if !ok {
+		return entities.User{}, apperrors.NotFound("user not found")
+	}
+	if r.taken(updatedUser, id) {
+		return entities.User{}, errUserTaken
+	}
+
+	setIfNotZero(&user.Username, updatedUser.Username)
+	setIfNotZero(&user.Email, updatedUser.Email)
+	setIfNotZero(&user.FirstName, updatedUser.FirstName)
+	setIfNotZero(&user.LastName, updatedUser.LastName)
+	setIfNotZero(&user.PhoneNumber, updatedUser.PhoneNumber)
+	setIfNotZero(&user.DateOfBirth, updatedUser.DateOfBirth)
+	setIfNotZero(&user.Address, updatedUser.Address)
+	user.UpdatedAt = time.Now()
+
+	r.Store.tables.users[id] = user
+	return user, nil
+}
+
+func (r *MemoryUserRepository) UpdatePassword(id uint, passwordHash string) error {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	user, ok := r.Store.tables.users[id]
+	if !ok {
+		return apperrors.NotFound("user not found")
+	}
+
+	user.Password = passwordHash
+	user.UpdatedAt = time.Now()
+	r.Store.tables.users[id] = user
+	return nil
+}
+
+func (r *MemoryUserRepository) SetEmailVerifiedAt(id uint, verifiedAt *time.Time) error {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	user, ok := r.Store.tables.users[id]
+	if !ok {
+		return apperrors.NotFound("user not found")
+	}
+
+	user.EmailVerifiedAt = verifiedAt
+	user.UpdatedAt = time.Now()
+	r.Store.tables.users[id] = user
+	return nil
+}
+
+func (r *MemoryUserRepository) UpdateRole(id uint, role entities.AccessType) error {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	user, ok := r.Store.tables.users[id]
+	if !ok {
+		return apperrors.NotFound("user not found")
+	}
+
+	user.Role = role
+	user.UpdatedAt = time.Now()
+	r.Store.tables.users[id] = user
+	return nil
+}
+
+func (r *MemoryUserRepository) CountUsersByRole(role entities.AccessType) (int64, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	var count int64
+	for _, user := range r.Store.tables.users {
+		if user.Role == role {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *MemoryUserRepository) CreateRoleChange(change entities.RoleChange) (entities.RoleChange, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	change.ID = r.Store.nextID("role_changes")
+	change.CreatedAt = time.Now()
+	r.Store.tables.roleChanges[change.ID] = change
+
+	return change, nil
+}
+
+func (r *MemoryUserRepository) RoleChanges(userID uint) ([]entities.RoleChange, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	changes := make([]entities.RoleChange, 0)
+	for _, change := range r.Store.tables.roleChanges {
+		if change.UserID == userID {
+			changes = append(changes, change)
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].ID < changes[j].ID
+	})
+	return changes, nil
+}
+
+// taken mirrors the unique constraints on username, email and phone number.
+func (r *MemoryUserRepository) taken(candidate entities.User, exceptID uint) bool {
+	for id, user := range r.Store.tables.users {
+		if id == exceptID {
+			continue
+		}
+		if (candidate.Username != "" && user.Username == candidate.Username) ||
+			(candidate.Email != "" && user.Email == candidate.Email) ||
+			(candidate.PhoneNumber != "" && user.PhoneNumber == candidate.PhoneNumber) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,207 @@
+package dataaccess
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"sort"
+	"time"
+)
+
+type MemoryWebhookRepository struct {
+	Store *MemoryStore
+}
+
+func NewMemoryWebhookRepository(store *MemoryStore) *MemoryWebhookRepository {
+	return &MemoryWebhookRepository{Store: store}
+}
+
+func (r *MemoryWebhookRepository) AllWebhooks() ([]entities.Webhook, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	return r.webhooks(func(entities.Webhook) bool { return true }), nil
+}
+
+func (r *MemoryWebhookRepository) ActiveWebhooks() ([]entities.Webhook, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	return r.webhooks(func(webhook entities.Webhook) bool { return webhook.Active }), nil
+}
+
+func (r *MemoryWebhookRepository) WebhookByID(id uint) (*entities.Webhook, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	webhook, ok := r.Store.tables.webhooks[id]
+	if !ok {
+		return nil, apperrors.NotFound("webhook not found")
+	}
+	return &webhook, nil
+}
+
+func (r *MemoryWebhookRepository) CreateWebhook(webhook entities.Webhook) (entities.Webhook, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	webhook.ID = r.Store.nextID("webhooks")
+	webhook.CreatedAt = time.Now()
+	webhook.UpdatedAt = webhook.CreatedAt
+	r.Store.tables.webhooks[webhook.ID] = webhook
+
+	return webhook, nil
+}
+
+func (r *MemoryWebhookRepository) UpdateWebhook(id uint, updatedWebhook entities.Webhook) (entities.Webhook, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	webhook, ok := r.Store.tables.webhooks[id]
+	if !ok {
+		return entities.Webhook{}, apperrors.NotFound("webhook not found")
+	}
+
+	webhook.URL = updatedWebhook.URL
+	webhook.EventTypes = updatedWebhook.EventTypes
+	webhook.Active = updatedWebhook.Active
+	setIfNotZero(&webhook.Secret, updatedWebhook.Secret)
+	webhook.UpdatedAt = time.Now()
+
+	r.Store.tables.webhooks[id] = webhook
+	return webhook, nil
+}
+
+func (r *MemoryWebhookRepository) DeleteWebhook(id uint) (entities.Webhook, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	webhook, ok := r.Store.tables.webhooks[id]
+	if !ok {
+		return entities.Webhook{}, apperrors.NotFound("webhook not found")
+	}
+
+	delete(r.Store.tables.webhooks, id)
+	return webhook, nil
+}
+
+func (r *MemoryWebhookRepository) CreateDelivery(delivery entities.WebhookDelivery) (entities.WebhookDelivery, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	delivery.ID = r.Store.nextID("webhook_deliveries")
+	delivery.CreatedAt = time.Now()
+	delivery.UpdatedAt = delivery.CreatedAt
+	r.Store.tables.webhookDeliveries[delivery.ID] = delivery
+
+	return delivery, nil
+}
+
+func (r *MemoryWebhookRepository) DeliveryByID(id uint) (*entities.WebhookDelivery, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	delivery, ok := r.Store.tables.webhookDeliveries[id]
+	if !ok {
+		return nil, apperrors.NotFound("delivery not found")
+	}
+	return &delivery, nil
+}
+
+func (r *MemoryWebhookRepository) DeliveriesForWebhook(webhookID uint, query entities.WebhookDeliveryQuery) ([]entities.WebhookDelivery, int64, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	matches := make([]entities.WebhookDelivery, 0)
+	for _, delivery := range r.Store.tables.webhookDeliveries {
+		if delivery.WebhookID == webhookID && (query.Status == "" || delivery.Status == query.Status) {
+			matches = append(matches, delivery)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].ID > matches[j].ID
+	})
+
+	total := int64(len(matches))
+	start := query.Offset()
+	if start > len(matches) {
+		start = len(matches)
+	}
+	end := start + query.Limit
+	if end > len(matches) {
+		end = len(matches)
+	}
+
+	return matches[start:end], total, nil
+}
+
+func (r *MemoryWebhookRepository) DueDeliveries(now time.Time, limit int) ([]entities.WebhookDelivery, error) {
+	r.Store.mu.RLock()
+	defer r.Store.mu.RUnlock()
+
+	due := make([]entities.WebhookDelivery, 0)
+	for _, delivery := range r.Store.tables.webhookDeliveries {
+		if delivery.Status == entities.DeliveryPending && !delivery.NextAttemptAt.After(now) {
+			due = append(due, delivery)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].NextAttemptAt.Equal(due[j].NextAttemptAt) {
+			return due[i].NextAttemptAt.Before(due[j].NextAttemptAt)
+		}
+		return due[i].ID < due[j].ID
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	return due, nil
+}
+
+func (r *MemoryWebhookRepository) ClaimDelivery(id uint, now time.Time, until time.Time) (bool, error) {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	delivery, ok := r.Store.tables.webhookDeliveries[id]
+	if !ok || delivery.Status != entities.DeliveryPending || delivery.NextAttemptAt.After(now) {
+		return false, nil
+	}
+
+	delivery.NextAttemptAt = until
+	delivery.UpdatedAt = time.Now()
+	r.Store.tables.webhookDeliveries[id] = delivery
+	return true, nil
+}
+
+func (r *MemoryWebhookRepository) SaveAttempt(attempt entities.WebhookDelivery) error {
+	r.Store.mu.Lock()
+	defer r.Store.mu.Unlock()
+
+	delivery, ok := r.Store.tables.webhookDeliveries[attempt.ID]
+	if !ok {
+		return apperrors.NotFound("delivery not found")
+	}
+
+	delivery.Status = attempt.Status
+	delivery.Attempts = attempt.Attempts
+	delivery.ResponseStatus = attempt.ResponseStatus
+	delivery.LastError = attempt.LastError
+	delivery.NextAttemptAt = attempt.NextAttemptAt
+	delivery.DeliveredAt = attempt.DeliveredAt
+	delivery.UpdatedAt = time.Now()
+
+	r.Store.tables.webhookDeliveries[attempt.ID] = delivery
+	return nil
+}
+
+func (r *MemoryWebhookRepository) webhooks(keep func(entities.Webhook) bool) []entities.Webhook {
+	webhooks := make([]entities.Webhook, 0)
+	for _, webhook := range r.Store.tables.webhooks {
+		if keep(webhook) {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	sort.Slice(webhooks, func(i, j int) bool {
+		return webhooks[i].ID < webhooks[j].ID
+	})
+	return webhooks
+}
@@ -0,0 +1,238 @@
+package dataaccess
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"gorm.io/gorm"
+	"html"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	searchConfig        = "simple"
+	similarityThreshold = 0.3
+	headlineOptions     = "StartSel=<mark>, StopSel=</mark>, MaxWords=20, MinWords=8, MaxFragments=2"
+	headlineMaxWords    = 20
+)
+
+// Search documents and trigram haystacks of each table on its own. Migration 0012 indexes
+// exactly these expressions, with the searchConfig spelled out, so change them together.
+const (
+	destinationDocument = `setweight(to_tsvector('simple', coalesce(destinations.name, '')), 'A') ||
+		setweight(to_tsvector('simple', coalesce(destinations.description, '')), 'C')`
+	locationDocument = `setweight(to_tsvector('simple', coalesce(locations.name, '') || ' ' || coalesce(locations.country, '')), 'A') ||
+		setweight(to_tsvector('simple', coalesce(locations.description, '')), 'C')`
+	locationHaystack = `(locations.name || ' ' || locations.country)`
+)
+
+// Weights used by ts_rank for the A, B and C labels; the portable ranking reuses them so
+// results are ordered the same way on every backend.
+const (
+	weightA = 1.0
+	weightB = 0.4
+	weightC = 0.2
+)
+
+// prefixTsQuery turns free text into a tsquery where every word is matched as a prefix,
+// so "lap fin" matches "Lapland, Finland". Punctuation is dropped so user input can never
+// produce tsquery syntax errors.
+func prefixTsQuery(term string) string {
+	return strings.Join(prefixWords(term), " & ")
+}
+
+// anyPrefixTsQuery matches where any word of prefixTsQuery does. A document spread over
+// several tables matches prefixTsQuery only if one of its parts matches this.
+func anyPrefixTsQuery(term string) string {
+	return strings.Join(prefixWords(term), " | ")
+}
+
+func prefixWords(term string) []string {
+	words := searchWords(term)
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+	return words
+}
+
+// withSimilarityThreshold runs query in a transaction where the <% operator, which unlike
+// word_similarity can use a trigram index, matches above similarityThreshold.
+func withSimilarityThreshold(db *gorm.DB, query func(tx *gorm.DB) error) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		threshold := strconv.FormatFloat(similarityThreshold, 'f', -1, 64)
+		if err := tx.Exec("SELECT set_config('pg_trgm.word_similarity_threshold', ?, true)", threshold).Error; err != nil {
+			return err
+		}
+		return query(tx)
+	})
+}
+
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+type weightedText struct {
+	text   string
+	weight float64
+}
+
+// textRank is the portable counterpart of the full-text query used on Postgres: every
+// query word has to prefix-match a word of some field, and each contributes the weight
+// of the best field it matched. Trigram similarity has no equivalent here, so typos only
+// match on Postgres.
+func textRank(queryWords []string, fields ...weightedText) (float64, bool) {
+	fieldWords := make([][]string, len(fields))
+	for i, field := range fields {
+		fieldWords[i] = searchWords(field.text)
+	}
+
+	rank := 0.0
+	for _, queryWord := range queryWords {
+		best := 0.0
+		for i, words := range fieldWords {
+			for _, word := range words {
+				if strings.HasPrefix(word, queryWord) && fields[i].weight > best {
+					best = fields[i].weight
+				}
+			}
+		}
+		if best == 0 {
+			return 0, false
+		}
+		rank += best
+	}
+
+	return rank / float64(len(queryWords)), true
+}
+
+// escapedHTML is the SQL counterpart of html.EscapeString. Descriptions are escaped before
+// ts_headline marks them up, so markup they contain reaches clients as text.
+func escapedHTML(expression string) string {
+	return `replace(replace(replace(replace(replace(` + expression +
+		`, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&#34;'), '''', '&#39;')`
+}
+
+// headline marks the words of text that match the query the way ts_headline does, keeping
+// at most headlineMaxWords words starting shortly before the first match. The text is
+// HTML-escaped, so only the marks are markup.
+func headline(text string, queryWords []string) string {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return ""
+	}
+
+	first := -1
+	for i, word := range words {
+		normalized := strings.Join(searchWords(word), "")
+		words[i] = html.EscapeString(word)
+		for _, queryWord := range queryWords {
+			if normalized != "" && strings.HasPrefix(normalized, queryWord) {
+				words[i] = "<mark>" + words[i] + "</mark>"
+				if first < 0 {
+					first = i
+				}
+				break
+			}
+		}
+	}
+
+	start := 0
+	if first > 2 {
+		start = first - 2
+	}
+	end := start + headlineMaxWords
+	if end > len(words) {
+		end = len(words)
+	}
+
+	return strings.Join(words[start:end], " ")
+}
+
+func rankDestination(queryWords []string, destination entities.Destination, location entities.Location) (entities.DestinationSearchResult, bool) {
+	rank, ok := textRank(queryWords,
+		weightedText{destination.Name, weightA},
+		weightedText{location.Name + " " + location.Country, weightB},
+		weightedText{destination.Description, weightC},
+	)
+	if !ok {
+		return entities.DestinationSearchResult{}, false
+	}
+
+	return entities.DestinationSearchResult{
+		Destination: destination,
+		Location:    location.Name,
+		Country:     location.Country,
+		Rank:        rank,
+		Snippet:     headline(destination.Description, queryWords),
+	}, true
+}
+
+func rankLocation(queryWords []string, location entities.Location) (entities.LocationSearchResult, bool) {
+	rank, ok := textRank(queryWords,
+		weightedText{location.Name + " " + location.Country, weightA},
+		weightedText{location.Description, weightC},
+	)
+	if !ok {
+		return entities.LocationSearchResult{}, false
+	}
+
+	return entities.LocationSearchResult{
+		Location: location,
+		Rank:     rank,
+		Snippet:  headline(location.Description, queryWords),
+	}, true
+}
+
+func sortDestinationResults(results []entities.DestinationSearchResult, limit int) []entities.DestinationSearchResult {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Rank != results[j].Rank {
+			return results[i].Rank > results[j].Rank
+		}
+		return results[i].Destination.ID < results[j].Destination.ID
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+func sortLocationResults(results []entities.LocationSearchResult, limit int) []entities.LocationSearchResult {
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Rank != results[j].Rank {
+			return results[i].Rank > results[j].Rank
+		}
+		return results[i].Location.ID < results[j].Location.ID
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// nearestDestinations computes distances in Go for backends without trigonometric SQL.
+func nearestDestinations(candidates []entities.Destination, latitude float64, longitude float64, radiusKm float64, limit int) []entities.DestinationWithDistance {
+	nearby := make([]entities.DestinationWithDistance, 0)
+	for _, destination := range candidates {
+		if destination.Latitude == nil || destination.Longitude == nil {
+			continue
+		}
+		distance := entities.HaversineKm(latitude, longitude, *destination.Latitude, *destination.Longitude)
+		if distance <= radiusKm {
+			nearby = append(nearby, entities.DestinationWithDistance{Destination: destination, DistanceKm: distance})
+		}
+	}
+
+	sort.SliceStable(nearby, func(i, j int) bool {
+		if nearby[i].DistanceKm != nearby[j].DistanceKm {
+			return nearby[i].DistanceKm < nearby[j].DistanceKm
+		}
+		return nearby[i].ID < nearby[j].ID
+	})
+	if len(nearby) > limit {
+		nearby = nearby[:limit]
+	}
+	return nearby
+}
@@ -0,0 +1,133 @@
+package dataaccess
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type GormTokenRepository struct {
+	Db *gorm.DB
+}
+
+func NewGormTokenRepository(db *gorm.DB) *GormTokenRepository {
+	return &GormTokenRepository{Db: db}
+}
+
+func (r *GormTokenRepository) CreateRefreshToken(token entities.RefreshToken) (entities.RefreshToken, error) {
+	if err := r.Db.Create(&token).Error; err != nil {
+		return entities.RefreshToken{}, err
+	}
+	return token, nil
+}
+
+func (r *GormTokenRepository) RefreshTokenByHash(hash string) (*entities.RefreshToken, error) {
+	var token entities.RefreshToken
+
+	if err := r.Db.First(&token, "token_hash = ?", hash).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NotFound("refresh token not found")
+		}
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (r *GormTokenRepository) RevokeRefreshToken(id uint) (bool, error) {
+	result := r.Db.Model(&entities.RefreshToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *GormTokenRepository) RevokeRefreshTokenFamily(familyID string) ([]entities.RefreshToken, error) {
+	return r.revokeRefreshTokens(r.Db.Where("family_id = ?", familyID))
+}
+
+func (r *GormTokenRepository) RevokeUserRefreshTokens(userID uint) ([]entities.RefreshToken, error) {
+	return r.revokeRefreshTokens(r.Db.Where("user_id = ?", userID))
+}
+
+func (r *GormTokenRepository) revokeRefreshTokens(scope *gorm.DB) ([]entities.RefreshToken, error) {
+	var tokens []entities.RefreshToken
+
+	if err := scope.Where("revoked_at IS NULL").Find(&tokens).Error; err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return tokens, nil
+	}
+
+	ids := make([]uint, 0, len(tokens))
+	for _, token := range tokens {
+		ids = append(ids, token.ID)
+	}
+
+	if err := r.Db.Model(&entities.RefreshToken{}).Where("id IN ?", ids).Update("revoked_at", time.Now()).Error; err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+func (r *GormTokenRepository) RevokeAccessTokens(tokens []entities.RevokedToken) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+	return r.Db.Clauses(clause.OnConflict{DoNothing: true}).Create(&tokens).Error
+}
+
+func (r *GormTokenRepository) IsAccessTokenRevoked(tokenID string) (bool, error) {
+	var count int64
+
+	if err := r.Db.Model(&entities.RevokedToken{}).Where("token_id = ?", tokenID).Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r *GormTokenRepository) DeleteExpiredRevocations(before time.Time) error {
+	return r.Db.Where("expires_at < ?", before).Delete(&entities.RevokedToken{}).Error
+}
+
+func (r *GormTokenRepository) CreateUserToken(token entities.UserToken) (entities.UserToken, error) {
+	if err := r.Db.Create(&token).Error; err != nil {
+		return entities.UserToken{}, err
+	}
+	return token, nil
+}
+
+func (r *GormTokenRepository) UserTokenByHash(hash string) (*entities.UserToken, error) {
+	var token entities.UserToken
+
+	if err := r.Db.First(&token, "token_hash = ?", hash).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NotFound("token not found")
+		}
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (r *GormTokenRepository) UseUserToken(id uint) (bool, error) {
+	result := r.Db.Model(&entities.UserToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *GormTokenRepository) DeleteUserTokens(userID uint, purpose entities.UserTokenPurpose) error {
+	return r.Db.Where("user_id = ? AND purpose = ?", userID, purpose).Delete(&entities.UserToken{}).Error
+}
@@ -0,0 +1,135 @@
+package dataaccess
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"errors"
+	"gorm.io/gorm"
+)
+
+type GormTripRepository struct {
+	Db *gorm.DB
+}
+
+func NewGormTripRepository(db *gorm.DB) *GormTripRepository {
+	return &GormTripRepository{Db: db}
+}
+
+func orderedStops(db *gorm.DB) *gorm.DB {
+	return db.Order("day, position, id")
+}
+
+func (r *GormTripRepository) AllTrips() ([]entities.Trip, error) {
+	var trips []entities.Trip
+	result := r.Db.Preload("Stops", orderedStops).Order("start_date, id").Find(&trips)
+	return trips, result.Error
+}
+
+func (r *GormTripRepository) TripsByOwner(ownerID uint) ([]entities.Trip, error) {
+	var trips []entities.Trip
+	result := r.Db.Preload("Stops", orderedStops).Where("owner_id = ?", ownerID).Order("start_date, id").Find(&trips)
+	return trips, result.Error
+}
+
+func (r *GormTripRepository) TripByID(id uint) (*entities.Trip, error) {
+	var trip entities.Trip
+
+	if err := r.Db.Preload("Stops", orderedStops).First(&trip, "ID = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NotFound("trip not found")
+		}
+		return nil, err
+	}
+
+	return &trip, nil
+}
+
+func (r *GormTripRepository) CreateTrip(trip entities.Trip) (entities.Trip, error) {
+	if err := r.Db.Create(&trip).Error; err != nil {
+		return entities.Trip{}, err
+	}
+	return trip, nil
+}
+
+func (r *GormTripRepository) UpdateTrip(id uint, updatedTrip entities.Trip) (entities.Trip, error) {
+	var trip entities.Trip
+
+	if err := r.Db.First(&trip, "ID = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.Trip{}, apperrors.NotFound("trip not found")
+		}
+		return entities.Trip{}, err
+	}
+
+	fields := map[string]interface{}{
+		"name":       updatedTrip.Name,
+		"start_date": updatedTrip.StartDate,
+		"end_date":   updatedTrip.EndDate,
+	}
+	if err := r.Db.Model(&trip).Updates(fields).Error; err != nil {
+		return entities.Trip{}, err
+	}
+
+	updated, err := r.TripByID(id)
+	if err != nil {
+		return entities.Trip{}, err
+	}
+	return *updated, nil
+}
+
+func (r *GormTripRepository) DeleteTrip(id uint) (entities.Trip, error) {
+	trip, err := r.TripByID(id)
+	if err != nil {
+		return entities.Trip{}, err
+	}
+
+	err = r.Db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("trip_id = ?", id).Delete(&entities.TripStop{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entities.Trip{}, id).Error
+	})
+	if err != nil {
+		return entities.Trip{}, err
+	}
+
+	return *trip, nil
+}
+
+func (r *GormTripRepository) AddStop(stop entities.TripStop) (entities.TripStop, error) {
+	if err := r.Db.Create(&stop).Error; err != nil {
+		return entities.TripStop{}, translateWriteError(err, "stop already exists")
+	}
+	return stop, nil
+}
+
+func (r *GormTripRepository) DeleteStop(tripID uint, stopID uint) (entities.TripStop, error) {
+	var stop entities.TripStop
+
+	if err := r.Db.First(&stop, "id = ? AND trip_id = ?", stopID, tripID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.TripStop{}, apperrors.NotFound("stop not found")
+		}
+		return entities.TripStop{}, err
+	}
+
+	if err := r.Db.Delete(&stop).Error; err != nil {
+		return entities.TripStop{}, err
+	}
+
+	return stop, nil
+}
+
+func (r *GormTripRepository) UpdateStopOrder(tripID uint, stops []entities.TripStop) error {
+	return r.Db.Transaction(func(tx *gorm.DB) error {
+		for _, stop := range stops {
+			err := tx.Model(&entities.TripStop{}).
+				Where("id = ? AND trip_id = ?", stop.ID, tripID).
+				Updates(map[string]interface{}{"day": stop.Day, "position": stop.Position}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
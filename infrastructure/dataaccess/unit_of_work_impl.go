@@ -0,0 +1,32 @@
+package dataaccess
+
+import (
+	"Trip-Trove-API/domain/repositories"
+	"gorm.io/gorm"
+)
+
+func NewGormRepositories(db *gorm.DB) repositories.Repositories {
+	return repositories.Repositories{
+		Destinations: NewGormDestinationRepository(db),
+		Locations:    NewGormLocationRepository(db),
+		Users:        NewGormUserRepository(db),
+		Tokens:       NewGormTokenRepository(db),
+		Trips:        NewGormTripRepository(db),
+		Events:       NewGormEventLogRepository(db),
+		Webhooks:     NewGormWebhookRepository(db),
+	}
+}
+
+type GormUnitOfWork struct {
+	Db *gorm.DB
+}
+
+func NewGormUnitOfWork(db *gorm.DB) *GormUnitOfWork {
+	return &GormUnitOfWork{Db: db}
+}
+
+func (u *GormUnitOfWork) Do(fn func(repos repositories.Repositories) error) error {
+	return u.Db.Transaction(func(tx *gorm.DB) error {
+		return fn(NewGormRepositories(tx))
+	})
+}
@@ -1,14 +1,16 @@
 package dataaccess
 
 import (
+	"Trip-Trove-API/domain/apperrors"
 	"Trip-Trove-API/domain/entities"
-	"Trip-Trove-API/utils"
 	"errors"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
-	"os"
+	"time"
 )
 
+var errInvalidCredentials = apperrors.Unauthorized("invalid email or password")
+
 type GormUserRepository struct {
 	Db *gorm.DB
 }
@@ -38,7 +40,20 @@ func (r *GormUserRepository) UserByID(id uint) (*entities.User, error) {
 
 	if err := r.Db.First(&user, "ID = ?", id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("user not found")
+			return nil, apperrors.NotFound("user not found")
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (r *GormUserRepository) UserByEmail(email string) (*entities.User, error) {
+	var user entities.User
+
+	if err := r.Db.First(&user, "email = ?", email).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NotFound("user not found")
 		}
 		return nil, err
 	}
@@ -55,42 +70,32 @@ func (r *GormUserRepository) Register(user entities.User) (entities.User, error)
 	}
 	user.Password = string(hashedPassword)
 	if err := r.Db.Create(&user).Error; err != nil {
-		return entities.User{}, err
+		return entities.User{}, translateWriteError(err, "username, email or phone number is already in use")
 	}
 	return user, nil
 }
 
-func (r *GormUserRepository) Login(loginData entities.LoginRequest) (entities.LoginResponse, error) {
+func (r *GormUserRepository) CreateUsers(users []entities.User) ([]entities.User, error) {
+	if err := r.Db.Create(&users).Error; err != nil {
+		return nil, translateWriteError(err, "username, email or phone number is already in use")
+	}
+	return users, nil
+}
+
+func (r *GormUserRepository) Authenticate(loginData entities.LoginRequest) (*entities.User, error) {
 	var user entities.User
 
 	if err := r.Db.First(&user, "email = ?", loginData.Email).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return entities.LoginResponse{}, errors.New("email not found")
+			return nil, errInvalidCredentials
 		}
-		return entities.LoginResponse{}, err
+		return nil, err
 	}
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(loginData.Password)); err != nil {
-		return entities.LoginResponse{}, err
-	}
-
-	jwtWrapper := utils.JwtWrapper{
-		SecretKey:         os.Getenv("JWT_SECRET"),
-		Issuer:            "AuthService",
-		ExpirationMinutes: 60,
-	}
-
-	signedToken, err := jwtWrapper.GenerateToken(user)
-	if err != nil {
-		return entities.LoginResponse{}, err
+		return nil, errInvalidCredentials
 	}
 
-	data := entities.LoginResponse{
-		Email: user.Email,
-		Jwt:   signedToken,
-		ID:    user.ID,
-	}
-
-	return data, nil
+	return &user, nil
 }
 
 func (r *GormUserRepository) DeleteUser(id uint) (entities.User, error) {
@@ -98,7 +103,7 @@ func (r *GormUserRepository) DeleteUser(id uint) (entities.User, error) {
 
 	if err := r.Db.First(&user, "ID = ?", id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return entities.User{}, errors.New("user not found")
+			return entities.User{}, apperrors.NotFound("user not found")
 		}
 		return entities.User{}, err
 	}
@@ -115,20 +120,74 @@ func (r *GormUserRepository) UpdateUser(id uint, updatedUser entities.User) (ent
 
 	if err := r.Db.First(&user, "ID = ?", id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return entities.User{}, errors.New("user not found")
+			return entities.User{}, apperrors.NotFound("user not found")
 		}
 		return entities.User{}, err
 	}
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(updatedUser.Password), bcrypt.DefaultCost)
-	if err != nil {
-		return entities.User{}, err
+	if err := r.Db.Model(&user).Omit("password", "access_type").Updates(updatedUser).Error; err != nil {
+		return entities.User{}, translateWriteError(err, "username, email or phone number is already in use")
 	}
-	updatedUser.Password = string(hashedPassword)
 
-	if err := r.Db.Model(&user).Updates(updatedUser).Error; err != nil {
-		return entities.User{}, err
+	return user, nil
+}
+
+func (r *GormUserRepository) UpdatePassword(id uint, passwordHash string) error {
+	result := r.Db.Model(&entities.User{}).Where("id = ?", id).Update("password", passwordHash)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NotFound("user not found")
 	}
+	return nil
+}
 
-	return user, nil
+func (r *GormUserRepository) SetEmailVerifiedAt(id uint, verifiedAt *time.Time) error {
+	result := r.Db.Model(&entities.User{}).Where("id = ?", id).Update("email_verified_at", verifiedAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NotFound("user not found")
+	}
+	return nil
+}
+
+func (r *GormUserRepository) UpdateRole(id uint, role entities.AccessType) error {
+	result := r.Db.Model(&entities.User{}).Where("id = ?", id).Update("access_type", role)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.NotFound("user not found")
+	}
+	return nil
+}
+
+func (r *GormUserRepository) CountUsersByRole(role entities.AccessType) (int64, error) {
+	var count int64
+
+	if err := r.Db.Model(&entities.User{}).Where("access_type = ?", role).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *GormUserRepository) CreateRoleChange(change entities.RoleChange) (entities.RoleChange, error) {
+	if err := r.Db.Create(&change).Error; err != nil {
+		return entities.RoleChange{}, err
+	}
+	return change, nil
+}
+
+func (r *GormUserRepository) RoleChanges(userID uint) ([]entities.RoleChange, error) {
+	changes := make([]entities.RoleChange, 0)
+
+	if err := r.Db.Where("user_id = ?", userID).Order("id").Find(&changes).Error; err != nil {
+		return nil, err
+	}
+
+	return changes, nil
 }
@@ -0,0 +1,157 @@
+package dataaccess
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+type GormWebhookRepository struct {
+	Db *gorm.DB
+}
+
+func NewGormWebhookRepository(db *gorm.DB) *GormWebhookRepository {
+	return &GormWebhookRepository{Db: db}
+}
+
+func (r *GormWebhookRepository) AllWebhooks() ([]entities.Webhook, error) {
+	var webhooks []entities.Webhook
+	result := r.Db.Order("id").Find(&webhooks)
+	return webhooks, result.Error
+}
+
+func (r *GormWebhookRepository) ActiveWebhooks() ([]entities.Webhook, error) {
+	var webhooks []entities.Webhook
+	result := r.Db.Where("active = ?", true).Order("id").Find(&webhooks)
+	return webhooks, result.Error
+}
+
+func (r *GormWebhookRepository) WebhookByID(id uint) (*entities.Webhook, error) {
+	var webhook entities.Webhook
+
+	if err := r.Db.First(&webhook, "ID = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NotFound("webhook not found")
+		}
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+func (r *GormWebhookRepository) CreateWebhook(webhook entities.Webhook) (entities.Webhook, error) {
+	if err := r.Db.Create(&webhook).Error; err != nil {
+		return entities.Webhook{}, err
+	}
+	return webhook, nil
+}
+
+func (r *GormWebhookRepository) UpdateWebhook(id uint, updatedWebhook entities.Webhook) (entities.Webhook, error) {
+	webhook, err := r.WebhookByID(id)
+	if err != nil {
+		return entities.Webhook{}, err
+	}
+
+	fields := []string{"URL", "EventTypes", "Active"}
+	if updatedWebhook.Secret != "" {
+		fields = append(fields, "Secret")
+	}
+	if err := r.Db.Model(webhook).Select(fields).Updates(updatedWebhook).Error; err != nil {
+		return entities.Webhook{}, err
+	}
+
+	updated, err := r.WebhookByID(id)
+	if err != nil {
+		return entities.Webhook{}, err
+	}
+	return *updated, nil
+}
+
+func (r *GormWebhookRepository) DeleteWebhook(id uint) (entities.Webhook, error) {
+	webhook, err := r.WebhookByID(id)
+	if err != nil {
+		return entities.Webhook{}, err
+	}
+
+	if err := r.Db.Delete(webhook).Error; err != nil {
+		return entities.Webhook{}, err
+	}
+
+	return *webhook, nil
+}
+
+func (r *GormWebhookRepository) CreateDelivery(delivery entities.WebhookDelivery) (entities.WebhookDelivery, error) {
+	if err := r.Db.Create(&delivery).Error; err != nil {
+		return entities.WebhookDelivery{}, err
+	}
+	return delivery, nil
+}
+
+func (r *GormWebhookRepository) DeliveryByID(id uint) (*entities.WebhookDelivery, error) {
+	var delivery entities.WebhookDelivery
+
+	if err := r.Db.First(&delivery, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NotFound("delivery not found")
+		}
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+func (r *GormWebhookRepository) DeliveriesForWebhook(webhookID uint, query entities.WebhookDeliveryQuery) ([]entities.WebhookDelivery, int64, error) {
+	var deliveries []entities.WebhookDelivery
+	var total int64
+
+	db := r.Db.Model(&entities.WebhookDelivery{}).Where("webhook_id = ?", webhookID)
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := db.Order("id DESC").
+		Offset(query.Offset()).
+		Limit(query.Limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return deliveries, total, nil
+}
+
+func (r *GormWebhookRepository) DueDeliveries(now time.Time, limit int) ([]entities.WebhookDelivery, error) {
+	var deliveries []entities.WebhookDelivery
+
+	err := r.Db.Where("status = ? AND next_attempt_at <= ?", entities.DeliveryPending, now).
+		Order("next_attempt_at, id").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (r *GormWebhookRepository) ClaimDelivery(id uint, now time.Time, until time.Time) (bool, error) {
+	result := r.Db.Model(&entities.WebhookDelivery{}).
+		Where("id = ? AND status = ? AND next_attempt_at <= ?", id, entities.DeliveryPending, now).
+		Update("next_attempt_at", until)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *GormWebhookRepository) SaveAttempt(delivery entities.WebhookDelivery) error {
+	return r.Db.Model(&entities.WebhookDelivery{ID: delivery.ID}).
+		Select("Status", "Attempts", "ResponseStatus", "LastError", "NextAttemptAt", "DeliveredAt").
+		Updates(delivery).Error
+}
@@ -0,0 +1,264 @@
+package eventbus
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/events"
+	"Trip-Trove-API/domain/repositories"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/jackc/pgx/v5"
+	"gorm.io/gorm"
+	"log"
+	"sync"
+	"time"
+)
+
+// DefaultChannel is the NOTIFY channel every API instance listens on.
+const DefaultChannel = "trip_trove_events"
+
+// maxNotifyPayload keeps notifications below Postgres' 8000 byte limit. Larger events
+// are sent as their sequence number and read back from the event log.
+const maxNotifyPayload = 7900
+
+// catchUpLimit bounds how many missed events are delivered after a reconnect.
+const catchUpLimit = 10000
+
+const (
+	minReconnectDelay = 500 * time.Millisecond
+	maxReconnectDelay = 30 * time.Second
+)
+
+// notification is the NOTIFY payload. Record is left out when the event is too large,
+// in which case listeners load it from the event log by Sequence.
+type notification struct {
+	Sequence uint64                `json:"seq,omitempty"`
+	Record   *entities.EventRecord `json:"record,omitempty"`
+}
+
+// PostgresBus fans events out to every API instance connected to the same database.
+// Publish sends a NOTIFY and each instance, this one included, delivers the event to its
+// local subscribers when the notification comes back on its LISTEN connection.
+type PostgresBus struct {
+	Db      *gorm.DB
+	DSN     string
+	Channel string
+	Log     repositories.EventLogRepository
+
+	local  *events.LocalBus
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	// lastSequence and catchUp are only touched by the listener goroutine.
+	lastSequence uint64
+	catchUp      map[uint64]struct{}
+
+	mu      sync.Mutex
+	started bool
+}
+
+func NewPostgresBus(db *gorm.DB, dsn string, eventLog repositories.EventLogRepository) *PostgresBus {
+	return &PostgresBus{Db: db, DSN: dsn, Channel: DefaultChannel, Log: eventLog, local: events.NewLocalBus()}
+}
+
+func (b *PostgresBus) Subscribe(subscriber events.Publisher) {
+	b.local.Subscribe(subscriber)
+}
+
+// Start opens the LISTEN connection and returns once it is listening, so events published
+// afterwards are not missed. Notifications are then handled until Close.
+func (b *PostgresBus) Start(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.started {
+		return errors.New("event bus already started")
+	}
+
+	conn, err := b.listen(ctx)
+	if err != nil {
+		return err
+	}
+
+	ctx, b.cancel = context.WithCancel(ctx)
+	b.done = make(chan struct{})
+	b.started = true
+
+	go b.run(ctx, conn)
+	return nil
+}
+
+// Close stops listening. Events published afterwards are only delivered locally.
+func (b *PostgresBus) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.started {
+		return nil
+	}
+
+	b.cancel()
+	<-b.done
+	b.started = false
+	return nil
+}
+
+// Publish notifies every instance. If the notification cannot be sent the event still
+// reaches this instance's subscribers; other instances pick it up from the event log
+// the next time they reconnect.
+func (b *PostgresBus) Publish(event events.Event) {
+	payload, err := b.encode(event)
+	if err == nil {
+		err = b.Db.Exec("SELECT pg_notify(?, ?)", b.Channel, payload).Error
+	}
+	if err != nil {
+		log.Printf("Error notifying other instances: %v", err)
+		b.local.Publish(event)
+	}
+}
+
+func (b *PostgresBus) encode(event events.Event) (string, error) {
+	record, err := event.Record()
+	if err != nil {
+		return "", err
+	}
+
+	encoded, err := json.Marshal(notification{Sequence: record.Sequence, Record: &record})
+	if err != nil {
+		return "", err
+	}
+	if len(encoded) <= maxNotifyPayload {
+		return string(encoded), nil
+	}
+	if event.Sequence == 0 {
+		return "", errors.New("event is too large to notify and is not in the event log")
+	}
+
+	encoded, err = json.Marshal(notification{Sequence: event.Sequence})
+	return string(encoded), err
+}
+
+func (b *PostgresBus) listen(ctx context.Context) (*pgx.Conn, error) {
+	conn, err := pgx.Connect(ctx, b.DSN)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{b.Channel}.Sanitize()); err != nil {
+		conn.Close(context.Background())
+		return nil, err
+	}
+	return conn, nil
+}
+
+// run handles notifications and reconnects with a growing delay when the connection
+// drops. After a reconnect the events logged in the meantime are delivered from the log.
+func (b *PostgresBus) run(ctx context.Context, conn *pgx.Conn) {
+	defer close(b.done)
+
+	delay := minReconnectDelay
+	for {
+		err := b.receive(ctx, conn)
+		conn.Close(context.Background())
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("Event bus connection lost: %v", err)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+
+			conn, err = b.listen(ctx)
+			if err == nil {
+				break
+			}
+			log.Printf("Event bus reconnect failed: %v", err)
+			delay = min(delay*2, maxReconnectDelay)
+		}
+
+		delay = minReconnectDelay
+		b.catchUpFromLog()
+	}
+}
+
+func (b *PostgresBus) receive(ctx context.Context, conn *pgx.Conn) error {
+	for {
+		received, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		b.deliver(received.Payload)
+	}
+}
+
+func (b *PostgresBus) deliver(payload string) {
+	var message notification
+	if err := json.Unmarshal([]byte(payload), &message); err != nil {
+		log.Printf("Error decoding event notification: %v", err)
+		return
+	}
+
+	if _, ok := b.catchUp[message.Sequence]; ok {
+		delete(b.catchUp, message.Sequence)
+		return
+	}
+
+	record := message.Record
+	if record == nil {
+		logged, err := b.loggedEvent(message.Sequence)
+		if err != nil {
+			log.Printf("Error loading event %d from the event log: %v", message.Sequence, err)
+			return
+		}
+		record = logged
+	}
+
+	event, err := events.FromRecord(*record)
+	if err != nil {
+		log.Printf("Error decoding event notification: %v", err)
+		return
+	}
+
+	if event.Sequence > b.lastSequence {
+		b.lastSequence = event.Sequence
+	}
+	b.local.Publish(event)
+}
+
+func (b *PostgresBus) loggedEvent(sequence uint64) (*entities.EventRecord, error) {
+	records, err := b.Log.EventsSince(sequence-1, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 || records[0].Sequence != sequence {
+		return nil, errors.New("event is no longer in the log")
+	}
+	return &records[0], nil
+}
+
+// catchUpFromLog delivers the events logged while the bus was disconnected. Their
+// notifications may still arrive, so they are remembered and skipped once.
+func (b *PostgresBus) catchUpFromLog() {
+	if b.lastSequence == 0 {
+		return
+	}
+
+	records, err := b.Log.EventsSince(b.lastSequence, catchUpLimit)
+	if err != nil {
+		log.Printf("Error reading missed events from the event log: %v", err)
+		return
+	}
+
+	b.catchUp = make(map[uint64]struct{}, len(records))
+	for _, record := range records {
+		event, err := events.FromRecord(record)
+		if err != nil {
+			log.Printf("Error decoding logged event %d: %v", record.Sequence, err)
+			continue
+		}
+		b.catchUp[record.Sequence] = struct{}{}
+		b.lastSequence = record.Sequence
+		b.local.Publish(event)
+	}
+}
@@ -0,0 +1,50 @@
+package mail
+
+import (
+	"Trip-Trove-API/domain/mail"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LocalMailer never delivers anything. With a Dir it writes every message there as an .eml
+// file that mail clients can open; without one it prints messages to the log. It is meant for
+// local development, where the links in the messages are all that is needed.
+type LocalMailer struct {
+	Dir  string
+	From string
+
+	mu   sync.Mutex
+	sent int
+}
+
+func (m *LocalMailer) Send(message mail.Message) error {
+	now := time.Now()
+	if m.Dir == "" {
+		log.Printf("Mail to %s: %s\n%s", message.To, message.Subject, message.Text)
+		return nil
+	}
+
+	m.mu.Lock()
+	m.sent++
+	name := fmt.Sprintf("%s-%03d-%s.eml", now.Format("20060102T150405"), m.sent, fileSafe(message.To))
+	m.mu.Unlock()
+
+	if err := os.MkdirAll(m.Dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(m.Dir, name), compose(m.From, message, now), 0o600)
+}
+
+func fileSafe(address string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == os.PathSeparator {
+			return '_'
+		}
+		return r
+	}, address)
+}
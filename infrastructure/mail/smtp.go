@@ -0,0 +1,55 @@
+package mail
+
+import (
+	"Trip-Trove-API/domain/mail"
+	"bytes"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// SMTPMailer sends through an SMTP server. net/smtp upgrades to STARTTLS when the server
+// offers it; credentials are only sent over TLS or to localhost.
+type SMTPMailer struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+func (m *SMTPMailer) Send(message mail.Message) error {
+	var auth smtp.Auth
+	if m.Username != "" {
+		auth = smtp.PlainAuth("", m.Username, m.Password, m.Host)
+	}
+
+	address := net.JoinHostPort(m.Host, m.Port)
+	if err := smtp.SendMail(address, auth, m.From, []string{message.To}, compose(m.From, message, time.Now())); err != nil {
+		return fmt.Errorf("sending mail to %s: %w", message.To, err)
+	}
+	return nil
+}
+
+// compose builds the RFC 5322 message, with CRLF line endings as SMTP expects. Line breaks
+// are dropped from header values so an address cannot add headers of its own.
+func compose(from string, message mail.Message, date time.Time) []byte {
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "From: %s\r\n", headerValue(from))
+	fmt.Fprintf(&buffer, "To: %s\r\n", headerValue(message.To))
+	fmt.Fprintf(&buffer, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", headerValue(message.Subject)))
+	fmt.Fprintf(&buffer, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	buffer.WriteString("MIME-Version: 1.0\r\n")
+	buffer.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buffer.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	buffer.WriteString("\r\n")
+	buffer.WriteString(strings.ReplaceAll(strings.ReplaceAll(message.Text, "\r\n", "\n"), "\n", "\r\n"))
+	return buffer.Bytes()
+}
+
+func headerValue(value string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+}
@@ -1,70 +1,180 @@
 package middlewares
 
 import (
+	"Trip-Trove-API/domain/apperrors"
 	"Trip-Trove-API/domain/entities"
+	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt"
+	"github.com/gorilla/websocket"
 	"log"
 	"net/http"
 	"os"
 	"strings"
 )
 
-type AuthMiddleware struct{}
+type TokenRevocationChecker interface {
+	IsAccessTokenRevoked(tokenID string) (bool, error)
+}
+
+type AuthMiddleware struct {
+	Revocations TokenRevocationChecker
+}
+
+var (
+	errMissingToken   = apperrors.Unauthorized("no Authorization header provided")
+	errMalformedToken = apperrors.Unauthorized("incorrect format of Authorization token")
+	errInvalidToken   = apperrors.Unauthorized("invalid token")
+	errInvalidRole    = apperrors.Unauthorized("invalid role type in token")
+	errRevokedToken   = apperrors.Unauthorized("token has been revoked")
+	errAccessDenied   = apperrors.Forbidden("access denied")
+)
+
+// RequireAuth lets any authenticated user through.
+func (rm AuthMiddleware) RequireAuth() gin.HandlerFunc {
+	return rm.require(func(entities.AccessType) bool { return true })
+}
 
-func (rm AuthMiddleware) RequireRole(requiredRole entities.AccessType) gin.HandlerFunc {
+// RequirePermission lets through users whose role the active policy grants permission. Tokens
+// only carry the role, so tokens issued before permissions existed keep working.
+func (rm AuthMiddleware) RequirePermission(permission entities.Permission) gin.HandlerFunc {
+	return rm.require(func(role entities.AccessType) bool {
+		return entities.ActivePolicy().Allows(role, permission)
+	})
+}
+
+func (rm AuthMiddleware) require(allowed func(entities.AccessType) bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		clientToken := c.Request.Header.Get("Authorization")
-		if clientToken == "" {
-			c.JSON(http.StatusForbidden, gin.H{"error": "No Authorization header provided"})
-			c.Abort()
+		claims, err := rm.authenticate(c)
+		if err != nil {
+			writeProblem(c, err)
 			return
 		}
 
-		extractedToken := strings.Split(clientToken, "Bearer ")
-		if len(extractedToken) != 2 {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Incorrect Format of Authorization Token"})
-			c.Abort()
+		role, ok := roleFromClaims(claims)
+		if !ok {
+			writeProblem(c, errInvalidRole)
 			return
 		}
-		clientToken = strings.TrimSpace(extractedToken[1])
-
-		token, _ := jwt.Parse(clientToken, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-			}
-
-			secretKey := os.Getenv("JWT_SECRET")
-			if secretKey == "" {
-				log.Fatal("JWT_SECRET is not set in .env file")
-			}
-
-			return []byte(secretKey), nil
-		})
-
-		if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-			roleFloat, ok := claims["role"].(float64)
-			if !ok {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid role type in token"})
-				c.Abort()
-				return
-			}
-
-			role := entities.AccessType(roleFloat)
-
-			if role < requiredRole {
-				c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
-				c.Abort()
-				return
-			}
-			c.Set("userID", claims["userID"])
-			c.Set("role", role)
 
+		if !allowed(role) {
+			writeProblem(c, errAccessDenied)
+			return
+		}
+		setClaims(c, claims, role)
+
+		c.Next()
+	}
+}
+
+// OptionalAuth identifies the caller when a token is sent but lets anonymous requests through.
+func (rm AuthMiddleware) OptionalAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := rm.authenticate(c)
+		if errors.Is(err, errMissingToken) {
 			c.Next()
-		} else {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
-			c.Abort()
+			return
+		}
+		if err != nil {
+			writeProblem(c, err)
+			return
+		}
+
+		role, ok := roleFromClaims(claims)
+		if !ok {
+			writeProblem(c, errInvalidRole)
+			return
+		}
+
+		setClaims(c, claims, role)
+
+		c.Next()
+	}
+}
+
+func setClaims(c *gin.Context, claims jwt.MapClaims, role entities.AccessType) {
+	c.Set("userID", claims["userID"])
+	c.Set("role", role)
+	c.Set("tokenID", claims["jti"])
+	c.Set("tokenExpiresAt", claims["exp"])
+	c.Set("emailVerified", emailVerifiedFromClaims(claims))
+}
+
+// emailVerifiedFromClaims treats tokens issued before the email_verified claim existed as
+// verified, like the migration that introduced verification treats their users.
+func emailVerifiedFromClaims(claims jwt.MapClaims) bool {
+	verified, present := claims["email_verified"]
+	return !present || verified == true
+}
+
+// tokenFromRequest reads the bearer token from the Authorization header. Browsers cannot set
+// headers on WebSocket handshakes or EventSource requests, so those may pass it as
+// ?access_token= instead. The query token is then redacted from the request, so handlers and
+// panic dumps never see it.
+func tokenFromRequest(r *http.Request) (string, error) {
+	clientToken := r.Header.Get("Authorization")
+	if clientToken == "" {
+		if queryToken := r.URL.Query().Get("access_token"); queryToken != "" && acceptsQueryToken(r) {
+			r.URL.RawQuery = redactAccessToken(r.URL.RawQuery)
+			r.RequestURI = r.URL.RequestURI()
+			return queryToken, nil
 		}
+		return "", errMissingToken
+	}
+
+	extractedToken := strings.Split(clientToken, "Bearer ")
+	if len(extractedToken) != 2 {
+		return "", errMalformedToken
+	}
+	return strings.TrimSpace(extractedToken[1]), nil
+}
+
+func acceptsQueryToken(r *http.Request) bool {
+	return websocket.IsWebSocketUpgrade(r) || strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+}
+
+func (rm AuthMiddleware) authenticate(c *gin.Context) (jwt.MapClaims, error) {
+	clientToken, err := tokenFromRequest(c.Request)
+	if err != nil {
+		return nil, err
+	}
+
+	token, _ := jwt.Parse(clientToken, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+
+		secretKey := os.Getenv("JWT_SECRET")
+		if secretKey == "" {
+			log.Fatal("JWT_SECRET is not set in .env file")
+		}
+
+		return []byte(secretKey), nil
+	})
+
+	if token == nil || !token.Valid {
+		return nil, errInvalidToken
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errInvalidToken
+	}
+
+	if tokenID, _ := claims["jti"].(string); tokenID != "" && rm.Revocations != nil {
+		revoked, err := rm.Revocations.IsAccessTokenRevoked(tokenID)
+		if err != nil || revoked {
+			return nil, errRevokedToken
+		}
+	}
+
+	return claims, nil
+}
+
+func roleFromClaims(claims jwt.MapClaims) (entities.AccessType, bool) {
+	roleFloat, ok := claims["role"].(float64)
+	if !ok {
+		return 0, false
 	}
+	return entities.AccessType(roleFloat), true
 }
@@ -0,0 +1,70 @@
+package middlewares
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"log"
+	"net/http"
+)
+
+const problemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details body.
+type Problem struct {
+	Type     string                 `json:"type"`
+	Title    string                 `json:"title"`
+	Status   int                    `json:"status"`
+	Detail   string                 `json:"detail,omitempty"`
+	Instance string                 `json:"instance,omitempty"`
+	Errors   []apperrors.FieldError `json:"errors,omitempty"`
+}
+
+var statusByKind = map[apperrors.Kind]int{
+	apperrors.KindNotFound:     http.StatusNotFound,
+	apperrors.KindConflict:     http.StatusConflict,
+	apperrors.KindValidation:   http.StatusBadRequest,
+	apperrors.KindForbidden:    http.StatusForbidden,
+	apperrors.KindUnauthorized: http.StatusUnauthorized,
+}
+
+// ErrorMiddleware renders the last error a handler attached with c.Error as problem+json.
+// Handlers that already wrote a response are left alone.
+func ErrorMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		writeProblem(c, c.Errors.Last().Err)
+	}
+}
+
+func writeProblem(c *gin.Context, err error) {
+	err = apperrors.FromValidation(err)
+
+	problem := Problem{
+		Type:     "about:blank",
+		Status:   http.StatusInternalServerError,
+		Detail:   "internal server error",
+		Instance: c.Request.URL.Path,
+	}
+	if status, ok := statusByKind[apperrors.KindOf(err)]; ok {
+		problem.Status = status
+		problem.Detail = err.Error()
+		problem.Errors = apperrors.FieldsOf(err)
+	} else {
+		log.Printf("%s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+	}
+	problem.Title = http.StatusText(problem.Status)
+
+	body, marshalErr := json.Marshal(problem)
+	if marshalErr != nil {
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	c.Abort()
+	c.Data(problem.Status, problemContentType, body)
+}
@@ -6,5 +6,7 @@ import (
 )
 
 type IAuthMiddleware interface {
-	RequireRole(role entities.AccessType) gin.HandlerFunc
+	RequireAuth() gin.HandlerFunc
+	RequirePermission(permission entities.Permission) gin.HandlerFunc
+	OptionalAuth() gin.HandlerFunc
 }
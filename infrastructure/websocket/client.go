@@ -0,0 +1,264 @@
+package websocket
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/events"
+	"encoding/json"
+	"errors"
+	"github.com/gorilla/websocket"
+	"log"
+	"sync"
+	"time"
+)
+
+// Client is an authenticated subscriber together with the topics it subscribed to. Every
+// message goes through its bounded queue. Socket clients are drained by a single writer
+// goroutine; stream clients, such as Server-Sent Events, have no conn and are drained by
+// their handler through Messages.
+type Client struct {
+	conn      *Connection
+	Actor     entities.Actor
+	ExpiresAt time.Time
+	topics    map[Topic]bool
+	// lastSequence is the last event sequence covered by a replay.
+	lastSequence uint64
+	// catchingUp is set while the replay is read; live events wait in heldBack meanwhile.
+	catchingUp bool
+	heldBack   []Outbound
+
+	config    Config
+	metrics   *metrics
+	send      chan Outbound
+	done      chan struct{}
+	closeOnce sync.Once
+	closeCode int
+	closeText string
+}
+
+func newClient(conn *Connection, actor entities.Actor, expiresAt time.Time, config Config, metrics *metrics) *Client {
+	return &Client{
+		conn:      conn,
+		Actor:     actor,
+		ExpiresAt: expiresAt,
+		topics:    make(map[Topic]bool),
+		config:    config,
+		metrics:   metrics,
+		done:      make(chan struct{}),
+	}
+}
+
+// start makes room for extra messages beyond the configured queue and starts the writer.
+// Nothing is queued before it runs.
+func (c *Client) start(extra int) {
+	c.config.QueueSize += extra
+	c.send = make(chan Outbound, c.config.QueueSize)
+	if c.conn != nil {
+		c.prepareReads()
+		go c.writePump()
+	}
+}
+
+// Outbound is a queued message. Name and Sequence label it for transports that carry them,
+// like the event and id fields of Server-Sent Events.
+type Outbound struct {
+	Name     string
+	Sequence uint64
+	Payload  []byte
+}
+
+func newOutbound(v interface{}) (Outbound, error) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return Outbound{}, err
+	}
+
+	switch message := v.(type) {
+	case events.Envelope:
+		return Outbound{Name: string(message.Type), Sequence: message.Sequence, Payload: payload}, nil
+	case Reply:
+		return Outbound{Name: message.Action, Payload: payload}, nil
+	default:
+		return Outbound{Name: "message", Payload: payload}, nil
+	}
+}
+
+// Send queues v without blocking. It returns false when the client is closed or its queue
+// is full, in which case the client is disconnected as a slow consumer.
+func (c *Client) Send(v interface{}) bool {
+	message, err := newOutbound(v)
+	if err != nil {
+		log.Printf("Error: %v", err)
+		return false
+	}
+	return c.enqueue(message)
+}
+
+func (c *Client) enqueue(message Outbound) bool {
+	select {
+	case <-c.done:
+		return false
+	default:
+	}
+
+	select {
+	case c.send <- message:
+		return true
+	default:
+		c.metrics.droppedMessages.Add(1)
+		c.metrics.slowConsumerDisconnects.Add(1)
+		c.closeWith(websocket.ClosePolicyViolation, "slow consumer")
+		return false
+	}
+}
+
+// holdBack keeps a live event until the replay is queued. Like enqueue, it disconnects the
+// client once more than a queue's worth of events is waiting.
+func (c *Client) holdBack(message Outbound) bool {
+	if len(c.heldBack) >= c.config.QueueSize {
+		c.metrics.droppedMessages.Add(1)
+		c.metrics.slowConsumerDisconnects.Add(1)
+		c.closeWith(websocket.ClosePolicyViolation, "slow consumer")
+		return false
+	}
+	c.heldBack = append(c.heldBack, message)
+	return true
+}
+
+func (c *Client) ReadJSON(v interface{}) error {
+	return c.conn.ReadJSON(v)
+}
+
+// Close asks the writer to say goodbye and close the connection. It is safe to call more than once.
+func (c *Client) Close() {
+	c.closeWith(websocket.CloseNormalClosure, "")
+}
+
+func (c *Client) closeWith(code int, text string) {
+	c.closeOnce.Do(func() {
+		c.closeCode = code
+		c.closeText = text
+		close(c.done)
+	})
+}
+
+// Done is closed once the client starts shutting down.
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// Messages is the queue a stream client drains. Call MarkDelivered after writing each one.
+func (c *Client) Messages() <-chan Outbound {
+	return c.send
+}
+
+func (c *Client) MarkDelivered() {
+	c.metrics.delivered.Add(1)
+}
+
+// WriteWait bounds a single write to the client.
+func (c *Client) WriteWait() time.Duration {
+	return c.config.WriteWait
+}
+
+// PingPeriod is how often an idle connection should be pinged to keep it open.
+func (c *Client) PingPeriod() time.Duration {
+	return c.config.PingPeriod
+}
+
+func (c *Client) expired(now time.Time) bool {
+	return !c.ExpiresAt.IsZero() && now.After(c.ExpiresAt)
+}
+
+// readDeadline is the next pong deadline, but never later than the token expiry.
+func (c *Client) readDeadline() time.Time {
+	deadline := time.Now().Add(c.config.PongWait)
+	if !c.ExpiresAt.IsZero() && c.ExpiresAt.Before(deadline) {
+		return c.ExpiresAt
+	}
+	return deadline
+}
+
+// prepareReads limits inbound messages and keeps the read deadline moving while pongs arrive.
+func (c *Client) prepareReads() {
+	c.conn.SetReadLimit(c.config.MaxMessageSize)
+	_ = c.conn.SetReadDeadline(c.readDeadline())
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(c.readDeadline())
+	})
+}
+
+// writePump owns every write to the connection: queued messages, pings and the final close frame.
+func (c *Client) writePump() {
+	ticker := time.NewTicker(c.config.PingPeriod)
+	defer func() {
+		ticker.Stop()
+		_ = c.conn.Close()
+	}()
+
+	for {
+		select {
+		case message := <-c.send:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(c.config.WriteWait))
+			if err := c.conn.WriteMessage(websocket.TextMessage, message.Payload); err != nil {
+				c.closeWith(websocket.CloseAbnormalClosure, "")
+				return
+			}
+			c.metrics.delivered.Add(1)
+
+		case <-ticker.C:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(c.config.WriteWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				c.closeWith(websocket.CloseAbnormalClosure, "")
+				return
+			}
+
+		case <-c.done:
+			if c.closeCode != websocket.CloseAbnormalClosure {
+				message := websocket.FormatCloseMessage(c.closeCode, c.closeText)
+				_ = c.conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(c.config.WriteWait))
+			}
+			return
+		}
+	}
+}
+
+// replay queues the events the client missed. Live events up to the last replayed sequence
+// are skipped afterwards so nothing is delivered twice.
+func (c *Client) replay(since uint64, missed []events.Event, err error) {
+	if errors.Is(err, events.ErrResyncRequired) {
+		c.Send(Reply{Action: ActionResync, Error: err.Error()})
+		return
+	}
+	if err != nil {
+		log.Printf("Error replaying events: %v", err)
+		c.Send(Reply{Action: ActionResync, Error: "could not replay missed events"})
+		return
+	}
+
+	c.lastSequence = since
+	for _, event := range missed {
+		c.lastSequence = event.Sequence
+		if c.wants(event) {
+			c.Send(event.Envelope())
+		}
+	}
+	c.Send(Reply{Action: ActionReplayed, Sequence: c.lastSequence})
+}
+
+func (c *Client) alreadyReplayed(sequence uint64) bool {
+	return sequence != 0 && sequence <= c.lastSequence
+}
+
+// wants reports whether the client subscribed to any topic the event belongs to and is
+// allowed to see it. Callers hold the manager mutex.
+func (c *Client) wants(event events.Event) bool {
+	if !event.VisibleTo(c.Actor) {
+		return false
+	}
+	for _, topic := range topicsFor(event) {
+		if c.topics[topic] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,26 @@
+package websocket
+
+import "time"
+
+type Config struct {
+	// QueueSize bounds the messages waiting for a client. A client whose queue is full is
+	// disconnected as a slow consumer instead of holding up everyone else.
+	QueueSize int
+	// BroadcastSize bounds the events waiting to be routed. Publish drops events once it is full.
+	BroadcastSize  int
+	WriteWait      time.Duration
+	PongWait       time.Duration
+	PingPeriod     time.Duration
+	MaxMessageSize int64
+}
+
+func DefaultConfig() Config {
+	return Config{
+		QueueSize:      256,
+		BroadcastSize:  1024,
+		WriteWait:      10 * time.Second,
+		PongWait:       60 * time.Second,
+		PingPeriod:     54 * time.Second,
+		MaxMessageSize: 64 * 1024,
+	}
+}
@@ -2,13 +2,31 @@ package websocket
 
 import "Trip-Trove-API/domain/entities"
 
-type EventUpdateNotification struct {
-	Action      string               `json:"action"`
-	Destination entities.Destination `json:"destination"`
-}
+const (
+	ActionSubscribe    = "subscribe"
+	ActionUnsubscribe  = "unsubscribe"
+	ActionSubscribed   = "subscribed"
+	ActionUnsubscribed = "unsubscribed"
+	ActionError        = "error"
+	ActionReplayed     = "replayed"
+	ActionResync       = "resync_required"
+
+	ActionCreateDestination = "CreateDestination"
+	ActionCreated           = "created"
+)
 
 type Message struct {
 	Action      string               `json:"action"`
 	Destination entities.Destination `json:"destination,omitempty"`
 	ID          string               `json:"id,omitempty"`
+	Topic       string               `json:"topic,omitempty"`
+}
+
+// Reply acknowledges a client message or reports why it was rejected.
+type Reply struct {
+	Action   string      `json:"action"`
+	Topic    string      `json:"topic,omitempty"`
+	Sequence uint64      `json:"seq,omitempty"`
+	Error    string      `json:"error,omitempty"`
+	Data     interface{} `json:"data,omitempty"`
 }
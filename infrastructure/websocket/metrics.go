@@ -0,0 +1,33 @@
+package websocket
+
+import "sync/atomic"
+
+type metrics struct {
+	connectedClients        atomic.Int64
+	published               atomic.Int64
+	delivered               atomic.Int64
+	droppedEvents           atomic.Int64
+	droppedMessages         atomic.Int64
+	slowConsumerDisconnects atomic.Int64
+}
+
+// Metrics is a point-in-time copy of the manager counters.
+type Metrics struct {
+	ConnectedClients        int64 `json:"connected_clients"`
+	Published               int64 `json:"published"`
+	Delivered               int64 `json:"delivered"`
+	DroppedEvents           int64 `json:"dropped_events"`
+	DroppedMessages         int64 `json:"dropped_messages"`
+	SlowConsumerDisconnects int64 `json:"slow_consumer_disconnects"`
+}
+
+func (m *metrics) snapshot() Metrics {
+	return Metrics{
+		ConnectedClients:        m.connectedClients.Load(),
+		Published:               m.published.Load(),
+		Delivered:               m.delivered.Load(),
+		DroppedEvents:           m.droppedEvents.Load(),
+		DroppedMessages:         m.droppedMessages.Load(),
+		SlowConsumerDisconnects: m.slowConsumerDisconnects.Load(),
+	}
+}
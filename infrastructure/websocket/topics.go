@@ -0,0 +1,77 @@
+package websocket
+
+import (
+	"Trip-Trove-API/domain/events"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Topic names a stream of events a client can subscribe to: "destinations" and "locations"
+// for every destination or location, "destination:<id>" for a single destination and
+// "location:<id>" for a location together with all of its destinations.
+type Topic string
+
+const (
+	TopicAllDestinations Topic = "destinations"
+	TopicAllLocations    Topic = "locations"
+)
+
+var ErrInvalidTopic = errors.New("invalid topic, expected destinations, locations, destination:<id> or location:<id>")
+
+func DestinationTopic(id uint) Topic {
+	return Topic("destination:" + strconv.FormatUint(uint64(id), 10))
+}
+
+func LocationTopic(id uint) Topic {
+	return Topic("location:" + strconv.FormatUint(uint64(id), 10))
+}
+
+func ParseTopic(raw string) (Topic, error) {
+	if Topic(raw) == TopicAllDestinations || Topic(raw) == TopicAllLocations {
+		return Topic(raw), nil
+	}
+
+	kind, idStr, found := strings.Cut(raw, ":")
+	if !found {
+		return "", ErrInvalidTopic
+	}
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil || id == 0 {
+		return "", ErrInvalidTopic
+	}
+
+	switch kind {
+	case "destination":
+		return DestinationTopic(uint(id)), nil
+	case "location":
+		return LocationTopic(uint(id)), nil
+	default:
+		return "", ErrInvalidTopic
+	}
+}
+
+// DestinationID returns the id of a destination:<id> topic.
+func (t Topic) DestinationID() (uint, bool) {
+	idStr, found := strings.CutPrefix(string(t), "destination:")
+	if !found {
+		return 0, false
+	}
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	return uint(id), err == nil
+}
+
+func topicsFor(event events.Event) []Topic {
+	switch {
+	case event.Destination != nil:
+		return []Topic{
+			TopicAllDestinations,
+			DestinationTopic(event.Destination.ID),
+			LocationTopic(event.Destination.LocationID),
+		}
+	case event.Location != nil:
+		return []Topic{TopicAllLocations, LocationTopic(event.Location.ID)}
+	default:
+		return nil
+	}
+}
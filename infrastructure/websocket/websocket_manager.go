@@ -1,50 +1,227 @@
 package websocket
 
 import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/events"
+	"github.com/gorilla/websocket"
 	"log"
 	"sync"
+	"time"
 )
 
 type WebSocketManager struct {
-	clients   map[*Connection]bool
-	broadcast chan EventUpdateNotification
-	mutex     sync.Mutex
+	clients   map[*Client]bool
+	broadcast chan events.Event
+	mutex     sync.RWMutex
+	config    Config
+	metrics   metrics
+	quit      chan struct{}
+	closeOnce sync.Once
 }
 
 func NewWebSocketManager() *WebSocketManager {
+	return NewWebSocketManagerWithConfig(DefaultConfig())
+}
+
+func NewWebSocketManagerWithConfig(config Config) *WebSocketManager {
 	return &WebSocketManager{
-		clients:   make(map[*Connection]bool),
-		broadcast: make(chan EventUpdateNotification, 1024),
+		clients:   make(map[*Client]bool),
+		broadcast: make(chan events.Event, config.BroadcastSize),
+		config:    config,
+		quit:      make(chan struct{}),
 	}
 }
 
-func (m *WebSocketManager) AddWebSocketClient(client *Connection) {
+// Subscription is what a client asks for when it connects.
+type Subscription struct {
+	Topics []Topic
+	// Since, when set, resumes the stream: the events logged after it are replayed from
+	// Replay before live delivery starts.
+	Since  *uint64
+	Replay events.Replayer
+}
+
+// AddWebSocketClient registers conn for actor and starts its writer. The caller keeps reading
+// from the returned client and calls RemoveWebSocketClient once reading fails.
+func (m *WebSocketManager) AddWebSocketClient(conn *Connection, actor entities.Actor, expiresAt time.Time, subscription Subscription) *Client {
+	return m.addClient(conn, actor, expiresAt, subscription)
+}
+
+// AddStreamClient registers a client without a socket. The caller drains Messages until
+// Done is closed and then calls RemoveWebSocketClient.
+func (m *WebSocketManager) AddStreamClient(actor entities.Actor, expiresAt time.Time, subscription Subscription) *Client {
+	return m.addClient(nil, actor, expiresAt, subscription)
+}
+
+func (m *WebSocketManager) addClient(conn *Connection, actor entities.Actor, expiresAt time.Time, subscription Subscription) *Client {
+	client := newClient(conn, actor, expiresAt, m.config, &m.metrics)
+	for _, topic := range subscription.Topics {
+		client.topics[topic] = true
+	}
+
+	// A resuming client is registered before the replay is read, so live events logged in
+	// the meantime are held back for it rather than missed. The read runs without the lock.
+	resuming := subscription.Since != nil && subscription.Replay != nil
+	var missed []events.Event
+	var replayErr error
+	if resuming {
+		m.mutex.Lock()
+		client.catchingUp = true
+		m.register(client)
+		m.mutex.Unlock()
+
+		missed, replayErr = subscription.Replay.EventsSince(*subscription.Since)
+	}
+
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
-	m.clients[client] = true
+
+	// The queue grows by the replay so that catching up never counts as being slow.
+	client.start(len(missed) + len(client.heldBack) + 1)
+
+	select {
+	case <-m.quit:
+		client.closeWith(websocket.CloseGoingAway, "server shutting down")
+		return client
+	case <-client.done:
+		// Dropped while catching up, for instance because its token expired.
+		return client
+	default:
+	}
+
+	if resuming {
+		client.replay(*subscription.Since, missed, replayErr)
+		client.catchingUp = false
+		for _, message := range client.heldBack {
+			if client.alreadyReplayed(message.Sequence) {
+				continue
+			}
+			if !client.enqueue(message) {
+				m.remove(client)
+				return client
+			}
+		}
+		client.heldBack = nil
+	}
+
+	m.register(client)
+	return client
+}
+
+// register adds client. Callers hold the write lock.
+func (m *WebSocketManager) register(client *Client) {
+	if !m.clients[client] {
+		m.clients[client] = true
+		m.metrics.connectedClients.Add(1)
+	}
 }
 
-func (m *WebSocketManager) RemoveWebSocketClient(client *Connection) {
+func (m *WebSocketManager) RemoveWebSocketClient(client *Client) {
+	client.Close()
+
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
-	delete(m.clients, client)
+	m.remove(client)
+}
+
+// remove forgets client. Callers hold the write lock.
+func (m *WebSocketManager) remove(client *Client) {
+	if m.clients[client] {
+		delete(m.clients, client)
+		m.metrics.connectedClients.Add(-1)
+	}
 }
 
-func (m *WebSocketManager) AddToBroadcast(notification EventUpdateNotification) {
-	m.broadcast <- notification
+func (m *WebSocketManager) Subscribe(client *Client, topic Topic) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	client.topics[topic] = true
 }
 
+func (m *WebSocketManager) Unsubscribe(client *Client, topic Topic) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	delete(client.topics, topic)
+}
+
+// Publish queues the event for BroadcastWebSocketMessage. It never blocks the caller: when
+// the broadcast queue is full, or the manager is closed, the event is dropped and counted.
+func (m *WebSocketManager) Publish(event events.Event) {
+	select {
+	case <-m.quit:
+		m.metrics.droppedEvents.Add(1)
+		return
+	default:
+	}
+
+	select {
+	case m.broadcast <- event:
+		m.metrics.published.Add(1)
+	default:
+		m.metrics.droppedEvents.Add(1)
+	}
+}
+
+// BroadcastWebSocketMessage delivers each event, wrapped in its envelope, to the clients
+// subscribed to one of its topics. Private destinations only reach sockets whose actor may
+// see them, and clients whose token expired are disconnected. It returns after Close.
 func (m *WebSocketManager) BroadcastWebSocketMessage() {
-	for notification := range m.broadcast {
-		m.mutex.Lock()
-		for client := range m.clients {
-			err := client.WriteJSON(notification)
-			if err != nil {
-				log.Printf("Error: %v", err)
-				_ = client.Close()
-				delete(m.clients, client)
+	for {
+		select {
+		case event := <-m.broadcast:
+			m.deliver(event)
+		case <-m.quit:
+			return
+		}
+	}
+}
+
+func (m *WebSocketManager) deliver(event events.Event) {
+	message, err := newOutbound(event.Envelope())
+	if err != nil {
+		log.Printf("Error: %v", err)
+		return
+	}
+
+	now := time.Now()
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for client := range m.clients {
+		if client.expired(now) {
+			client.closeWith(websocket.ClosePolicyViolation, "token expired")
+			m.remove(client)
+			continue
+		}
+		if !client.wants(event) || client.alreadyReplayed(event.Sequence) {
+			continue
+		}
+		if client.catchingUp {
+			if !client.holdBack(message) {
+				m.remove(client)
 			}
+			continue
+		}
+		if !client.enqueue(message) {
+			m.remove(client)
 		}
-		m.mutex.Unlock()
 	}
 }
+
+// Close stops routing events and disconnects every client with a going-away close frame.
+func (m *WebSocketManager) Close() {
+	m.closeOnce.Do(func() {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+
+		close(m.quit)
+		for client := range m.clients {
+			client.closeWith(websocket.CloseGoingAway, "server shutting down")
+			m.remove(client)
+		}
+	})
+}
+
+func (m *WebSocketManager) Metrics() Metrics {
+	return m.metrics.snapshot()
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"Trip-Trove-API/domain/mail"
+	mailer "Trip-Trove-API/infrastructure/mail"
+	"log"
+	"os"
+)
+
+// openMailer picks how account emails go out through MAILER: log (the default) prints them,
+// file writes them to MAIL_DIR, and smtp sends them through SMTP_HOST.
+func openMailer() mail.Mailer {
+	from := envOrDefault("MAIL_FROM", "Trip Trove <no-reply@triptrove.local>")
+
+	switch os.Getenv("MAILER") {
+	case "", "log":
+		return &mailer.LocalMailer{From: from}
+	case "file":
+		return &mailer.LocalMailer{Dir: envOrDefault("MAIL_DIR", "mail"), From: from}
+	case "smtp":
+		if os.Getenv("SMTP_HOST") == "" {
+			log.Fatal("MAILER=smtp needs SMTP_HOST")
+		}
+		return &mailer.SMTPMailer{
+			Host:     os.Getenv("SMTP_HOST"),
+			Port:     envOrDefault("SMTP_PORT", "587"),
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     from,
+		}
+	default:
+		log.Fatalf("Unknown MAILER %q, expected log, file or smtp", os.Getenv("MAILER"))
+		return nil
+	}
+}
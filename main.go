@@ -1,70 +1,176 @@
 package main
 
 import (
+	"Trip-Trove-API/commands"
 	"Trip-Trove-API/database"
-	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/events"
 	"Trip-Trove-API/domain/services"
-	"Trip-Trove-API/infrastructure/dataaccess"
 	"Trip-Trove-API/infrastructure/middlewares"
 	"Trip-Trove-API/infrastructure/websocket"
 	"Trip-Trove-API/presentation/handlers"
 	"Trip-Trove-API/routes"
+	"Trip-Trove-API/utils"
+	"context"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
 func main() {
-	router := gin.Default()
-	router.Use(middlewares.CORSMiddleware())
-
 	if err := godotenv.Load(); err != nil {
 		log.Fatal("Error loading .env file")
 	}
+	loadPolicy()
 
-	db := database.ConnectDB()
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if database.Driver() != database.DriverPostgres {
+			log.Fatal("Migrations only run against postgres; sqlite and memory create their schema on startup")
+		}
+		if err := commands.Migrate(database.ConnectDB(), os.Args[2:], os.Stdout); err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		return
+	}
 
-	entitiesToMigrate := []interface{}{
-		&entities.Destination{},
-		&entities.Location{},
-		&entities.User{},
+	if len(os.Args) > 1 && os.Args[1] == "seed" {
+		if database.Driver() == database.DriverMemory {
+			log.Fatal("Seeding needs a persistent database; the memory driver starts empty every time")
+		}
+		db := database.ConnectDB()
+		prepareSchema(db)
+		if err := commands.Seed(db, os.Args[2:], os.Stdout); err != nil {
+			log.Fatalf("Seeding failed: %v", err)
+		}
+		return
 	}
 
-	for _, entity := range entitiesToMigrate {
-		err := db.AutoMigrate(entity)
-		if err != nil {
-			log.Fatalf("Failed to migrate database: %v", err)
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		if database.Driver() == database.DriverMemory {
+			log.Fatal("Importing needs a persistent database; the memory driver starts empty every time")
+		}
+		db := database.ConnectDB()
+		prepareSchema(db)
+		if err := commands.Import(db, os.Args[2:], os.Stdin, os.Stdout); err != nil {
+			log.Fatalf("Import failed: %v", err)
 		}
+		return
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == "create-admin" {
+		if database.Driver() == database.DriverMemory {
+			log.Fatal("Creating an admin needs a persistent database; the memory driver starts empty every time")
+		}
+		db := database.ConnectDB()
+		prepareSchema(db)
+		if err := commands.CreateAdmin(db, os.Args[2:], os.Stdin, os.Stdout); err != nil {
+			log.Fatalf("Creating the admin failed: %v", err)
+		}
+		return
+	}
+
+	repos, unitOfWork, db := openStorage()
+
+	router := gin.New()
+	router.Use(middlewares.LoggerMiddleware(), gin.Recovery())
+	router.Use(middlewares.CORSMiddleware())
+	router.Use(middlewares.ErrorMiddleware())
+
 	websocketManager := websocket.NewWebSocketManager()
 
 	go websocketManager.BroadcastWebSocketMessage()
 
-	authMiddleware := middlewares.AuthMiddleware{}
+	authMiddleware := middlewares.AuthMiddleware{Revocations: repos.Tokens}
 
-	destinationRepository := dataaccess.NewGormDestinationRepository(db)
-	locationRepository := dataaccess.NewGormLocationRepository(db)
-	userRepository := dataaccess.NewGormUserRepository(db)
+	jwtWrapper := utils.JwtWrapper{
+		SecretKey:         os.Getenv("JWT_SECRET"),
+		Issuer:            "AuthService",
+		ExpirationMinutes: 60,
+		ExpirationHours:   24 * 30,
+	}
+
+	eventBus, closeEventBus := openEventBus(context.Background(), db, repos)
+	eventBus.Subscribe(websocketManager)
 
-	destinationService := services.DestinationService{Repo: destinationRepository, LocationRepo: locationRepository, WsManager: websocketManager}
-	locationService := services.LocationService{Repo: locationRepository, DestinationRepo: destinationRepository}
-	userService := services.UserService{Repo: userRepository}
+	// Webhooks are queued only by the instance that published the event, not through the bus.
+	webhookService := services.WebhookService{Repo: repos.Webhooks}
+	eventStream := services.EventStream{Log: repos.Events, Subscribers: []events.Publisher{eventBus, &webhookService}}
+
+	destinationService := services.DestinationService{Repo: repos.Destinations, LocationRepo: repos.Locations, UnitOfWork: unitOfWork, Events: &eventStream}
+	locationService := services.LocationService{Repo: repos.Locations, UnitOfWork: unitOfWork, Events: &eventStream}
+	userService := services.UserService{
+		Repo:        repos.Users,
+		TokenRepo:   repos.Tokens,
+		Jwt:         jwtWrapper,
+		UnitOfWork:  unitOfWork,
+		Mailer:      openMailer(),
+		LinkBaseURL: envOrDefault("APP_URL", "http://localhost:"+envOrDefault("PORT", "8080")),
+	}
+	tripService := services.TripService{Repo: repos.Trips, DestinationRepo: repos.Destinations, LocationRepo: repos.Locations}
 
 	destinationHandler := handlers.DestinationHandler{Service: &destinationService}
 	locationHandler := handlers.LocationHandler{Service: &locationService}
 	userHandler := handlers.UserHandler{Service: &userService}
+	tripHandler := handlers.TripHandler{Service: &tripService}
+	searchHandler := handlers.SearchHandler{DestinationService: &destinationService, LocationService: &locationService}
 
 	routes.RegisterDestinationRoutes(router, &destinationHandler, authMiddleware)
 	routes.RegisterLocationRoutes(router, &locationHandler, authMiddleware)
 	routes.RegisterUserRoutes(router, &userHandler, authMiddleware)
+	routes.RegisterTripRoutes(router, &tripHandler, authMiddleware)
+	routes.RegisterSearchRoutes(router, &searchHandler, authMiddleware)
 
-	wsController := handlers.WebSocketHandler{Service: &destinationService, WebSocketManager: websocketManager}
-	routes.RegisterWebSocketRoutes(router, &wsController)
+	wsController := handlers.WebSocketHandler{Service: &destinationService, WebSocketManager: websocketManager, Events: &eventStream}
+	routes.RegisterWebSocketRoutes(router, &wsController, authMiddleware)
 
-	err := router.Run()
-	if err != nil {
-		log.Fatalf("Failed to run server: %v", err)
-		return
+	eventsHandler := handlers.EventsHandler{Service: &destinationService, WebSocketManager: websocketManager, Events: &eventStream}
+	routes.RegisterEventRoutes(router, &eventsHandler, authMiddleware)
+
+	generatorService := services.GeneratorService{Destinations: &destinationService}
+	generatorHandler := handlers.GeneratorHandler{Service: &generatorService}
+	routes.RegisterGeneratorRoutes(router, &generatorHandler, authMiddleware)
+
+	webhookHandler := handlers.WebhookHandler{Service: &webhookService}
+	routes.RegisterWebhookRoutes(router, &webhookHandler, authMiddleware)
+
+	server := &http.Server{Addr: ":" + envOrDefault("PORT", "8080"), Handler: router}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go webhookService.Run(ctx)
+
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Failed to run server: %v", err)
+		}
+	}()
+
+	<-ctx.Done()
+	log.Println("Shutting down")
+
+	generatorService.Close()
+	eventStream.Close()
+	closeEventBus()
+
+	// Hijacked WebSocket connections are not tracked by Shutdown, the manager closes them.
+	websocketManager.Close()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Forced shutdown: %v", err)
+	}
+}
+
+func envOrDefault(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
 	}
+	return fallback
 }
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"log"
+	"os"
+)
+
+// loadPolicy replaces the default role permissions with the JSON file at ROLE_PERMISSIONS_FILE,
+// when it is set.
+func loadPolicy() {
+	path := os.Getenv("ROLE_PERMISSIONS_FILE")
+	if path == "" {
+		return
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		log.Fatalf("Failed to open the role permissions: %v", err)
+	}
+	defer file.Close()
+
+	policy, err := entities.LoadPolicy(file)
+	if err != nil {
+		log.Fatalf("Failed to load %s: %v", path, err)
+	}
+	entities.SetPolicy(policy)
+}
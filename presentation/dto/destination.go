@@ -0,0 +1,111 @@
+package dto
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/services"
+)
+
+// DestinationRequest is the body of a create or update. The owner is always taken from the
+// caller, never from the request.
+type DestinationRequest struct {
+	Name             string   `json:"name"`
+	LocationID       uint     `json:"location_id"`
+	ImageUrl         string   `json:"image_url"`
+	Description      string   `json:"description"`
+	VisitorsLastYear int      `json:"visitors_last_year"`
+	IsPrivate        bool     `json:"is_private"`
+	Latitude         *float64 `json:"latitude"`
+	Longitude        *float64 `json:"longitude"`
+}
+
+func (r DestinationRequest) ToDestination() entities.Destination {
+	return entities.Destination{
+		Name:             r.Name,
+		LocationID:       r.LocationID,
+		ImageUrl:         r.ImageUrl,
+		Description:      r.Description,
+		VisitorsLastYear: r.VisitorsLastYear,
+		IsPrivate:        r.IsPrivate,
+		Latitude:         r.Latitude,
+		Longitude:        r.Longitude,
+	}
+}
+
+type DestinationResponse struct {
+	Model
+	Name             string   `json:"name"`
+	LocationID       uint     `json:"location_id"`
+	ImageUrl         string   `json:"image_url"`
+	Description      string   `json:"description"`
+	VisitorsLastYear int      `json:"visitors_last_year"`
+	IsPrivate        bool     `json:"is_private"`
+	Latitude         *float64 `json:"latitude"`
+	Longitude        *float64 `json:"longitude"`
+	OwnerID          *uint    `json:"owner_id"`
+}
+
+func NewDestinationResponse(destination entities.Destination) DestinationResponse {
+	return DestinationResponse{
+		Model:            newModel(destination.Model),
+		Name:             destination.Name,
+		LocationID:       destination.LocationID,
+		ImageUrl:         destination.ImageUrl,
+		Description:      destination.Description,
+		VisitorsLastYear: destination.VisitorsLastYear,
+		IsPrivate:        destination.IsPrivate,
+		Latitude:         destination.Latitude,
+		Longitude:        destination.Longitude,
+		OwnerID:          destination.OwnerID,
+	}
+}
+
+func NewDestinationResponses(destinations []entities.Destination) []DestinationResponse {
+	return mapAll(destinations, NewDestinationResponse)
+}
+
+type DestinationWithDistanceResponse struct {
+	DestinationResponse
+	DistanceKm float64 `json:"distance_km"`
+}
+
+func NewDestinationsWithDistance(destinations []entities.DestinationWithDistance) []DestinationWithDistanceResponse {
+	return mapAll(destinations, func(destination entities.DestinationWithDistance) DestinationWithDistanceResponse {
+		return DestinationWithDistanceResponse{
+			DestinationResponse: NewDestinationResponse(destination.Destination),
+			DistanceKm:          destination.DistanceKm,
+		}
+	})
+}
+
+type DestinationsByLocationResponse struct {
+	Location     LocationResponse      `json:"location"`
+	Destinations []DestinationResponse `json:"destinations"`
+}
+
+func NewDestinationsByLocation(byLocation services.DestinationsByLocation) DestinationsByLocationResponse {
+	return DestinationsByLocationResponse{
+		Location:     NewLocationResponse(byLocation.Location),
+		Destinations: NewDestinationResponses(byLocation.Destinations),
+	}
+}
+
+type DestinationSearchResultResponse struct {
+	Destination DestinationResponse `json:"destination"`
+	Location    string              `json:"location"`
+	Country     string              `json:"country"`
+	Rank        float64             `json:"rank"`
+	// Snippet is HTML: an escaped excerpt of the description with the matches in <mark>.
+	Snippet string `json:"snippet"`
+}
+
+func NewDestinationSearchResults(results []entities.DestinationSearchResult) []DestinationSearchResultResponse {
+	return mapAll(results, func(result entities.DestinationSearchResult) DestinationSearchResultResponse {
+		return DestinationSearchResultResponse{
+			Destination: NewDestinationResponse(result.Destination),
+			Location:    result.Location,
+			Country:     result.Country,
+			Rank:        result.Rank,
+			Snippet:     result.Snippet,
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package dto
+
+import "Trip-Trove-API/domain/entities"
+
+type LocationRequest struct {
+	Name        string   `json:"name"`
+	Country     string   `json:"country"`
+	Description string   `json:"description"`
+	Latitude    *float64 `json:"latitude"`
+	Longitude   *float64 `json:"longitude"`
+}
+
+func (r LocationRequest) ToLocation() entities.Location {
+	return entities.Location{
+		Name:        r.Name,
+		Country:     r.Country,
+		Description: r.Description,
+		Latitude:    r.Latitude,
+		Longitude:   r.Longitude,
+	}
+}
+
+type LocationResponse struct {
+	Model
+	Name        string   `json:"name"`
+	Country     string   `json:"country"`
+	Description string   `json:"description"`
+	Latitude    *float64 `json:"latitude"`
+	Longitude   *float64 `json:"longitude"`
+}
+
+func NewLocationResponse(location entities.Location) LocationResponse {
+	return LocationResponse{
+		Model:       newModel(location.Model),
+		Name:        location.Name,
+		Country:     location.Country,
+		Description: location.Description,
+		Latitude:    location.Latitude,
+		Longitude:   location.Longitude,
+	}
+}
+
+func NewLocationResponses(locations []entities.Location) []LocationResponse {
+	return mapAll(locations, NewLocationResponse)
+}
+
+type LocationSearchResultResponse struct {
+	Location LocationResponse `json:"location"`
+	Rank     float64          `json:"rank"`
+	// Snippet is HTML: an escaped excerpt of the description with the matches in <mark>.
+	Snippet string `json:"snippet"`
+}
+
+func NewLocationSearchResults(results []entities.LocationSearchResult) []LocationSearchResultResponse {
+	return mapAll(results, func(result entities.LocationSearchResult) LocationSearchResultResponse {
+		return LocationSearchResultResponse{
+			Location: NewLocationResponse(result.Location),
+			Rank:     result.Rank,
+			Snippet:  result.Snippet,
+		}
+	})
+}
@@ -0,0 +1,29 @@
+// Package dto holds the request and response shapes of the HTTP API. Handlers bind requests
+// into them and map entities onto them, so the Gorm models can change without changing the
+// API and internal columns are never serialized.
+package dto
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+// Model is the public part of gorm.Model; soft-delete timestamps stay internal.
+type Model struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func newModel(model gorm.Model) Model {
+	return Model{ID: model.ID, CreatedAt: model.CreatedAt, UpdatedAt: model.UpdatedAt}
+}
+
+// mapAll maps every entity and never returns nil, so empty lists encode as [].
+func mapAll[E any, R any](items []E, mapOne func(E) R) []R {
+	mapped := make([]R, 0, len(items))
+	for _, item := range items {
+		mapped = append(mapped, mapOne(item))
+	}
+	return mapped
+}
@@ -0,0 +1,74 @@
+package dto
+
+import "Trip-Trove-API/domain/entities"
+
+// TripRequest is the body of a create or update. Stops are only read on create.
+type TripRequest struct {
+	Name      string            `json:"name"`
+	StartDate string            `json:"start_date"`
+	EndDate   string            `json:"end_date"`
+	Stops     []TripStopRequest `json:"stops"`
+}
+
+func (r TripRequest) ToTrip() entities.Trip {
+	trip := entities.Trip{Name: r.Name, StartDate: r.StartDate, EndDate: r.EndDate}
+	if r.Stops != nil {
+		trip.Stops = mapAll(r.Stops, TripStopRequest.ToTripStop)
+	}
+	return trip
+}
+
+type TripStopRequest struct {
+	DestinationID uint   `json:"destination_id"`
+	Day           int    `json:"day"`
+	Position      int    `json:"position"`
+	Notes         string `json:"notes"`
+}
+
+func (r TripStopRequest) ToTripStop() entities.TripStop {
+	return entities.TripStop{DestinationID: r.DestinationID, Day: r.Day, Position: r.Position, Notes: r.Notes}
+}
+
+type TripResponse struct {
+	Model
+	OwnerID   uint               `json:"owner_id"`
+	Name      string             `json:"name"`
+	StartDate string             `json:"start_date"`
+	EndDate   string             `json:"end_date"`
+	Stops     []TripStopResponse `json:"stops"`
+}
+
+func NewTripResponse(trip entities.Trip) TripResponse {
+	return TripResponse{
+		Model:     newModel(trip.Model),
+		OwnerID:   trip.OwnerID,
+		Name:      trip.Name,
+		StartDate: trip.StartDate,
+		EndDate:   trip.EndDate,
+		Stops:     mapAll(trip.Stops, NewTripStopResponse),
+	}
+}
+
+func NewTripResponses(trips []entities.Trip) []TripResponse {
+	return mapAll(trips, NewTripResponse)
+}
+
+type TripStopResponse struct {
+	Model
+	TripID        uint   `json:"trip_id"`
+	DestinationID uint   `json:"destination_id"`
+	Day           int    `json:"day"`
+	Position      int    `json:"position"`
+	Notes         string `json:"notes"`
+}
+
+func NewTripStopResponse(stop entities.TripStop) TripStopResponse {
+	return TripStopResponse{
+		Model:         newModel(stop.Model),
+		TripID:        stop.TripID,
+		DestinationID: stop.DestinationID,
+		Day:           stop.Day,
+		Position:      stop.Position,
+		Notes:         stop.Notes,
+	}
+}
@@ -0,0 +1,152 @@
+package dto
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"time"
+)
+
+type RegisterUserRequest struct {
+	Username    string `json:"username" binding:"required" validate:"required,usernameValidator"`
+	Password    string `json:"password" binding:"required" validate:"required"`
+	Email       string `json:"email" binding:"required" validate:"required,email"`
+	FirstName   string `json:"first_name" binding:"required" validate:"required,nameValidator"`
+	LastName    string `json:"last_name" binding:"required" validate:"required,nameValidator"`
+	PhoneNumber string `json:"phone_number" binding:"required" validate:"required,e164"`
+	DateOfBirth string `json:"date_of_birth" binding:"required" validate:"max=20"`
+	Address     string `json:"address" binding:"required" validate:"max=100"`
+}
+
+func (r RegisterUserRequest) ToUser() entities.User {
+	return entities.User{
+		Username:    r.Username,
+		Password:    r.Password,
+		Email:       r.Email,
+		FirstName:   r.FirstName,
+		LastName:    r.LastName,
+		PhoneNumber: r.PhoneNumber,
+		DateOfBirth: r.DateOfBirth,
+		Address:     r.Address,
+	}
+}
+
+// UpdateUserRequest is an admin's full replacement of a user's profile. Neither the role nor
+// the password can be changed through it.
+type UpdateUserRequest struct {
+	Username    string `json:"username" binding:"required" validate:"required,usernameValidator"`
+	Email       string `json:"email" binding:"required" validate:"required,email"`
+	FirstName   string `json:"first_name" binding:"required" validate:"required,nameValidator"`
+	LastName    string `json:"last_name" binding:"required" validate:"required,nameValidator"`
+	PhoneNumber string `json:"phone_number" binding:"required" validate:"required,e164"`
+	DateOfBirth string `json:"date_of_birth" binding:"required" validate:"max=20"`
+	Address     string `json:"address" binding:"required" validate:"max=100"`
+}
+
+func (r UpdateUserRequest) ToUser() entities.User {
+	return ProfileRequest(r).ToUser()
+}
+
+// ProfileRequest is a user's partial update of their own profile: omitted fields are kept.
+type ProfileRequest struct {
+	Username    string `json:"username" validate:"omitempty,usernameValidator"`
+	Email       string `json:"email" validate:"omitempty,email"`
+	FirstName   string `json:"first_name" validate:"omitempty,nameValidator"`
+	LastName    string `json:"last_name" validate:"omitempty,nameValidator"`
+	PhoneNumber string `json:"phone_number" validate:"omitempty,e164"`
+	DateOfBirth string `json:"date_of_birth" validate:"max=20"`
+	Address     string `json:"address" validate:"max=100"`
+}
+
+func (r ProfileRequest) ToUser() entities.User {
+	return entities.User{
+		Username:    r.Username,
+		Email:       r.Email,
+		FirstName:   r.FirstName,
+		LastName:    r.LastName,
+		PhoneNumber: r.PhoneNumber,
+		DateOfBirth: r.DateOfBirth,
+		Address:     r.Address,
+	}
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required" validate:"required"`
+	NewPassword     string `json:"new_password" binding:"required" validate:"required,passwordValidator"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required" validate:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required" validate:"required"`
+	NewPassword string `json:"new_password" binding:"required" validate:"required,passwordValidator"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required" validate:"required"`
+}
+
+type UserResponse struct {
+	Model
+	Username      string                `json:"username"`
+	Email         string                `json:"email"`
+	FirstName     string                `json:"first_name"`
+	LastName      string                `json:"last_name"`
+	PhoneNumber   string                `json:"phone_number"`
+	DateOfBirth   string                `json:"date_of_birth"`
+	Address       string                `json:"address"`
+	Role          string                `json:"role"`
+	Permissions   []entities.Permission `json:"permissions"`
+	EmailVerified bool                  `json:"email_verified"`
+}
+
+func NewUserResponse(user entities.User) UserResponse {
+	return UserResponse{
+		Model:         newModel(user.Model),
+		Username:      user.Username,
+		Email:         user.Email,
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+		PhoneNumber:   user.PhoneNumber,
+		DateOfBirth:   user.DateOfBirth,
+		Address:       user.Address,
+		Role:          user.Role.String(),
+		Permissions:   entities.ActivePolicy().Permissions(user.Role),
+		EmailVerified: user.EmailVerified(),
+	}
+}
+
+func NewUserResponses(users []entities.User) []UserResponse {
+	return mapAll(users, NewUserResponse)
+}
+
+type RoleRequest struct {
+	Role   string `json:"role" binding:"required" validate:"required,oneof=user manager admin"`
+	Reason string `json:"reason" validate:"max=500"`
+}
+
+type RoleChangeResponse struct {
+	ID          uint      `json:"id"`
+	UserID      uint      `json:"user_id"`
+	ChangedByID *uint     `json:"changed_by_id"`
+	OldRole     string    `json:"old_role"`
+	NewRole     string    `json:"new_role"`
+	Reason      string    `json:"reason"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func NewRoleChangeResponse(change entities.RoleChange) RoleChangeResponse {
+	return RoleChangeResponse{
+		ID:          change.ID,
+		UserID:      change.UserID,
+		ChangedByID: change.ChangedByID,
+		OldRole:     change.OldRole.String(),
+		NewRole:     change.NewRole.String(),
+		Reason:      change.Reason,
+		CreatedAt:   change.CreatedAt,
+	}
+}
+
+func NewRoleChangeResponses(changes []entities.RoleChange) []RoleChangeResponse {
+	return mapAll(changes, NewRoleChangeResponse)
+}
@@ -0,0 +1,71 @@
+package dto
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"time"
+)
+
+// WebhookResponse leaves out the signing secret, which is only ever sent by the client.
+type WebhookResponse struct {
+	Model
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Active     bool     `json:"active"`
+}
+
+func NewWebhookResponse(webhook entities.Webhook) WebhookResponse {
+	eventTypes := []string(webhook.EventTypes)
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+	return WebhookResponse{
+		Model:      newModel(webhook.Model),
+		URL:        webhook.URL,
+		EventTypes: eventTypes,
+		Active:     webhook.Active,
+	}
+}
+
+func NewWebhookResponses(webhooks []entities.Webhook) []WebhookResponse {
+	return mapAll(webhooks, NewWebhookResponse)
+}
+
+type WebhookDeliveryResponse struct {
+	ID             uint                           `json:"id"`
+	WebhookID      uint                           `json:"webhook_id"`
+	EventSequence  uint64                         `json:"event_sequence"`
+	EventType      string                         `json:"event_type"`
+	Payload        string                         `json:"payload"`
+	Status         entities.WebhookDeliveryStatus `json:"status"`
+	Attempts       int                            `json:"attempts"`
+	ResponseStatus int                            `json:"response_status"`
+	LastError      string                         `json:"last_error"`
+	NextAttemptAt  time.Time                      `json:"next_attempt_at"`
+	DeliveredAt    *time.Time                     `json:"delivered_at"`
+	ReplayOf       *uint                          `json:"replay_of"`
+	CreatedAt      time.Time                      `json:"created_at"`
+	UpdatedAt      time.Time                      `json:"updated_at"`
+}
+
+func NewWebhookDeliveryResponse(delivery entities.WebhookDelivery) WebhookDeliveryResponse {
+	return WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		WebhookID:      delivery.WebhookID,
+		EventSequence:  delivery.EventSequence,
+		EventType:      delivery.EventType,
+		Payload:        delivery.Payload,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		DeliveredAt:    delivery.DeliveredAt,
+		ReplayOf:       delivery.ReplayOf,
+		CreatedAt:      delivery.CreatedAt,
+		UpdatedAt:      delivery.UpdatedAt,
+	}
+}
+
+func NewWebhookDeliveryResponses(deliveries []entities.WebhookDelivery) []WebhookDeliveryResponse {
+	return mapAll(deliveries, NewWebhookDeliveryResponse)
+}
@@ -0,0 +1,19 @@
+package handlers
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"github.com/gin-gonic/gin"
+)
+
+func actorFromContext(c *gin.Context) entities.Actor {
+	role, ok := c.Get("role")
+	if !ok {
+		return entities.Anonymous
+	}
+	userIDInterface, _ := c.Get("userID")
+	userIDFloat, _ := userIDInterface.(float64)
+
+	accessType, _ := role.(entities.AccessType)
+	emailVerified := c.GetBool("emailVerified")
+	return entities.Actor{UserID: uint(userIDFloat), Role: accessType, Authenticated: true, EmailVerified: emailVerified}
+}
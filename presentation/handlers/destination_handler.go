@@ -1,15 +1,17 @@
 package handlers
 
 import (
+	"Trip-Trove-API/domain/apperrors"
 	"Trip-Trove-API/domain/entities"
 	"Trip-Trove-API/domain/services"
-	"Trip-Trove-API/utils"
+	"Trip-Trove-API/presentation/dto"
+	"bytes"
+	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
-	"github.com/jaswdr/faker"
+	"io"
 	"net/http"
 	"strconv"
-	"time"
 )
 
 type DestinationHandler struct {
@@ -17,166 +19,242 @@ type DestinationHandler struct {
 }
 
 func (handler *DestinationHandler) AllDestinations(c *gin.Context) {
-	destinations, err := handler.Service.AllDestinations()
+	query, err := parseDestinationQuery(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch destinations"})
+		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, destinations)
-}
 
-func (handler *DestinationHandler) DestinationByID(c *gin.Context) {
-	id := c.Param("id")
-	destination, err := handler.Service.DestinationByID(id)
+	page, err := handler.Service.AllDestinations(query, actorFromContext(c))
 	if err != nil {
-		if err.Error() == "invalid ID format" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID format"})
-		} else {
-			c.JSON(http.StatusNotFound, gin.H{"error": "destination not found"})
-		}
+		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, destination)
+	c.JSON(http.StatusOK, NewPaginatedResponse(c, dto.NewDestinationResponses(page.Destinations), page.Total, page.Page, page.Limit))
 }
 
-func (handler *DestinationHandler) DestinationsByLocationID(c *gin.Context) {
-	locationID := c.Param("locationId")
-	destinations, err := handler.Service.DestinationsByLocationID(locationID)
-	if err != nil {
-		if err.Error() == "invalid ID format" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		} else {
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+func parseDestinationQuery(c *gin.Context) (entities.DestinationQuery, error) {
+	var query entities.DestinationQuery
+	var err error
+
+	intParams := map[string]*int{"page": &query.Page, "limit": &query.Limit}
+	for name, target := range intParams {
+		if value := c.Query(name); value != "" {
+			if *target, err = strconv.Atoi(value); err != nil {
+				return query, invalidParameter(name)
+			}
 		}
-		return
 	}
-	c.JSON(http.StatusOK, destinations)
+
+	query.SortBy = entities.DestinationSortField(c.Query("sort"))
+	switch c.DefaultQuery("order", "asc") {
+	case "asc":
+	case "desc":
+		query.SortDesc = true
+	default:
+		return query, invalidParameter("order")
+	}
+
+	if value := c.Query("location_id"); value != "" {
+		locationID, err := strconv.ParseUint(value, 10, 0)
+		if err != nil {
+			return query, invalidParameter("location_id")
+		}
+		id := uint(locationID)
+		query.Filter.LocationID = &id
+	}
+	query.Filter.Country = c.Query("country")
+
+	visitorParams := map[string]**int{"min_visitors": &query.Filter.MinVisitors, "max_visitors": &query.Filter.MaxVisitors}
+	for name, target := range visitorParams {
+		if value := c.Query(name); value != "" {
+			visitors, err := strconv.Atoi(value)
+			if err != nil {
+				return query, invalidParameter(name)
+			}
+			*target = &visitors
+		}
+	}
+
+	return query, nil
 }
 
-func (handler *DestinationHandler) CreateDestination(c *gin.Context) {
-	role, _ := c.Get("role")
-	if role != entities.Manager && role != entities.Admin {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+func (handler *DestinationHandler) NearbyDestinations(c *gin.Context) {
+	latitude, err := strconv.ParseFloat(c.Query("lat"), 64)
+	if err != nil {
+		c.Error(invalidParameter("lat"))
+		return
+	}
+	longitude, err := strconv.ParseFloat(c.Query("lng"), 64)
+	if err != nil {
+		c.Error(invalidParameter("lng"))
 		return
 	}
 
-	var newDestination entities.Destination
+	radiusKm := 0.0
+	if value := c.Query("radius_km"); value != "" {
+		if radiusKm, err = strconv.ParseFloat(value, 64); err != nil {
+			c.Error(invalidParameter("radius_km"))
+			return
+		}
+	}
+	limit := 0
+	if value := c.Query("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil {
+			c.Error(invalidParameter("limit"))
+			return
+		}
+	}
 
-	if err := c.BindJSON(&newDestination); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	destinations, err := handler.Service.NearbyDestinations(latitude, longitude, radiusKm, limit, actorFromContext(c))
+	if err != nil {
+		c.Error(err)
 		return
 	}
+	c.JSON(http.StatusOK, dto.NewDestinationsWithDistance(destinations))
+}
 
-	validate := validator.New()
-
-	err := validate.RegisterValidation("name", utils.NameValidator)
+func (handler *DestinationHandler) DestinationByID(c *gin.Context) {
+	id := c.Param("id")
+	destination, err := handler.Service.DestinationByID(id, actorFromContext(c))
 	if err != nil {
+		c.Error(err)
 		return
 	}
-	err = validate.RegisterValidation("description", utils.DescriptionValidator)
+	c.JSON(http.StatusOK, dto.NewDestinationResponse(*destination))
+}
+
+func (handler *DestinationHandler) DestinationsByLocationID(c *gin.Context) {
+	locationID := c.Param("locationId")
+	destinations, err := handler.Service.DestinationsByLocationID(locationID, actorFromContext(c))
 	if err != nil {
+		c.Error(err)
 		return
 	}
+	c.JSON(http.StatusOK, dto.NewDestinationsByLocation(*destinations))
+}
 
-	if err := validate.Struct(newDestination); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+func (handler *DestinationHandler) CreateDestination(c *gin.Context) {
+	var request dto.DestinationRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+	newDestination := request.ToDestination()
+
+	if err := services.ValidateDestination(newDestination); err != nil {
+		c.Error(err)
 		return
 	}
 
-	destination, err := handler.Service.CreateDestination(newDestination)
+	destination, err := handler.Service.CreateDestination(newDestination, actorFromContext(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create destination"})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, destination)
+	c.JSON(http.StatusCreated, dto.NewDestinationResponse(destination))
 }
 
 func (handler *DestinationHandler) DeleteDestination(c *gin.Context) {
-	role, _ := c.Get("role")
-	if role != entities.Manager && role != entities.Admin {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
-		return
-	}
-
 	id := c.Param("id")
 
-	destination, err := handler.Service.DeleteDestination(id)
+	destination, err := handler.Service.DeleteDestination(id, actorFromContext(c))
 
 	if err != nil {
-		if err.Error() == "invalid ID format" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID format"})
-		} else {
-			c.JSON(http.StatusNotFound, gin.H{"error": "destination not found"})
-		}
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, destination)
+	c.JSON(http.StatusOK, dto.NewDestinationResponse(destination))
 }
 
 func (handler *DestinationHandler) UpdateDestination(c *gin.Context) {
-	role, _ := c.Get("role")
-	if role != entities.Manager && role != entities.Admin {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+	id := c.Param("id")
+
+	var request dto.DestinationRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(invalidBody(err))
 		return
 	}
+	updatedDestination := request.ToDestination()
 
-	id := c.Param("id")
-
-	var updatedDestination entities.Destination
+	if err := services.ValidateDestination(updatedDestination); err != nil {
+		c.Error(err)
+		return
+	}
 
-	if err := c.BindJSON(&updatedDestination); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	destination, err := handler.Service.UpdateDestination(id, updatedDestination, actorFromContext(c))
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
-	validate := validator.New()
+	c.JSON(http.StatusOK, dto.NewDestinationResponse(destination))
+}
 
-	err := validate.RegisterValidation("name", utils.NameValidator)
+// maxImportBytes caps the body of an import request.
+const maxImportBytes = 10 << 20
+
+// ImportDestinations takes a CSV or JSON Lines body, chosen with ?format= or the Content-Type,
+// and answers with the per-row report. ?dry_run=true checks the rows without saving them.
+func (handler *DestinationHandler) ImportDestinations(c *gin.Context) {
+	format, err := importFormat(c)
 	if err != nil {
+		c.Error(err)
 		return
 	}
-	err = validate.RegisterValidation("description", utils.DescriptionValidator)
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
 	if err != nil {
+		c.Error(invalidParameter("dry_run"))
 		return
 	}
 
-	if err := validate.Struct(&updatedDestination); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxImportBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.Error(apperrors.Validation(fmt.Sprintf("an import is limited to %d bytes", maxImportBytes)))
+			return
+		}
+		c.Error(err)
 		return
 	}
 
-	destination, err := handler.Service.UpdateDestination(id, updatedDestination)
+	rows, err := services.ParseDestinationImport(bytes.NewReader(body), format)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update destination"})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, destination)
-}
-
-func (handler *DestinationHandler) Head(c *gin.Context) {
-	c.Header("Content-Type", "application/json")
+	report, err := handler.Service.ImportDestinations(rows, dryRun, actorFromContext(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
 
-	c.Status(http.StatusOK)
+	c.JSON(http.StatusOK, report)
 }
 
-func (handler *DestinationHandler) StartGeneratingDestinationsHandler(c *gin.Context) {
-	intervalParam := c.Query("interval")
-	interval, err := strconv.Atoi(intervalParam)
-	if err != nil || interval <= 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid interval parameter"})
-		return
+func importFormat(c *gin.Context) (services.ImportFormat, error) {
+	if format := c.Query("format"); format != "" {
+		return services.ParseImportFormat(format)
 	}
 
-	f := faker.New()
-	handler.Service.StartGeneratingDestinations(time.Duration(interval)*time.Second, f)
-	c.JSON(http.StatusOK, gin.H{"message": "Started generating destinations"})
+	switch c.ContentType() {
+	case "text/csv":
+		return services.ImportCSV, nil
+	case "application/jsonl", "application/x-ndjson", "application/x-jsonlines":
+		return services.ImportJSONL, nil
+	default:
+		return "", apperrors.Validation("set ?format=csv or ?format=jsonl, or a text/csv or application/x-ndjson Content-Type")
+	}
 }
 
-func (handler *DestinationHandler) StopGeneratingDestinationsHandler(c *gin.Context) {
-	println("Stop called")
-	handler.Service.StopGeneratingDestinations()
-	c.JSON(http.StatusOK, gin.H{"message": "Stopped generating destinations"})
+func (handler *DestinationHandler) Head(c *gin.Context) {
+	c.Header("Content-Type", "application/json")
+
+	c.Status(http.StatusOK)
 }
@@ -0,0 +1,22 @@
+package handlers
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"errors"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+)
+
+func invalidParameter(name string) error {
+	return apperrors.Validation(fmt.Sprintf("invalid %s parameter", name))
+}
+
+// invalidBody reports a request body that could not be bound. Binding tags are checked by
+// the same validator as the entities, so those failures keep their field details.
+func invalidBody(err error) error {
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		return apperrors.FromValidation(err)
+	}
+	return &apperrors.Error{Kind: apperrors.KindValidation, Message: "malformed request body: " + err.Error(), Err: err}
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"Trip-Trove-API/domain/events"
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/infrastructure/websocket"
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// EventsHandler streams the same events as /ws over Server-Sent Events, for clients that
+// only listen or sit behind proxies that break WebSocket upgrades.
+type EventsHandler struct {
+	Service          services.IDestinationService
+	WebSocketManager *websocket.WebSocketManager
+	Events           events.Replayer
+}
+
+func (handler *EventsHandler) StreamEvents(c *gin.Context) {
+	actor := actorFromContext(c)
+	expiresAt := tokenExpiryFromContext(c)
+
+	subscription, err := subscriptionFromQuery(c, handler.Service, handler.Events, actor)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if len(subscription.Topics) == 0 {
+		subscription.Topics = []websocket.Topic{websocket.TopicAllDestinations, websocket.TopicAllLocations}
+	}
+
+	// Browsers resend the id of the last event they saw when EventSource reconnects.
+	if lastEventID := c.GetHeader("Last-Event-ID"); lastEventID != "" {
+		since, err := parseSequence(lastEventID, "Last-Event-ID")
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		subscription.Since = &since
+	}
+
+	client := handler.WebSocketManager.AddStreamClient(actor, expiresAt, subscription)
+	defer handler.WebSocketManager.RemoveWebSocketClient(client)
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(client.PingPeriod())
+	defer heartbeat.Stop()
+
+	var expired <-chan time.Time
+	if !expiresAt.IsZero() {
+		expiry := time.NewTimer(time.Until(expiresAt))
+		defer expiry.Stop()
+		expired = expiry.C
+	}
+
+	controller := http.NewResponseController(c.Writer)
+	for {
+		var err error
+		select {
+		case message := <-client.Messages():
+			event := sse.Event{Event: message.Name, Data: string(message.Payload)}
+			if message.Sequence != 0 {
+				event.Id = strconv.FormatUint(message.Sequence, 10)
+			}
+			err = handler.write(c, controller, client, func(w io.Writer) error {
+				return sse.Encode(w, event)
+			})
+			if err == nil {
+				client.MarkDelivered()
+			}
+
+		case <-heartbeat.C:
+			err = handler.write(c, controller, client, func(w io.Writer) error {
+				_, err := io.WriteString(w, ": ping\n\n")
+				return err
+			})
+
+		case <-client.Done():
+			return
+		case <-expired:
+			return
+		case <-c.Request.Context().Done():
+			return
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+// write sends one frame with a deadline, so a stalled reader cannot hold the handler forever.
+func (handler *EventsHandler) write(c *gin.Context, controller *http.ResponseController, client *websocket.Client, frame func(w io.Writer) error) error {
+	_ = controller.SetWriteDeadline(time.Now().Add(client.WriteWait()))
+	if err := frame(c.Writer); err != nil {
+		return err
+	}
+	return controller.Flush()
+}
@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/services"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+)
+
+type GeneratorHandler struct {
+	Service services.IGeneratorService
+}
+
+func (handler *GeneratorHandler) StartJob(c *gin.Context) {
+	var request services.GeneratorRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+
+	if err := validator.New().Struct(request); err != nil {
+		c.Error(apperrors.FromValidation(err))
+		return
+	}
+
+	job, err := handler.Service.StartJob(request, actorFromContext(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, job)
+}
+
+func (handler *GeneratorHandler) Jobs(c *gin.Context) {
+	c.JSON(http.StatusOK, handler.Service.Jobs())
+}
+
+func (handler *GeneratorHandler) JobByID(c *gin.Context) {
+	job, err := handler.Service.JobByID(c.Param("jobId"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, job)
+}
+
+func (handler *GeneratorHandler) StopJob(c *gin.Context) {
+	job, err := handler.Service.StopJob(c.Param("jobId"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, job)
+}
@@ -1,10 +1,10 @@
 package handlers
 
 import (
-	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/apperrors"
 	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/presentation/dto"
 	"Trip-Trove-API/utils"
-	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 	"net/http"
@@ -17,170 +17,120 @@ type LocationHandler struct {
 func (handler *LocationHandler) AllLocations(c *gin.Context) {
 	locations, err := handler.Service.AllLocations()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch locations"})
+		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, locations)
+	c.JSON(http.StatusOK, dto.NewLocationResponses(locations))
 }
 
 func (handler *LocationHandler) LocationByID(c *gin.Context) {
 	id := c.Param("id")
 	location, err := handler.Service.LocationByID(id)
 	if err != nil {
-		if err.Error() == "invalid ID format" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		} else {
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-		}
+		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, location)
+	c.JSON(http.StatusOK, dto.NewLocationResponse(*location))
 }
 
 func (handler *LocationHandler) CreateLocation(c *gin.Context) {
-	role, _ := c.Get("role")
-	if role != entities.Manager && role != entities.Admin {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
-		return
-	}
-
-	var newLocation entities.Location
+	var request dto.LocationRequest
 
-	if err := c.BindJSON(&newLocation); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(invalidBody(err))
 		return
 	}
+	newLocation := request.ToLocation()
 
 	validate := validator.New()
 
 	err := validate.RegisterValidation("name", utils.NameValidator)
 	if err != nil {
+		c.Error(err)
 		return
 	}
 	err = validate.RegisterValidation("country", utils.CountryValidator)
 	if err != nil {
+		c.Error(err)
 		return
 	}
 	err = validate.RegisterValidation("description", utils.DescriptionValidator)
 	if err != nil {
+		c.Error(err)
 		return
 	}
 
 	err = validate.Struct(newLocation)
 
 	if err != nil {
-
-		var invalidValidationError *validator.InvalidValidationError
-		if errors.As(err, &invalidValidationError) {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid validation error"})
-			return
-		}
-
-		var errorMessages []string
-		for _, err := range err.(validator.ValidationErrors) {
-			errorMessage := "Validation error on field '" + err.Field() + "': " + err.ActualTag()
-			if err.Param() != "" {
-				errorMessage += " (Parameter: " + err.Param() + ")"
-			}
-			errorMessages = append(errorMessages, errorMessage)
-		}
-
-		c.JSON(http.StatusBadRequest, gin.H{"errors": errorMessages})
+		c.Error(apperrors.FromValidation(err))
 		return
 	}
 
-	location, err := handler.Service.CreateLocation(newLocation)
+	location, err := handler.Service.CreateLocation(newLocation, actorFromContext(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create location"})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, location)
+	c.JSON(http.StatusCreated, dto.NewLocationResponse(location))
 }
 
 func (handler *LocationHandler) DeleteLocation(c *gin.Context) {
-	role, _ := c.Get("role")
-	if role != entities.Manager && role != entities.Admin {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
-		return
-	}
-
 	id := c.Param("id")
 
-	location, err := handler.Service.DeleteLocation(id)
+	location, err := handler.Service.DeleteLocation(id, actorFromContext(c))
 
 	if err != nil {
-		if err.Error() == "invalid ID format" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		} else {
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-		}
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, location)
+	c.JSON(http.StatusOK, dto.NewLocationResponse(location))
 }
 
 func (handler *LocationHandler) UpdateLocation(c *gin.Context) {
-	role, _ := c.Get("role")
-	if role != entities.Manager && role != entities.Admin {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
-		return
-	}
-
 	id := c.Param("id")
 
-	var updatedLocation entities.Location
+	var request dto.LocationRequest
 
-	if err := c.BindJSON(&updatedLocation); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(invalidBody(err))
 		return
 	}
+	updatedLocation := request.ToLocation()
 
 	validate := validator.New()
 
 	err := validate.RegisterValidation("name", utils.NameValidator)
 	if err != nil {
+		c.Error(err)
 		return
 	}
 	err = validate.RegisterValidation("country", utils.CountryValidator)
 	if err != nil {
+		c.Error(err)
 		return
 	}
 	err = validate.RegisterValidation("description", utils.DescriptionValidator)
 	if err != nil {
+		c.Error(err)
 		return
 	}
 
 	err = validate.Struct(updatedLocation)
 
 	if err != nil {
-
-		var invalidValidationError *validator.InvalidValidationError
-		if errors.As(err, &invalidValidationError) {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid validation error"})
-			return
-		}
-
-		var errorMessages []string
-		for _, err := range err.(validator.ValidationErrors) {
-			errorMessage := "Validation error on field '" + err.Field() + "': " + err.ActualTag()
-			if err.Param() != "" {
-				errorMessage += " (Parameter: " + err.Param() + ")"
-			}
-			errorMessages = append(errorMessages, errorMessage)
-		}
-
-		c.JSON(http.StatusBadRequest, gin.H{"errors": errorMessages})
+		c.Error(apperrors.FromValidation(err))
 		return
 	}
 
-	location, err := handler.Service.UpdateLocation(id, updatedLocation)
+	location, err := handler.Service.UpdateLocation(id, updatedLocation, actorFromContext(c))
 
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "location not found"})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, location)
+	c.JSON(http.StatusOK, dto.NewLocationResponse(location))
 }
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"strconv"
+)
+
+type PaginatedResponse struct {
+	Data     interface{} `json:"data"`
+	Total    int64       `json:"total"`
+	Page     int         `json:"page"`
+	Limit    int         `json:"limit"`
+	NextPage *string     `json:"next_page"`
+	PrevPage *string     `json:"prev_page"`
+}
+
+func NewPaginatedResponse(c *gin.Context, data interface{}, total int64, page int, limit int) PaginatedResponse {
+	response := PaginatedResponse{
+		Data:  data,
+		Total: total,
+		Page:  page,
+		Limit: limit,
+	}
+
+	if int64(page*limit) < total {
+		next := pageLink(c, page+1, limit)
+		response.NextPage = &next
+	}
+	if page > 1 {
+		prev := pageLink(c, page-1, limit)
+		response.PrevPage = &prev
+	}
+
+	return response
+}
+
+func pageLink(c *gin.Context, page int, limit int) string {
+	link := *c.Request.URL
+	values := link.Query()
+	values.Set("page", strconv.Itoa(page))
+	values.Set("limit", strconv.Itoa(limit))
+	link.RawQuery = values.Encode()
+	return link.RequestURI()
+}
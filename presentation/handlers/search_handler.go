@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/presentation/dto"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type SearchHandler struct {
+	DestinationService services.IDestinationService
+	LocationService    services.ILocationService
+}
+
+type SearchResponse struct {
+	Query        string                                `json:"query"`
+	Destinations []dto.DestinationSearchResultResponse `json:"destinations"`
+	Locations    []dto.LocationSearchResultResponse    `json:"locations"`
+}
+
+func (handler *SearchHandler) Search(c *gin.Context) {
+	term := c.Query("q")
+
+	limit := 0
+	if value := c.Query("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil {
+			c.Error(invalidParameter("limit"))
+			return
+		}
+	}
+
+	searchType := c.DefaultQuery("type", "all")
+	if searchType != "all" && searchType != "destinations" && searchType != "locations" {
+		c.Error(invalidParameter("type"))
+		return
+	}
+
+	response := SearchResponse{
+		Query:        term,
+		Destinations: []dto.DestinationSearchResultResponse{},
+		Locations:    []dto.LocationSearchResultResponse{},
+	}
+
+	if searchType != "locations" {
+		destinations, err := handler.DestinationService.SearchDestinations(term, limit, actorFromContext(c))
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		response.Destinations = dto.NewDestinationSearchResults(destinations)
+	}
+	if searchType != "destinations" {
+		locations, err := handler.LocationService.SearchLocations(term, limit)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		response.Locations = dto.NewLocationSearchResults(locations)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
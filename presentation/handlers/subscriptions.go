@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/events"
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/infrastructure/websocket"
+	"github.com/gin-gonic/gin"
+	"strconv"
+)
+
+// subscriptionFromQuery reads the topics to subscribe to on connect (?topic=, repeatable) and
+// the sequence to resume after (?since=).
+func subscriptionFromQuery(c *gin.Context, service services.IDestinationService, replayer events.Replayer, actor entities.Actor) (websocket.Subscription, error) {
+	subscription := websocket.Subscription{Replay: replayer}
+
+	for _, rawTopic := range c.QueryArray("topic") {
+		topic, err := authorizeTopic(service, rawTopic, actor)
+		if err != nil {
+			return websocket.Subscription{}, err
+		}
+		subscription.Topics = append(subscription.Topics, topic)
+	}
+
+	if sinceParam, ok := c.GetQuery("since"); ok {
+		since, err := parseSequence(sinceParam, "since")
+		if err != nil {
+			return websocket.Subscription{}, err
+		}
+		subscription.Since = &since
+	}
+
+	return subscription, nil
+}
+
+// authorizeTopic parses rawTopic and refuses single destinations the actor may not see,
+// the same way GET /destinations/:id does.
+func authorizeTopic(service services.IDestinationService, rawTopic string, actor entities.Actor) (websocket.Topic, error) {
+	topic, err := websocket.ParseTopic(rawTopic)
+	if err != nil {
+		return "", apperrors.Validation(err.Error())
+	}
+
+	if id, ok := topic.DestinationID(); ok {
+		if _, err := service.DestinationByID(strconv.FormatUint(uint64(id), 10), actor); err != nil {
+			return "", err
+		}
+	}
+	return topic, nil
+}
+
+func parseSequence(value string, name string) (uint64, error) {
+	sequence, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, invalidParameter(name)
+	}
+	return sequence, nil
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/presentation/dto"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+)
+
+type TripHandler struct {
+	Service services.ITripService
+}
+
+func (handler *TripHandler) AllTrips(c *gin.Context) {
+	trips, err := handler.Service.AllTrips(actorFromContext(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewTripResponses(trips))
+}
+
+func (handler *TripHandler) TripByID(c *gin.Context) {
+	trip, err := handler.Service.TripByID(c.Param("id"), actorFromContext(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewTripResponse(*trip))
+}
+
+func (handler *TripHandler) CreateTrip(c *gin.Context) {
+	var request dto.TripRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+	newTrip := request.ToTrip()
+
+	if err := validator.New().Struct(newTrip); err != nil {
+		c.Error(apperrors.FromValidation(err))
+		return
+	}
+
+	trip, err := handler.Service.CreateTrip(newTrip, actorFromContext(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewTripResponse(trip))
+}
+
+func (handler *TripHandler) UpdateTrip(c *gin.Context) {
+	var request dto.TripRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+	updatedTrip := request.ToTrip()
+
+	if err := validator.New().StructExcept(updatedTrip, "Stops"); err != nil {
+		c.Error(apperrors.FromValidation(err))
+		return
+	}
+
+	trip, err := handler.Service.UpdateTrip(c.Param("id"), updatedTrip, actorFromContext(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewTripResponse(trip))
+}
+
+func (handler *TripHandler) DeleteTrip(c *gin.Context) {
+	trip, err := handler.Service.DeleteTrip(c.Param("id"), actorFromContext(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewTripResponse(trip))
+}
+
+func (handler *TripHandler) AddStop(c *gin.Context) {
+	var request dto.TripStopRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+	newStop := request.ToTripStop()
+
+	if err := validator.New().Struct(newStop); err != nil {
+		c.Error(apperrors.FromValidation(err))
+		return
+	}
+
+	stop, err := handler.Service.AddStop(c.Param("id"), newStop, actorFromContext(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewTripStopResponse(stop))
+}
+
+func (handler *TripHandler) DeleteStop(c *gin.Context) {
+	stop, err := handler.Service.DeleteStop(c.Param("id"), c.Param("stopId"), actorFromContext(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewTripStopResponse(stop))
+}
+
+func (handler *TripHandler) ReorderStops(c *gin.Context) {
+	var reorderRequest entities.ReorderStopsRequest
+
+	if err := c.ShouldBindJSON(&reorderRequest); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+
+	if err := validator.New().Struct(reorderRequest); err != nil {
+		c.Error(apperrors.FromValidation(err))
+		return
+	}
+
+	trip, err := handler.Service.ReorderStops(c.Param("id"), reorderRequest.Stops, actorFromContext(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewTripResponse(*trip))
+}
+
+func (handler *TripHandler) TripSummary(c *gin.Context) {
+	summary, err := handler.Service.TripSummary(c.Param("id"), actorFromContext(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
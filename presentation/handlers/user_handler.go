@@ -1,10 +1,11 @@
 package handlers
 
 import (
+	"Trip-Trove-API/domain/apperrors"
 	"Trip-Trove-API/domain/entities"
 	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/presentation/dto"
 	"Trip-Trove-API/utils"
-	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -18,217 +19,372 @@ type UserHandler struct {
 func (handler *UserHandler) AllUsers(c *gin.Context) {
 	users, err := handler.Service.AllUsers()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch users"})
+		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, users)
+	c.JSON(http.StatusOK, dto.NewUserResponses(users))
 }
 
 func (handler *UserHandler) UserByID(c *gin.Context) {
 	requestedID := c.Param("id")
-	userIDInterface, _ := c.Get("userID")
-	role, _ := c.Get("role")
-
-	userIDFloat, _ := userIDInterface.(float64)
-
 	var reqID uint
 	_, err := fmt.Sscan(requestedID, &reqID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		c.Error(apperrors.ErrInvalidID)
 		return
 	}
 
-	if role == entities.NormalUser && uint(userIDFloat) != reqID {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+	if actor := actorFromContext(c); actor.UserID != reqID && !actor.Can(entities.UsersRead) {
+		c.Error(services.ErrForbidden)
 		return
 	}
 
 	user, err := handler.Service.UserByID(requestedID)
 	if err != nil {
-		fmt.Println("Error fetching user:", err)
-		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, user)
+	c.JSON(http.StatusOK, dto.NewUserResponse(*user))
 }
 
 func (handler *UserHandler) Register(c *gin.Context) {
-	var newUser entities.User
+	var request dto.RegisterUserRequest
 
-	if err := c.ShouldBindJSON(&newUser); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(invalidBody(err))
 		return
 	}
 
-	validate := validator.New()
+	if err := validateUserRequest(request); err != nil {
+		c.Error(err)
+		return
+	}
 
-	validators := map[string]validator.Func{
-		"usernameValidator": utils.UsernameValidator,
-		"nameValidator":     utils.NameValidator,
-		"passwordValidator": utils.PasswordValidator,
+	user, err := handler.Service.Register(request.ToUser())
+	if err != nil {
+		c.Error(err)
+		return
 	}
 
-	print("Aici")
+	c.JSON(http.StatusCreated, dto.NewUserResponse(user))
+}
 
-	for validatorName, validatorFunction := range validators {
-		if err := validate.RegisterValidation(validatorName, validatorFunction); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to register validator: " + validatorName})
-			return
-		}
-	}
+func (handler *UserHandler) Login(c *gin.Context) {
+	var loginData entities.LoginRequest
 
-	err := validate.Struct(newUser)
+	if err := c.ShouldBindJSON(&loginData); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
 
+	loginResponse, err := handler.Service.Login(loginData)
 	if err != nil {
+		c.Error(err)
+		return
+	}
 
-		var invalidValidationError *validator.InvalidValidationError
-		if errors.As(err, &invalidValidationError) {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid validation error"})
-			return
-		}
+	c.JSON(http.StatusOK, loginResponse)
+}
 
-		var errorMessages []string
-		for _, err := range err.(validator.ValidationErrors) {
-			errorMessage := "Validation error on field '" + err.Field() + "': " + err.ActualTag()
-			if err.Param() != "" {
-				errorMessage += " (Parameter: " + err.Param() + ")"
-			}
-			errorMessages = append(errorMessages, errorMessage)
-		}
+func (handler *UserHandler) Refresh(c *gin.Context) {
+	var refreshRequest entities.RefreshRequest
 
-		c.JSON(http.StatusBadRequest, gin.H{"errors": errorMessages})
+	if err := c.ShouldBindJSON(&refreshRequest); err != nil {
+		c.Error(invalidBody(err))
 		return
 	}
 
-	user, err := handler.Service.Register(newUser)
+	loginResponse, err := handler.Service.Refresh(refreshRequest.RefreshToken)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to register"})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, user)
+	c.JSON(http.StatusOK, loginResponse)
 }
 
-func (handler *UserHandler) Login(c *gin.Context) {
-	var loginData entities.LoginRequest
+func (handler *UserHandler) Logout(c *gin.Context) {
+	var logoutRequest entities.LogoutRequest
 
-	if err := c.ShouldBindJSON(&loginData); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&logoutRequest); err != nil {
+			c.Error(invalidBody(err))
+			return
+		}
 	}
 
-	loginResponse, err := handler.Service.Login(loginData)
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "login failed"})
+	actor := actorFromContext(c)
+	tokenIDInterface, _ := c.Get("tokenID")
+	tokenID, _ := tokenIDInterface.(string)
+	expiresAtInterface, _ := c.Get("tokenExpiresAt")
+	expiresAt, _ := expiresAtInterface.(float64)
+
+	if err := handler.Service.Logout(actor.UserID, tokenID, int64(expiresAt), logoutRequest.RefreshToken); err != nil {
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, loginResponse)
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out"})
 }
 
-func (handler *UserHandler) DeleteUser(c *gin.Context) {
+func (handler *UserHandler) RevokeSessions(c *gin.Context) {
 	requestedID := c.Param("id")
-	userIDInterface, _ := c.Get("userID")
-	role, _ := c.Get("role")
 
-	userIDFloat, _ := userIDInterface.(float64)
+	if err := handler.Service.RevokeAllSessions(requestedID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "All sessions revoked"})
+}
 
+func (handler *UserHandler) DeleteUser(c *gin.Context) {
+	requestedID := c.Param("id")
 	var reqID uint
 	_, err := fmt.Sscan(requestedID, &reqID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		c.Error(apperrors.ErrInvalidID)
 		return
 	}
 
-	if role == entities.NormalUser && uint(userIDFloat) != reqID {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+	if actor := actorFromContext(c); actor.UserID != reqID && !actor.Can(entities.UsersWrite) {
+		c.Error(services.ErrForbidden)
 		return
 	}
 
 	user, err := handler.Service.DeleteUser(requestedID)
 
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, user)
+	c.JSON(http.StatusOK, dto.NewUserResponse(user))
 }
 
 func (handler *UserHandler) UpdateUser(c *gin.Context) {
 	requestedID := c.Param("id")
-	userIDInterface, _ := c.Get("userID")
-	role, _ := c.Get("role")
-
-	userIDFloat, _ := userIDInterface.(float64)
-
 	var reqID uint
 	_, err := fmt.Sscan(requestedID, &reqID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		c.Error(apperrors.ErrInvalidID)
 		return
 	}
 
-	if role == entities.NormalUser && uint(userIDFloat) != reqID {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+	if actor := actorFromContext(c); actor.UserID != reqID && !actor.Can(entities.UsersWrite) {
+		c.Error(services.ErrForbidden)
 		return
 	}
 
-	var updatedUser entities.User
+	var request dto.UpdateUserRequest
 
-	if err := c.BindJSON(&updatedUser); err != nil {
-		if err.Error() == "invalid ID format" {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID format"})
-		} else {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(invalidBody(err))
 		return
 	}
 
-	validate := validator.New()
+	if err := validateUserRequest(request); err != nil {
+		c.Error(err)
+		return
+	}
 
-	validators := map[string]validator.Func{
-		"usernameValidator": utils.UsernameValidator,
-		"nameValidator":     utils.NameValidator,
-		"passwordValidator": utils.PasswordValidator,
+	user, err := handler.Service.UpdateUser(requestedID, request.ToUser())
+
+	if err != nil {
+		c.Error(err)
+		return
 	}
 
-	for validatorName, validatorFunction := range validators {
-		if err := validate.RegisterValidation(validatorName, validatorFunction); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to register validator: " + validatorName})
-			return
-		}
+	c.JSON(http.StatusOK, dto.NewUserResponse(user))
+}
+
+func (handler *UserHandler) Me(c *gin.Context) {
+	user, err := handler.Service.UserByID(fmt.Sprint(actorFromContext(c).UserID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewUserResponse(*user))
+}
+
+func (handler *UserHandler) UpdateMe(c *gin.Context) {
+	var request dto.ProfileRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(invalidBody(err))
+		return
 	}
 
-	err = validate.Struct(updatedUser)
+	if err := validateUserRequest(request); err != nil {
+		c.Error(err)
+		return
+	}
 
+	user, err := handler.Service.UpdateProfile(actorFromContext(c).UserID, request.ToUser())
 	if err != nil {
+		c.Error(err)
+		return
+	}
 
-		var invalidValidationError *validator.InvalidValidationError
-		if errors.As(err, &invalidValidationError) {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid validation error"})
-			return
-		}
+	c.JSON(http.StatusOK, dto.NewUserResponse(user))
+}
 
-		var errorMessages []string
-		for _, err := range err.(validator.ValidationErrors) {
-			errorMessage := "Validation error on field '" + err.Field() + "': " + err.ActualTag()
-			if err.Param() != "" {
-				errorMessage += " (Parameter: " + err.Param() + ")"
-			}
-			errorMessages = append(errorMessages, errorMessage)
-		}
+func (handler *UserHandler) ChangePassword(c *gin.Context) {
+	var request dto.ChangePasswordRequest
 
-		c.JSON(http.StatusBadRequest, gin.H{"errors": errorMessages})
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(invalidBody(err))
 		return
 	}
 
-	user, err := handler.Service.UpdateUser(requestedID, updatedUser)
+	if err := validateUserRequest(request); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := handler.Service.ChangePassword(actorFromContext(c).UserID, request.CurrentPassword, request.NewPassword); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password changed"})
+}
+
+// ForgotPassword answers the same way whether or not the address has an account.
+func (handler *UserHandler) ForgotPassword(c *gin.Context) {
+	var request dto.ForgotPasswordRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+
+	if err := validateUserRequest(request); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := handler.Service.RequestPasswordReset(request.Email); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "If the address belongs to an account, a reset link has been sent to it"})
+}
+
+func (handler *UserHandler) ResetPassword(c *gin.Context) {
+	var request dto.ResetPasswordRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+
+	if err := validateUserRequest(request); err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := handler.Service.ResetPassword(request.Token, request.NewPassword); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password reset, please log in again"})
+}
+
+func (handler *UserHandler) SendEmailVerification(c *gin.Context) {
+	if err := handler.Service.SendEmailVerification(actorFromContext(c).UserID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "Verification email sent"})
+}
+
+func (handler *UserHandler) VerifyEmail(c *gin.Context) {
+	var request dto.VerifyEmailRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+
+	if err := handler.Service.VerifyEmail(request.Token); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Email verified"})
+}
 
+func (handler *UserHandler) ChangeRole(c *gin.Context) {
+	var request dto.RoleRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(invalidBody(err))
+		return
+	}
+
+	if err := validateUserRequest(request); err != nil {
+		c.Error(err)
+		return
+	}
+	role, _ := entities.ParseAccessType(request.Role)
+
+	user, err := handler.Service.ChangeRole(c.Param("id"), role, request.Reason, actorFromContext(c))
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, user)
+	c.JSON(http.StatusOK, dto.NewUserResponse(user))
+}
+
+// RevokeRole takes every role away from the user, who is left a normal user.
+func (handler *UserHandler) RevokeRole(c *gin.Context) {
+	reason := c.Query("reason")
+	if len(reason) > 500 {
+		c.Error(invalidParameter("reason"))
+		return
+	}
+
+	user, err := handler.Service.ChangeRole(c.Param("id"), entities.NormalUser, reason, actorFromContext(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewUserResponse(user))
+}
+
+func (handler *UserHandler) RoleChanges(c *gin.Context) {
+	changes, err := handler.Service.RoleChanges(c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewRoleChangeResponses(changes))
+}
+
+func validateUserRequest(request interface{}) error {
+	validate := validator.New()
+
+	validators := map[string]validator.Func{
+		"usernameValidator": utils.UsernameValidator,
+		"nameValidator":     utils.NameValidator,
+		"passwordValidator": utils.PasswordValidator,
+	}
+
+	for validatorName, validatorFunction := range validators {
+		if err := validate.RegisterValidation(validatorName, validatorFunction); err != nil {
+			return err
+		}
+	}
+
+	if err := validate.Struct(request); err != nil {
+		return apperrors.FromValidation(err)
+	}
+	return nil
 }
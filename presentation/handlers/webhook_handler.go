@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/presentation/dto"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+	"strconv"
+)
+
+type WebhookHandler struct {
+	Service services.IWebhookService
+}
+
+func (handler *WebhookHandler) AllWebhooks(c *gin.Context) {
+	webhooks, err := handler.Service.AllWebhooks()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewWebhookResponses(webhooks))
+}
+
+func (handler *WebhookHandler) WebhookByID(c *gin.Context) {
+	webhook, err := handler.Service.WebhookByID(c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewWebhookResponse(*webhook))
+}
+
+func (handler *WebhookHandler) CreateWebhook(c *gin.Context) {
+	request, err := bindWebhookRequest(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	webhook, err := handler.Service.CreateWebhook(request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.NewWebhookResponse(webhook))
+}
+
+func (handler *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	request, err := bindWebhookRequest(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	webhook, err := handler.Service.UpdateWebhook(c.Param("id"), request)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewWebhookResponse(webhook))
+}
+
+func bindWebhookRequest(c *gin.Context) (entities.WebhookRequest, error) {
+	var request entities.WebhookRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		return request, invalidBody(err)
+	}
+	if err := validator.New().Struct(request); err != nil {
+		return request, apperrors.FromValidation(err)
+	}
+
+	return request, nil
+}
+
+func (handler *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	webhook, err := handler.Service.DeleteWebhook(c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.NewWebhookResponse(webhook))
+}
+
+func (handler *WebhookHandler) Deliveries(c *gin.Context) {
+	query := entities.WebhookDeliveryQuery{Status: entities.WebhookDeliveryStatus(c.Query("status"))}
+
+	var err error
+	intParams := map[string]*int{"page": &query.Page, "limit": &query.Limit}
+	for name, target := range intParams {
+		if value := c.Query(name); value != "" {
+			if *target, err = strconv.Atoi(value); err != nil {
+				c.Error(invalidParameter(name))
+				return
+			}
+		}
+	}
+
+	page, err := handler.Service.Deliveries(c.Param("id"), query)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, NewPaginatedResponse(c, dto.NewWebhookDeliveryResponses(page.Deliveries), page.Total, page.Page, page.Limit))
+}
+
+func (handler *WebhookHandler) DeliveryByID(c *gin.Context) {
+	delivery, err := handler.Service.DeliveryByID(c.Param("id"), c.Param("deliveryId"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewWebhookDeliveryResponse(*delivery))
+}
+
+func (handler *WebhookHandler) ReplayDelivery(c *gin.Context) {
+	delivery, err := handler.Service.ReplayDelivery(c.Param("id"), c.Param("deliveryId"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusAccepted, dto.NewWebhookDeliveryResponse(delivery))
+}
@@ -1,64 +1,132 @@
 package handlers
 
 import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/events"
 	"Trip-Trove-API/domain/services"
 	"Trip-Trove-API/infrastructure/websocket"
+	"Trip-Trove-API/presentation/dto"
 	"github.com/gin-gonic/gin"
 	"log"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 type WebSocketHandler struct {
 	Service          services.IDestinationService
 	WebSocketManager *websocket.WebSocketManager
+	Events           events.Replayer
 }
 
 func (wc *WebSocketHandler) HandleConnections(c *gin.Context) {
+	actor := actorFromContext(c)
+	expiresAt := tokenExpiryFromContext(c)
+
+	subscription, err := subscriptionFromQuery(c, wc.Service, wc.Events, actor)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
 	ws, err := websocket.Upgrade(c.Writer, c.Request)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to set WebSocket upgrade: " + err.Error()})
 		return
 	}
 
-	defer func(ws *websocket.Connection) {
-		err := ws.Close()
-		if err != nil {
-			return
-		}
-
-	}(ws)
-
-	wc.WebSocketManager.AddWebSocketClient(ws)
+	// The read deadline never passes the token expiry, so the socket lives no longer than its token.
+	client := wc.WebSocketManager.AddWebSocketClient(ws, actor, expiresAt, subscription)
+	defer wc.WebSocketManager.RemoveWebSocketClient(client)
 
 	for {
 		var msg websocket.Message
-		err := ws.ReadJSON(&msg)
+		err := client.ReadJSON(&msg)
 		if err != nil {
 			log.Printf("Error reading json: %v", err)
 			break
 		}
 
+		var reply websocket.Reply
 		switch msg.Action {
 
-		case "GenerateDestination":
-			log.Printf("Got request to create destination")
-			newDestination := msg.Destination
-			//destination, err := wc.Service.CreateDestination(newDestination)
-			//if err != nil {
-			//	log.Printf("Error creating destination: %v", err)
-			//	err := ws.WriteJSON(gin.H{"error": err.Error()})
-			//	if err != nil {
-			//		return
-			//	}
-			//	continue
-			//}
-			//wc.WebSocketManager.AddToBroadcast(websocket.EventUpdateNotification{Action: "CreateDestination", Destination: newDestination})
-			err = ws.WriteJSON(newDestination)
-			if err != nil {
-				log.Printf("Error writing json: %v", err)
-				return
-			}
+		case websocket.ActionSubscribe:
+			reply = wc.subscribe(client, msg.Topic)
+
+		case websocket.ActionUnsubscribe:
+			reply = wc.unsubscribe(client, msg.Topic)
+
+		case websocket.ActionCreateDestination:
+			reply = wc.createDestination(client, msg.Destination)
+
+		default:
+			reply = websocket.Reply{Action: websocket.ActionError, Error: "unknown action " + strconv.Quote(msg.Action)}
+		}
+
+		if !client.Send(reply) {
+			return
 		}
 	}
+}
+
+func (wc *WebSocketHandler) Metrics(c *gin.Context) {
+	c.JSON(http.StatusOK, wc.WebSocketManager.Metrics())
+}
+
+func (wc *WebSocketHandler) subscribe(client *websocket.Client, rawTopic string) websocket.Reply {
+	topic, err := authorizeTopic(wc.Service, rawTopic, client.Actor)
+	if err != nil {
+		reply := socketError(err)
+		reply.Topic = rawTopic
+		return reply
+	}
+
+	wc.WebSocketManager.Subscribe(client, topic)
+	return websocket.Reply{Action: websocket.ActionSubscribed, Topic: string(topic)}
+}
+
+// createDestination goes through the same service call as POST /destinations/, so the
+// resulting DestinationCreated event reaches subscribers like any other mutation.
+func (wc *WebSocketHandler) createDestination(client *websocket.Client, destination entities.Destination) websocket.Reply {
+	if !client.Actor.Can(entities.DestinationsWrite) {
+		return socketError(services.ErrForbidden)
+	}
+	if err := services.ValidateDestination(destination); err != nil {
+		return socketError(err)
+	}
+
+	created, err := wc.Service.CreateDestination(destination, client.Actor)
+	if err != nil {
+		return socketError(err)
+	}
+	return websocket.Reply{Action: websocket.ActionCreated, Data: dto.NewDestinationResponse(created)}
+}
+
+func (wc *WebSocketHandler) unsubscribe(client *websocket.Client, rawTopic string) websocket.Reply {
+	topic, err := websocket.ParseTopic(rawTopic)
+	if err != nil {
+		return websocket.Reply{Action: websocket.ActionError, Topic: rawTopic, Error: err.Error()}
+	}
+
+	wc.WebSocketManager.Unsubscribe(client, topic)
+	return websocket.Reply{Action: websocket.ActionUnsubscribed, Topic: string(topic)}
+}
 
+// socketError reports err to the client without leaking internal details, like ErrorMiddleware.
+func socketError(err error) websocket.Reply {
+	if apperrors.KindOf(err) == apperrors.KindInternal {
+		log.Printf("Error: %v", err)
+		return websocket.Reply{Action: websocket.ActionError, Error: "internal server error"}
+	}
+	return websocket.Reply{Action: websocket.ActionError, Error: err.Error()}
+}
+
+func tokenExpiryFromContext(c *gin.Context) time.Time {
+	expiresAtInterface, _ := c.Get("tokenExpiresAt")
+	expiresAt, ok := expiresAtInterface.(float64)
+	if !ok {
+		return time.Time{}
+	}
+	return time.Unix(int64(expiresAt), 0)
 }
@@ -10,14 +10,14 @@ import (
 func RegisterDestinationRoutes(router *gin.Engine, destinationHandler *handlers.DestinationHandler, roleMiddleware middlewares.IAuthMiddleware) {
 	destinationGroup := router.Group("/destinations")
 	{
-		destinationGroup.GET("/", destinationHandler.AllDestinations)
-		destinationGroup.GET("/:id", destinationHandler.DestinationByID)
-		destinationGroup.GET("/location/:locationId", destinationHandler.DestinationsByLocationID)
-		destinationGroup.POST("/", roleMiddleware.RequireRole(entities.Manager), destinationHandler.CreateDestination)
-		destinationGroup.PUT("/:id", roleMiddleware.RequireRole(entities.Manager), destinationHandler.UpdateDestination)
-		destinationGroup.DELETE("/:id", roleMiddleware.RequireRole(entities.Manager), destinationHandler.DeleteDestination)
+		destinationGroup.GET("/", roleMiddleware.OptionalAuth(), destinationHandler.AllDestinations)
+		destinationGroup.GET("/nearby", roleMiddleware.OptionalAuth(), destinationHandler.NearbyDestinations)
+		destinationGroup.GET("/:id", roleMiddleware.OptionalAuth(), destinationHandler.DestinationByID)
+		destinationGroup.GET("/location/:locationId", roleMiddleware.OptionalAuth(), destinationHandler.DestinationsByLocationID)
+		destinationGroup.POST("/", roleMiddleware.RequirePermission(entities.DestinationsWrite), destinationHandler.CreateDestination)
+		destinationGroup.POST("/import", roleMiddleware.RequirePermission(entities.DestinationsImport), destinationHandler.ImportDestinations)
+		destinationGroup.PUT("/:id", roleMiddleware.RequirePermission(entities.DestinationsWrite), destinationHandler.UpdateDestination)
+		destinationGroup.DELETE("/:id", roleMiddleware.RequirePermission(entities.DestinationsDelete), destinationHandler.DeleteDestination)
 		destinationGroup.HEAD("/", destinationHandler.Head)
-		destinationGroup.GET("/start-generating-destinations", destinationHandler.StartGeneratingDestinationsHandler)
-		destinationGroup.GET("/stop-generating-destinations", destinationHandler.StopGeneratingDestinationsHandler)
 	}
 }
@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/infrastructure/middlewares"
+	"Trip-Trove-API/presentation/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterEventRoutes(router *gin.Engine, eventsHandler *handlers.EventsHandler, roleMiddleware middlewares.IAuthMiddleware) {
+	router.GET("/events", roleMiddleware.RequirePermission(entities.EventsRead), eventsHandler.StreamEvents)
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/infrastructure/middlewares"
+	"Trip-Trove-API/presentation/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterGeneratorRoutes(router *gin.Engine, generatorHandler *handlers.GeneratorHandler, roleMiddleware middlewares.IAuthMiddleware) {
+	generatorGroup := router.Group("/destinations/generator/jobs", roleMiddleware.RequirePermission(entities.GeneratorRun))
+	{
+		generatorGroup.GET("/", generatorHandler.Jobs)
+		generatorGroup.POST("/", generatorHandler.StartJob)
+		generatorGroup.GET("/:jobId", generatorHandler.JobByID)
+		generatorGroup.POST("/:jobId/stop", generatorHandler.StopJob)
+	}
+}
@@ -12,8 +12,8 @@ func RegisterLocationRoutes(router *gin.Engine, locationHandler *handlers.Locati
 	{
 		locationGroup.GET("/", locationHandler.AllLocations)
 		locationGroup.GET("/:id", locationHandler.LocationByID)
-		locationGroup.POST("/", roleMiddleware.RequireRole(entities.Manager), locationHandler.CreateLocation)
-		locationGroup.PUT("/:id", roleMiddleware.RequireRole(entities.Manager), locationHandler.UpdateLocation)
-		locationGroup.DELETE("/:id", roleMiddleware.RequireRole(entities.Manager), locationHandler.DeleteLocation)
+		locationGroup.POST("/", roleMiddleware.RequirePermission(entities.LocationsWrite), locationHandler.CreateLocation)
+		locationGroup.PUT("/:id", roleMiddleware.RequirePermission(entities.LocationsWrite), locationHandler.UpdateLocation)
+		locationGroup.DELETE("/:id", roleMiddleware.RequirePermission(entities.LocationsDelete), locationHandler.DeleteLocation)
 	}
 }
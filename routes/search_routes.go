@@ -0,0 +1,11 @@
+package routes
+
+import (
+	"Trip-Trove-API/infrastructure/middlewares"
+	"Trip-Trove-API/presentation/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterSearchRoutes(router *gin.Engine, searchHandler *handlers.SearchHandler, roleMiddleware middlewares.IAuthMiddleware) {
+	router.GET("/search", roleMiddleware.OptionalAuth(), searchHandler.Search)
+}
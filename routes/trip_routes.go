@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/infrastructure/middlewares"
+	"Trip-Trove-API/presentation/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterTripRoutes(router *gin.Engine, tripHandler *handlers.TripHandler, roleMiddleware middlewares.IAuthMiddleware) {
+	tripGroup := router.Group("/trips", roleMiddleware.RequirePermission(entities.TripsWrite))
+	{
+		tripGroup.GET("/", tripHandler.AllTrips)
+		tripGroup.GET("/:id", tripHandler.TripByID)
+		tripGroup.GET("/:id/summary", tripHandler.TripSummary)
+		tripGroup.POST("/", tripHandler.CreateTrip)
+		tripGroup.PUT("/:id", tripHandler.UpdateTrip)
+		tripGroup.DELETE("/:id", tripHandler.DeleteTrip)
+		tripGroup.POST("/:id/stops", tripHandler.AddStop)
+		tripGroup.PUT("/:id/stops/order", tripHandler.ReorderStops)
+		tripGroup.DELETE("/:id/stops/:stopId", tripHandler.DeleteStop)
+	}
+}
@@ -10,11 +10,24 @@ import (
 func RegisterUserRoutes(router *gin.Engine, userHandler *handlers.UserHandler, roleMiddleware middlewares.IAuthMiddleware) {
 	userGroup := router.Group("/users")
 	{
-		userGroup.GET("/", roleMiddleware.RequireRole(entities.Admin), userHandler.AllUsers)
-		userGroup.GET("/:id", roleMiddleware.RequireRole(entities.NormalUser), userHandler.UserByID)
+		userGroup.GET("/", roleMiddleware.RequirePermission(entities.UsersRead), userHandler.AllUsers)
+		userGroup.GET("/me", roleMiddleware.RequireAuth(), userHandler.Me)
+		userGroup.PATCH("/me", roleMiddleware.RequireAuth(), userHandler.UpdateMe)
+		userGroup.POST("/me/password", roleMiddleware.RequireAuth(), userHandler.ChangePassword)
+		userGroup.POST("/me/verify-email", roleMiddleware.RequireAuth(), userHandler.SendEmailVerification)
+		userGroup.POST("/verify-email", userHandler.VerifyEmail)
+		userGroup.POST("/password/forgot", userHandler.ForgotPassword)
+		userGroup.POST("/password/reset", userHandler.ResetPassword)
+		userGroup.GET("/:id", roleMiddleware.RequireAuth(), userHandler.UserByID)
 		userGroup.POST("/register", userHandler.Register)
 		userGroup.POST("/login", userHandler.Login)
-		userGroup.PUT("/:id", roleMiddleware.RequireRole(entities.Admin), userHandler.UpdateUser)
-		userGroup.DELETE("/:id", roleMiddleware.RequireRole(entities.Admin), userHandler.DeleteUser)
+		userGroup.POST("/refresh", userHandler.Refresh)
+		userGroup.POST("/logout", roleMiddleware.RequireAuth(), userHandler.Logout)
+		userGroup.POST("/:id/revoke-sessions", roleMiddleware.RequirePermission(entities.UsersWrite), userHandler.RevokeSessions)
+		userGroup.PUT("/:id/role", roleMiddleware.RequirePermission(entities.UsersRoles), userHandler.ChangeRole)
+		userGroup.DELETE("/:id/role", roleMiddleware.RequirePermission(entities.UsersRoles), userHandler.RevokeRole)
+		userGroup.GET("/:id/role-changes", roleMiddleware.RequirePermission(entities.UsersRoles), userHandler.RoleChanges)
+		userGroup.PUT("/:id", roleMiddleware.RequirePermission(entities.UsersWrite), userHandler.UpdateUser)
+		userGroup.DELETE("/:id", roleMiddleware.RequirePermission(entities.UsersWrite), userHandler.DeleteUser)
 	}
 }
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/infrastructure/middlewares"
+	"Trip-Trove-API/presentation/handlers"
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterWebhookRoutes(router *gin.Engine, webhookHandler *handlers.WebhookHandler, roleMiddleware middlewares.IAuthMiddleware) {
+	webhookGroup := router.Group("/webhooks", roleMiddleware.RequirePermission(entities.WebhooksManage))
+	{
+		webhookGroup.GET("/", webhookHandler.AllWebhooks)
+		webhookGroup.GET("/:id", webhookHandler.WebhookByID)
+		webhookGroup.POST("/", webhookHandler.CreateWebhook)
+		webhookGroup.PUT("/:id", webhookHandler.UpdateWebhook)
+		webhookGroup.DELETE("/:id", webhookHandler.DeleteWebhook)
+		webhookGroup.GET("/:id/deliveries", webhookHandler.Deliveries)
+		webhookGroup.GET("/:id/deliveries/:deliveryId", webhookHandler.DeliveryByID)
+		webhookGroup.POST("/:id/deliveries/:deliveryId/replay", webhookHandler.ReplayDelivery)
+	}
+}
@@ -1,10 +1,13 @@
 package routes
 
 import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/infrastructure/middlewares"
 	"Trip-Trove-API/presentation/handlers"
 	"github.com/gin-gonic/gin"
 )
 
-func RegisterWebSocketRoutes(router *gin.Engine, wsHandler *handlers.WebSocketHandler) {
-	router.GET("/ws", wsHandler.HandleConnections)
+func RegisterWebSocketRoutes(router *gin.Engine, wsHandler *handlers.WebSocketHandler, roleMiddleware middlewares.IAuthMiddleware) {
+	router.GET("/ws", roleMiddleware.RequireAuth(), wsHandler.HandleConnections)
+	router.GET("/ws/metrics", roleMiddleware.RequirePermission(entities.MetricsRead), wsHandler.Metrics)
 }
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"Trip-Trove-API/database"
+	"Trip-Trove-API/database/migrations"
+	"Trip-Trove-API/domain/repositories"
+	"Trip-Trove-API/infrastructure/dataaccess"
+	"gorm.io/gorm"
+	"log"
+	"os"
+)
+
+// openStorage connects to the backend selected by DB_DRIVER and makes sure its schema exists.
+// The returned db is nil for the memory backend.
+func openStorage() (repositories.Repositories, repositories.UnitOfWork, *gorm.DB) {
+	if database.Driver() == database.DriverMemory {
+		store := dataaccess.NewMemoryStore()
+		return dataaccess.NewMemoryRepositories(store), dataaccess.NewMemoryUnitOfWork(store), nil
+	}
+
+	db := database.ConnectDB()
+	prepareSchema(db)
+
+	return dataaccess.NewGormRepositories(db), dataaccess.NewGormUnitOfWork(db), db
+}
+
+// prepareSchema auto-migrates SQLite and, when DB_AUTO_MIGRATE is set, Postgres. Otherwise
+// Postgres is left to `migrate up` and only pending migrations are reported.
+func prepareSchema(db *gorm.DB) {
+	if database.Driver() == database.DriverSQLite || os.Getenv("DB_AUTO_MIGRATE") == "true" {
+		if err := database.AutoMigrate(db); err != nil {
+			log.Fatalf("Failed to migrate database: %v", err)
+		}
+		return
+	}
+
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %v", err)
+	}
+	pending, err := migrator.Pending()
+	if err != nil {
+		log.Printf("Could not check migration status: %v", err)
+	} else if len(pending) > 0 {
+		log.Printf("Warning: %d pending migration(s), run `migrate up`", len(pending))
+	}
+}
@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"Trip-Trove-API/commands"
+	"Trip-Trove-API/domain/entities"
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestCreateAdmin_CreatesTheFirstAdminOnly(t *testing.T) {
+	db := openSQLite(t)
+	t.Setenv("ADMIN_PASSWORD", "")
+
+	var out bytes.Buffer
+	args := []string{"-email", "root@example.com", "-username", "root", "-first-name", "Ana", "-last-name", "Silva", "-phone", "+40700000001"}
+	err := commands.CreateAdmin(db, args, strings.NewReader("weak\n"), &out)
+	assert.ErrorContains(t, err, "password")
+
+	err = commands.CreateAdmin(db, args, strings.NewReader("Secret-pass-1!\n"), &out)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "created admin root@example.com (id 1)")
+
+	var admin entities.User
+	require.NoError(t, db.First(&admin, "email = ?", "root@example.com").Error)
+	assert.Equal(t, entities.Admin, admin.Role)
+	assert.True(t, admin.EmailVerified())
+
+	var changes int64
+	db.Model(&entities.RoleChange{}).Where("user_id = ?", admin.ID).Count(&changes)
+	assert.Equal(t, int64(1), changes)
+
+	err = commands.CreateAdmin(db, []string{"-email", "other@example.com"}, strings.NewReader(""), &out)
+	assert.Error(t, err)
+}
+
+func TestCreateAdmin_PromotesAnExistingUser(t *testing.T) {
+	db := openSQLite(t)
+	user := entities.User{Username: "ana", Email: "ana@example.com", PhoneNumber: "+40700000002", Password: "hashed"}
+	require.NoError(t, db.Create(&user).Error)
+
+	var out bytes.Buffer
+	require.NoError(t, commands.CreateAdmin(db, []string{"-email", "ana@example.com"}, strings.NewReader(""), &out))
+	assert.Contains(t, out.String(), "promoted ana@example.com (id 1) to admin")
+
+	err := commands.CreateAdmin(db, []string{"-email", "ana@example.com"}, strings.NewReader(""), &out)
+	assert.ErrorContains(t, err, "an admin already exists")
+	err = commands.CreateAdmin(db, []string{"-email", "nobody@example.com"}, strings.NewReader(""), &out)
+	assert.ErrorContains(t, err, "-username, -first-name, -last-name, -phone")
+}
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"Trip-Trove-API/commands"
+	"Trip-Trove-API/domain/entities"
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const importRows = `{"name": "Belem Tower", "location": "Lisbon", "country": "Portugal", "description": "A fortified tower on the Tagus"}
+{"name": "Livraria Lello", "location": "Porto", "country": "Portugal", "description": "too short"}
+`
+
+func TestImport_ReadsFileAndPrintsRejections(t *testing.T) {
+	db := openSQLite(t)
+	manager := entities.User{Username: "manager1", Email: "manager1@example.com", PhoneNumber: "+40700000001", Password: "hashed", Role: entities.Manager}
+	require.NoError(t, db.Create(&manager).Error)
+
+	path := filepath.Join(t.TempDir(), "destinations.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(importRows), 0o600))
+
+	var out bytes.Buffer
+	err := commands.Import(db, []string{"-user", "1", "-dry-run", path}, nil, &out)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "line 2 rejected: description:")
+	assert.Contains(t, out.String(), "dry run, would have imported: 1 created, 0 updated, 1 rejected, 1 new locations")
+	assert.Empty(t, destinationNames(t, db))
+
+	out.Reset()
+	err = commands.Import(db, []string{"-user", "1", "-format", "jsonl", "-"}, strings.NewReader(importRows), &out)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "imported: 1 created")
+	assert.Equal(t, []string{"Belem Tower"}, destinationNames(t, db))
+
+	var logged int64
+	db.Table("event_log").Count(&logged)
+	assert.Equal(t, int64(2), logged, "the location and the destination are logged")
+}
+
+func TestImport_NeedsAManager(t *testing.T) {
+	db := openSQLite(t)
+	user := entities.User{Username: "user1", Email: "user1@example.com", PhoneNumber: "+40700000002", Password: "hashed", Role: entities.NormalUser}
+	require.NoError(t, db.Create(&user).Error)
+
+	var out bytes.Buffer
+	assert.Error(t, commands.Import(db, []string{"-format", "jsonl", "-"}, strings.NewReader(importRows), &out))
+	assert.Error(t, commands.Import(db, []string{"-user", "1", "-format", "jsonl", "-"}, strings.NewReader(importRows), &out))
+	assert.Error(t, commands.Import(db, []string{"-user", "1", "rows.txt"}, nil, &out), "the format cannot be guessed")
+}
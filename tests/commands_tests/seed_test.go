@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"Trip-Trove-API/commands"
+	"Trip-Trove-API/database"
+	"Trip-Trove-API/domain/entities"
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"path/filepath"
+	"testing"
+)
+
+func openSQLite(t *testing.T) *gorm.DB {
+	db, err := database.OpenSQLite(filepath.Join(t.TempDir(), "seed.db"))
+	require.NoError(t, err)
+	require.NoError(t, database.AutoMigrate(db))
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+func destinationNames(t *testing.T, db *gorm.DB) []string {
+	var names []string
+	require.NoError(t, db.Model(&entities.Destination{}).Order("id").Pluck("name", &names).Error)
+	return names
+}
+
+func TestSeed_InsertsRequestedRowsInBatches(t *testing.T) {
+	db := openSQLite(t)
+	var out bytes.Buffer
+
+	err := commands.Seed(db, []string{"-locations", "7", "-destinations", "3", "-users", "2", "-batch", "4"}, &out)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "seeded 6 users, 7 locations and 21 destinations")
+
+	var locations, destinations int64
+	db.Model(&entities.Location{}).Count(&locations)
+	db.Model(&entities.Destination{}).Count(&destinations)
+	assert.Equal(t, int64(7), locations)
+	assert.Equal(t, int64(21), destinations)
+
+	for _, role := range []entities.AccessType{entities.Admin, entities.Manager, entities.NormalUser} {
+		var users int64
+		db.Model(&entities.User{}).Where("access_type = ?", role).Count(&users)
+		assert.Equal(t, int64(2), users)
+	}
+
+	var admin entities.User
+	require.NoError(t, db.First(&admin, "username = ?", "admin1").Error)
+	assert.Equal(t, entities.Admin, admin.Role)
+	assert.NotEqual(t, "Seed-password-1", admin.Password, "passwords are stored hashed")
+}
+
+func TestSeed_IsReproducibleAfterWipe(t *testing.T) {
+	db := openSQLite(t)
+	args := []string{"-locations", "5", "-destinations", "2", "-users", "1", "-seed", "99"}
+
+	require.NoError(t, commands.Seed(db, args, &bytes.Buffer{}))
+	first := destinationNames(t, db)
+
+	require.NoError(t, commands.Seed(db, append(args, "-wipe"), &bytes.Buffer{}))
+	second := destinationNames(t, db)
+
+	assert.Len(t, first, 10)
+	assert.Equal(t, first, second)
+}
+
+func TestSeed_WithoutWipeReportsClashingRows(t *testing.T) {
+	db := openSQLite(t)
+	args := []string{"-locations", "2", "-destinations", "1", "-users", "1"}
+
+	require.NoError(t, commands.Seed(db, args, &bytes.Buffer{}))
+	assert.Error(t, commands.Seed(db, args, &bytes.Buffer{}), "the same seed produces the same unique names")
+}
@@ -0,0 +1,84 @@
+package contract
+
+import (
+	"Trip-Trove-API/database"
+	"Trip-Trove-API/database/migrations"
+	"Trip-Trove-API/domain/repositories"
+	"Trip-Trove-API/infrastructure/dataaccess"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type backend struct {
+	name string
+	open func(t *testing.T) (repositories.Repositories, repositories.UnitOfWork)
+}
+
+// backends lists every storage implementation the contract runs against. Postgres needs
+// TEST_DATABASE_URL pointing at a disposable database; its tables are truncated per test.
+var backends = []backend{
+	{name: "memory", open: openMemory},
+	{name: "sqlite", open: openSQLite},
+	{name: "postgres", open: openPostgres},
+}
+
+func openMemory(t *testing.T) (repositories.Repositories, repositories.UnitOfWork) {
+	store := dataaccess.NewMemoryStore()
+	return dataaccess.NewMemoryRepositories(store), dataaccess.NewMemoryUnitOfWork(store)
+}
+
+func openSQLite(t *testing.T) (repositories.Repositories, repositories.UnitOfWork) {
+	db, err := database.OpenSQLite(filepath.Join(t.TempDir(), "contract.db"))
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := database.AutoMigrate(db); err != nil {
+		t.Fatalf("migrate sqlite: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return dataaccess.NewGormRepositories(db), dataaccess.NewGormUnitOfWork(db)
+}
+
+func openPostgres(t *testing.T) (repositories.Repositories, repositories.UnitOfWork) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	db, err := database.OpenPostgres(dsn)
+	if err != nil {
+		t.Fatalf("open postgres: %v", err)
+	}
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
+		t.Fatalf("load migrations: %v", err)
+	}
+	if _, err := migrator.Up(); err != nil {
+		t.Fatalf("migrate postgres: %v", err)
+	}
+	truncate := "TRUNCATE destinations, locations, users, role_changes, refresh_tokens, revoked_tokens, user_tokens, trips, trip_stops, event_log, webhooks, webhook_deliveries RESTART IDENTITY CASCADE"
+	if err := db.Exec(truncate).Error; err != nil {
+		t.Fatalf("truncate postgres: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return dataaccess.NewGormRepositories(db), dataaccess.NewGormUnitOfWork(db)
+}
+
+// forEachBackend runs the contract against a fresh, empty instance of every backend.
+func forEachBackend(t *testing.T, contract func(t *testing.T, repos repositories.Repositories, unitOfWork repositories.UnitOfWork)) {
+	for _, b := range backends {
+		b := b
+		t.Run(b.name, func(t *testing.T) {
+			repos, unitOfWork := b.open(t)
+			contract(t, repos, unitOfWork)
+		})
+	}
+}
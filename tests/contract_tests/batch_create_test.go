@@ -0,0 +1,49 @@
+package contract
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestBatchCreate_Contract(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repositories.Repositories, _ repositories.UnitOfWork) {
+		locations, err := repos.Locations.CreateLocations([]entities.Location{
+			{Name: "Lisbon", Country: "Portugal"},
+			{Name: "Porto", Country: "Portugal"},
+		})
+		require.NoError(t, err)
+		require.Len(t, locations, 2)
+		assert.NotZero(t, locations[0].ID)
+		assert.NotEqual(t, locations[0].ID, locations[1].ID)
+
+		destinations, err := repos.Destinations.CreateDestinations([]entities.Destination{
+			{Name: "Belem Tower", LocationID: locations[0].ID},
+			{Name: "Livraria Lello", LocationID: locations[1].ID},
+		})
+		require.NoError(t, err)
+		require.Len(t, destinations, 2)
+		assert.Equal(t, locations[1].ID, destinations[1].LocationID)
+
+		_, err = repos.Locations.CreateLocations([]entities.Location{{Name: "Faro", Country: "Portugal"}, {Name: "Lisbon", Country: "Portugal"}})
+		assert.Equal(t, apperrors.KindConflict, apperrors.KindOf(err))
+		all, err := repos.Locations.AllLocations()
+		require.NoError(t, err)
+		assert.Len(t, all, 2, "a failed batch inserts nothing")
+
+		users, err := repos.Users.CreateUsers([]entities.User{
+			{Username: "admin1", Password: "hashed", Email: "admin1@example.com", FirstName: "Ana", LastName: "Silva", PhoneNumber: "+40700000001", Role: entities.Admin},
+			{Username: "user1", Password: "hashed", Email: "user1@example.com", FirstName: "Rui", LastName: "Costa", PhoneNumber: "+40700000002"},
+		})
+		require.NoError(t, err)
+		require.Len(t, users, 2)
+
+		admin, err := repos.Users.UserByID(users[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, entities.Admin, admin.Role, "CreateUsers keeps the role")
+		assert.Equal(t, "hashed", admin.Password, "CreateUsers stores the password as given")
+	})
+}
@@ -0,0 +1,219 @@
+package contract
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func pointer[T any](value T) *T {
+	return &value
+}
+
+// seedDestinations creates two locations and three destinations, one of them private to ownerID.
+func seedDestinations(t *testing.T, repos repositories.Repositories, ownerID uint) (entities.Location, []entities.Destination) {
+	lisbon, err := repos.Locations.CreateLocation(entities.Location{Name: "Lisbon", Country: "Portugal"})
+	require.NoError(t, err)
+	rome, err := repos.Locations.CreateLocation(entities.Location{Name: "Rome", Country: "Italy"})
+	require.NoError(t, err)
+
+	seeds := []entities.Destination{
+		{Name: "Belem Tower", LocationID: lisbon.ID, Description: "Fortified tower on the Tagus river", VisitorsLastYear: 500,
+			Latitude: pointer(38.6916), Longitude: pointer(-9.2160)},
+		{Name: "Colosseum", LocationID: rome.ID, Description: "Ancient amphitheatre in the centre of the city", VisitorsLastYear: 7000,
+			Latitude: pointer(41.8902), Longitude: pointer(12.4922)},
+		{Name: "Hidden Garden", LocationID: lisbon.ID, Description: "A quiet garden near the river", VisitorsLastYear: 20,
+			IsPrivate: true, OwnerID: pointer(ownerID), Latitude: pointer(38.7000), Longitude: pointer(-9.2000)},
+	}
+
+	destinations := make([]entities.Destination, 0, len(seeds))
+	for _, seed := range seeds {
+		destination, err := repos.Destinations.CreateDestination(seed)
+		require.NoError(t, err)
+		destinations = append(destinations, destination)
+	}
+	return lisbon, destinations
+}
+
+func names(destinations []entities.Destination) []string {
+	result := make([]string, 0, len(destinations))
+	for _, destination := range destinations {
+		result = append(result, destination.Name)
+	}
+	return result
+}
+
+func TestDestinationRepository_CrudContract(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repositories.Repositories, _ repositories.UnitOfWork) {
+		lisbon, destinations := seedDestinations(t, repos, 7)
+		belem := destinations[0]
+
+		found, err := repos.Destinations.DestinationByID(belem.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Belem Tower", found.Name)
+		assert.Equal(t, lisbon.ID, found.LocationID)
+
+		byName, err := repos.Destinations.DestinationByName("Belem Tower")
+		require.NoError(t, err)
+		assert.Equal(t, belem.ID, byName.ID)
+		_, err = repos.Destinations.DestinationByName("Belem")
+		assert.Equal(t, apperrors.KindNotFound, apperrors.KindOf(err))
+
+		_, err = repos.Destinations.CreateDestination(entities.Destination{Name: "Belem Tower", LocationID: lisbon.ID})
+		assert.Equal(t, apperrors.KindConflict, apperrors.KindOf(err))
+
+		replacement := belem
+		replacement.VisitorsLastYear = 900
+		updated, err := repos.Destinations.UpdateDestination(belem.ID, replacement)
+		require.NoError(t, err)
+		assert.Equal(t, "Belem Tower", updated.Name)
+		assert.Equal(t, 900, updated.VisitorsLastYear)
+
+		garden := destinations[2]
+		published, err := repos.Destinations.UpdateDestination(garden.ID, entities.Destination{Name: garden.Name, LocationID: garden.LocationID})
+		require.NoError(t, err)
+		stored, err := repos.Destinations.DestinationByID(garden.ID)
+		require.NoError(t, err)
+		for _, destination := range []entities.Destination{published, *stored} {
+			assert.False(t, destination.IsPrivate, "a private destination can be made public")
+			assert.Zero(t, destination.VisitorsLastYear)
+			assert.Empty(t, destination.Description)
+			assert.Nil(t, destination.Latitude)
+			require.NotNil(t, destination.OwnerID, "the owner is kept")
+			assert.Equal(t, uint(7), *destination.OwnerID)
+			assert.True(t, destination.UpdatedAt.After(garden.UpdatedAt))
+		}
+
+		ids, err := repos.Destinations.DestinationIDsForLocation(lisbon.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []uint{destinations[0].ID, destinations[2].ID}, ids)
+		inLisbon, err := repos.Destinations.DestinationsForLocation(lisbon.ID)
+		require.NoError(t, err)
+		require.Len(t, inLisbon, 2)
+		assert.Equal(t, destinations[2].ID, inLisbon[1].ID)
+		assert.Equal(t, destinations[2].Name, inLisbon[1].Name)
+
+		_, err = repos.Destinations.DeleteDestination(belem.ID)
+		require.NoError(t, err)
+		_, err = repos.Destinations.DestinationByID(belem.ID)
+		assert.Equal(t, apperrors.KindNotFound, apperrors.KindOf(err))
+		_, err = repos.Destinations.UpdateDestination(belem.ID, entities.Destination{Name: "Gone"})
+		assert.Equal(t, apperrors.KindNotFound, apperrors.KindOf(err))
+
+		require.NoError(t, repos.Destinations.DeleteDestinationsByLocationID(lisbon.ID))
+		all, err := repos.Destinations.AllDestinations()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Colosseum"}, names(all))
+	})
+}
+
+func TestDestinationRepository_QueryContract(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repositories.Repositories, _ repositories.UnitOfWork) {
+		lisbon, _ := seedDestinations(t, repos, 7)
+
+		public, total, err := repos.Destinations.QueryDestinations(entities.DestinationQuery{
+			Page: 1, Limit: 10, SortBy: entities.SortByName,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		assert.Equal(t, []string{"Belem Tower", "Colosseum"}, names(public))
+
+		owned, total, err := repos.Destinations.QueryDestinations(entities.DestinationQuery{
+			Page: 1, Limit: 10, SortBy: entities.SortByVisitorsLastYear, SortDesc: true,
+			Filter: entities.DestinationFilter{Visibility: entities.DestinationVisibility{OwnerID: pointer(uint(7))}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		assert.Equal(t, []string{"Colosseum", "Belem Tower", "Hidden Garden"}, names(owned))
+
+		page, total, err := repos.Destinations.QueryDestinations(entities.DestinationQuery{
+			Page: 2, Limit: 1, SortBy: entities.SortByName,
+			Filter: entities.DestinationFilter{Visibility: entities.DestinationVisibility{IncludePrivate: true}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		assert.Equal(t, []string{"Colosseum"}, names(page))
+
+		filtered, total, err := repos.Destinations.QueryDestinations(entities.DestinationQuery{
+			Page: 1, Limit: 10, SortBy: entities.SortByName,
+			Filter: entities.DestinationFilter{
+				LocationID:  pointer(lisbon.ID),
+				MinVisitors: pointer(100),
+				Visibility:  entities.DestinationVisibility{IncludePrivate: true},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, []string{"Belem Tower"}, names(filtered))
+
+		byCountry, _, err := repos.Destinations.QueryDestinations(entities.DestinationQuery{
+			Page: 1, Limit: 10, SortBy: entities.SortByName,
+			Filter: entities.DestinationFilter{Country: "italy"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Colosseum"}, names(byCountry))
+	})
+}
+
+func TestDestinationRepository_SearchContract(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repositories.Repositories, _ repositories.UnitOfWork) {
+		seedDestinations(t, repos, 7)
+
+		results, err := repos.Destinations.SearchDestinations("river", 10, entities.DestinationVisibility{})
+		require.NoError(t, err)
+		require.Len(t, results, 1, "private destinations stay hidden")
+		assert.Equal(t, "Belem Tower", results[0].Destination.Name)
+		assert.Equal(t, "Lisbon", results[0].Location)
+		assert.Equal(t, "Portugal", results[0].Country)
+
+		results, err = repos.Destinations.SearchDestinations("river", 10, entities.DestinationVisibility{OwnerID: pointer(uint(7))})
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+
+		results, err = repos.Destinations.SearchDestinations("colosseum", 10, entities.DestinationVisibility{})
+		require.NoError(t, err)
+		require.NotEmpty(t, results)
+		assert.Equal(t, "Colosseum", results[0].Destination.Name, "name matches rank first")
+
+		results, err = repos.Destinations.SearchDestinations("tower lisb", 10, entities.DestinationVisibility{})
+		require.NoError(t, err)
+		require.Len(t, results, 1, "the words may match the destination and its location")
+		assert.Equal(t, "Belem Tower", results[0].Destination.Name)
+
+		_, err = repos.Destinations.CreateDestination(entities.Destination{
+			Name: "Tram 28", LocationID: results[0].Destination.LocationID,
+			Description: `A yellow tram & its <img src=x onerror="alert(1)"> ride`,
+		})
+		require.NoError(t, err)
+		results, err = repos.Destinations.SearchDestinations("yellow", 10, entities.DestinationVisibility{})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.NotContains(t, results[0].Snippet, "<img", "descriptions are escaped before matches are marked")
+		assert.Contains(t, results[0].Snippet, `A <mark>yellow</mark> tram &amp; its &lt;img src=x onerror=&#34;alert(1)&#34;&gt; ride`)
+
+		results, err = repos.Destinations.SearchDestinations("   ", 10, entities.DestinationVisibility{})
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
+}
+
+func TestDestinationRepository_NearbyContract(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repositories.Repositories, _ repositories.UnitOfWork) {
+		seedDestinations(t, repos, 7)
+
+		nearby, err := repos.Destinations.DestinationsNearby(38.7, -9.2, 25, 10, entities.DestinationVisibility{IncludePrivate: true})
+		require.NoError(t, err)
+		require.Len(t, nearby, 2)
+		assert.Equal(t, "Hidden Garden", nearby[0].Destination.Name, "closest first")
+		assert.Equal(t, "Belem Tower", nearby[1].Destination.Name)
+		assert.Less(t, nearby[0].DistanceKm, nearby[1].DistanceKm)
+
+		nearby, err = repos.Destinations.DestinationsNearby(38.7, -9.2, 25, 10, entities.DestinationVisibility{})
+		require.NoError(t, err)
+		require.Len(t, nearby, 1)
+		assert.Equal(t, "Belem Tower", nearby[0].Destination.Name)
+	})
+}
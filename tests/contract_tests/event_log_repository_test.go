@@ -0,0 +1,53 @@
+package contract
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestEventLogRepository_Contract(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repositories.Repositories, _ repositories.UnitOfWork) {
+		oldest, latest, err := repos.Events.SequenceRange()
+		require.NoError(t, err)
+		assert.Zero(t, oldest)
+		assert.Zero(t, latest)
+
+		var sequences []uint64
+		for i := uint(1); i <= 5; i++ {
+			record, err := repos.Events.AppendEvent(entities.EventRecord{
+				Type:       "DestinationCreated",
+				Entity:     "destination",
+				EntityID:   i,
+				Payload:    `{"Name":"Belem Tower"}`,
+				OccurredAt: time.Now().UTC(),
+			})
+			require.NoError(t, err)
+			sequences = append(sequences, record.Sequence)
+		}
+		for i := 1; i < len(sequences); i++ {
+			assert.Greater(t, sequences[i], sequences[i-1], "sequences only grow")
+		}
+
+		records, err := repos.Events.EventsSince(sequences[1], 2)
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, sequences[2], records[0].Sequence)
+		assert.Equal(t, sequences[3], records[1].Sequence)
+		assert.Equal(t, uint(3), records[0].EntityID)
+		assert.Equal(t, `{"Name":"Belem Tower"}`, records[0].Payload)
+
+		require.NoError(t, repos.Events.TrimEvents(sequences[3]))
+		oldest, latest, err = repos.Events.SequenceRange()
+		require.NoError(t, err)
+		assert.Equal(t, sequences[3], oldest)
+		assert.Equal(t, sequences[4], latest)
+
+		record, err := repos.Events.AppendEvent(entities.EventRecord{Type: "LocationDeleted", Entity: "location", Payload: "{}", OccurredAt: time.Now().UTC()})
+		require.NoError(t, err)
+		assert.Greater(t, record.Sequence, sequences[4], "trimming never reuses sequences")
+	})
+}
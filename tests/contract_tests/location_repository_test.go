@@ -0,0 +1,55 @@
+package contract
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestLocationRepository_Contract(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repositories.Repositories, _ repositories.UnitOfWork) {
+		lisbon, err := repos.Locations.CreateLocation(entities.Location{Name: "Lisbon", Country: "Portugal", Description: "Hills and tiles"})
+		require.NoError(t, err)
+		assert.NotZero(t, lisbon.ID)
+
+		porto, err := repos.Locations.CreateLocation(entities.Location{Name: "Porto", Country: "Portugal"})
+		require.NoError(t, err)
+
+		found, err := repos.Locations.LocationByID(lisbon.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Lisbon", found.Name)
+
+		byName, err := repos.Locations.LocationByName("Porto")
+		require.NoError(t, err)
+		assert.Equal(t, porto.ID, byName.ID)
+		_, err = repos.Locations.LocationByName("porto")
+		assert.Equal(t, apperrors.KindNotFound, apperrors.KindOf(err), "names match exactly")
+
+		_, err = repos.Locations.CreateLocation(entities.Location{Name: "Lisbon", Country: "Portugal"})
+		assert.Equal(t, apperrors.KindConflict, apperrors.KindOf(err))
+
+		ids, err := repos.Locations.AllLocationIDs()
+		require.NoError(t, err)
+		assert.Equal(t, []uint{lisbon.ID, porto.ID}, ids)
+
+		updated, err := repos.Locations.UpdateLocation(porto.ID, entities.Location{Description: "Port wine"})
+		require.NoError(t, err)
+		assert.Equal(t, "Porto", updated.Name, "zero fields are left untouched")
+		assert.Equal(t, "Port wine", updated.Description)
+
+		results, err := repos.Locations.SearchLocations("lisbon", 10)
+		require.NoError(t, err)
+		require.NotEmpty(t, results)
+		assert.Equal(t, lisbon.ID, results[0].Location.ID)
+
+		_, err = repos.Locations.DeleteLocation(lisbon.ID)
+		require.NoError(t, err)
+		_, err = repos.Locations.LocationByID(lisbon.ID)
+		assert.Equal(t, apperrors.KindNotFound, apperrors.KindOf(err))
+		_, err = repos.Locations.DeleteLocation(lisbon.ID)
+		assert.Equal(t, apperrors.KindNotFound, apperrors.KindOf(err))
+	})
+}
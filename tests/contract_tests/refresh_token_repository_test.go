@@ -0,0 +1,51 @@
+package contract
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRefreshTokenRepository_OnlyOneRevokeClaimsAToken(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repositories.Repositories, _ repositories.UnitOfWork) {
+		token, err := repos.Tokens.CreateRefreshToken(entities.RefreshToken{
+			UserID:          1,
+			TokenHash:       "refresh-hash",
+			FamilyID:        "family",
+			AccessTokenID:   "access",
+			AccessExpiresAt: time.Now().Add(time.Minute),
+			ExpiresAt:       time.Now().Add(time.Hour),
+		})
+		require.NoError(t, err)
+
+		var wg sync.WaitGroup
+		claims := make(chan bool, 2)
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				claimed, err := repos.Tokens.RevokeRefreshToken(token.ID)
+				assert.NoError(t, err)
+				claims <- claimed
+			}()
+		}
+		wg.Wait()
+		close(claims)
+
+		claimedCount := 0
+		for claimed := range claims {
+			if claimed {
+				claimedCount++
+			}
+		}
+		assert.Equal(t, 1, claimedCount)
+
+		stored, err := repos.Tokens.RefreshTokenByHash("refresh-hash")
+		require.NoError(t, err)
+		assert.NotNil(t, stored.RevokedAt)
+	})
+}
@@ -0,0 +1,57 @@
+package contract
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestUserRepository_RoleContract(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repositories.Repositories, _ repositories.UnitOfWork) {
+		user, err := repos.Users.Register(newUser("ana", "ana@example.com", "+40700000001"))
+		require.NoError(t, err)
+
+		admins, err := repos.Users.CountUsersByRole(entities.Admin)
+		require.NoError(t, err)
+		assert.Zero(t, admins)
+
+		_, err = repos.Users.UpdateUser(user.ID, entities.User{Address: "Lisbon", Role: entities.Admin})
+		require.NoError(t, err)
+		stored, err := repos.Users.UserByID(user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entities.NormalUser, stored.Role, "UpdateUser never changes the role")
+
+		require.NoError(t, repos.Users.UpdateRole(user.ID, entities.Admin))
+		stored, err = repos.Users.UserByID(user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entities.Admin, stored.Role)
+		admins, err = repos.Users.CountUsersByRole(entities.Admin)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), admins)
+		assert.Equal(t, apperrors.KindNotFound, apperrors.KindOf(repos.Users.UpdateRole(user.ID+100, entities.Admin)))
+
+		changedBy := user.ID
+		first, err := repos.Users.CreateRoleChange(entities.RoleChange{UserID: user.ID, OldRole: entities.NormalUser, NewRole: entities.Admin, Reason: "bootstrap"})
+		require.NoError(t, err)
+		assert.NotZero(t, first.ID)
+		assert.False(t, first.CreatedAt.IsZero())
+		_, err = repos.Users.CreateRoleChange(entities.RoleChange{UserID: user.ID, ChangedByID: &changedBy, OldRole: entities.Admin, NewRole: entities.Manager})
+		require.NoError(t, err)
+
+		changes, err := repos.Users.RoleChanges(user.ID)
+		require.NoError(t, err)
+		require.Len(t, changes, 2)
+		assert.Equal(t, first.ID, changes[0].ID)
+		assert.Nil(t, changes[0].ChangedByID)
+		require.NotNil(t, changes[1].ChangedByID)
+		assert.Equal(t, user.ID, *changes[1].ChangedByID)
+		assert.Equal(t, entities.Manager, changes[1].NewRole)
+
+		changes, err = repos.Users.RoleChanges(user.ID + 100)
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+}
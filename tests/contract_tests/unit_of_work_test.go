@@ -0,0 +1,42 @@
+package contract
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/repositories"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestUnitOfWork_Contract(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repositories.Repositories, unitOfWork repositories.UnitOfWork) {
+		failure := errors.New("abort")
+		err := unitOfWork.Do(func(tx repositories.Repositories) error {
+			if _, err := tx.Locations.CreateLocation(entities.Location{Name: "Oslo", Country: "Norway"}); err != nil {
+				return err
+			}
+			return failure
+		})
+		assert.ErrorIs(t, err, failure)
+
+		locations, err := repos.Locations.AllLocations()
+		require.NoError(t, err)
+		assert.Empty(t, locations, "a failed unit leaves nothing behind")
+
+		var created entities.Location
+		err = unitOfWork.Do(func(tx repositories.Repositories) error {
+			created, err = tx.Locations.CreateLocation(entities.Location{Name: "Oslo", Country: "Norway"})
+			return err
+		})
+		require.NoError(t, err)
+
+		found, err := repos.Locations.LocationByID(created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Oslo", found.Name)
+
+		_, err = repos.Locations.LocationByID(created.ID + 100)
+		assert.Equal(t, apperrors.KindNotFound, apperrors.KindOf(err))
+	})
+}
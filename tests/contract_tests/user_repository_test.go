@@ -0,0 +1,69 @@
+package contract
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func newUser(username string, email string, phone string) entities.User {
+	return entities.User{
+		Username:    username,
+		Password:    "s3cret-password",
+		Email:       email,
+		FirstName:   "Ana",
+		LastName:    "Pop",
+		PhoneNumber: phone,
+	}
+}
+
+func TestUserRepository_Contract(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repositories.Repositories, _ repositories.UnitOfWork) {
+		user := newUser("ana", "ana@example.com", "+40700000001")
+		user.Role = entities.Admin
+
+		registered, err := repos.Users.Register(user)
+		require.NoError(t, err)
+		assert.NotZero(t, registered.ID)
+		assert.Equal(t, entities.NormalUser, registered.Role, "registration never grants a role")
+		assert.NotEqual(t, "s3cret-password", registered.Password, "passwords are stored hashed")
+
+		authenticated, err := repos.Users.Authenticate(entities.LoginRequest{Email: "ana@example.com", Password: "s3cret-password"})
+		require.NoError(t, err)
+		assert.Equal(t, registered.ID, authenticated.ID)
+
+		_, err = repos.Users.Authenticate(entities.LoginRequest{Email: "ana@example.com", Password: "wrong"})
+		assert.Equal(t, apperrors.KindUnauthorized, apperrors.KindOf(err))
+		_, err = repos.Users.Authenticate(entities.LoginRequest{Email: "nobody@example.com", Password: "s3cret-password"})
+		assert.Equal(t, apperrors.KindUnauthorized, apperrors.KindOf(err))
+
+		_, err = repos.Users.Register(newUser("ana", "other@example.com", "+40700000002"))
+		assert.Equal(t, apperrors.KindConflict, apperrors.KindOf(err))
+		_, err = repos.Users.Register(newUser("other", "ana@example.com", "+40700000003"))
+		assert.Equal(t, apperrors.KindConflict, apperrors.KindOf(err))
+
+		updated, err := repos.Users.UpdateUser(registered.ID, entities.User{Address: "Lisbon", Password: "ignored"})
+		require.NoError(t, err)
+		assert.Equal(t, "Lisbon", updated.Address)
+		_, err = repos.Users.Authenticate(entities.LoginRequest{Email: "ana@example.com", Password: "s3cret-password"})
+		assert.NoError(t, err, "UpdateUser never changes the password")
+
+		require.NoError(t, repos.Users.UpdatePassword(registered.ID, "new-hash"))
+		stored, err := repos.Users.UserByID(registered.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "new-hash", stored.Password)
+		assert.Equal(t, apperrors.KindNotFound, apperrors.KindOf(repos.Users.UpdatePassword(registered.ID+100, "new-hash")))
+
+		_, err = repos.Users.UserByID(registered.ID + 100)
+		assert.Equal(t, apperrors.KindNotFound, apperrors.KindOf(err))
+
+		_, err = repos.Users.DeleteUser(registered.ID)
+		require.NoError(t, err)
+		ids, err := repos.Users.AllUserIDs()
+		require.NoError(t, err)
+		assert.Empty(t, ids)
+	})
+}
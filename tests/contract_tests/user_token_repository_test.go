@@ -0,0 +1,77 @@
+package contract
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestUserTokenRepository_Contract(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repositories.Repositories, _ repositories.UnitOfWork) {
+		user, err := repos.Users.Register(newUser("ana", "ana@example.com", "+40700000001"))
+		require.NoError(t, err)
+		assert.False(t, user.EmailVerified())
+
+		found, err := repos.Users.UserByEmail("ana@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, found.ID)
+		_, err = repos.Users.UserByEmail("nobody@example.com")
+		assert.Equal(t, apperrors.KindNotFound, apperrors.KindOf(err))
+
+		verifiedAt := time.Now().UTC().Truncate(time.Second)
+		require.NoError(t, repos.Users.SetEmailVerifiedAt(user.ID, &verifiedAt))
+		stored, err := repos.Users.UserByID(user.ID)
+		require.NoError(t, err)
+		require.True(t, stored.EmailVerified())
+		assert.True(t, verifiedAt.Equal(*stored.EmailVerifiedAt))
+		require.NoError(t, repos.Users.SetEmailVerifiedAt(user.ID, nil))
+		stored, err = repos.Users.UserByID(user.ID)
+		require.NoError(t, err)
+		assert.False(t, stored.EmailVerified())
+		assert.Equal(t, apperrors.KindNotFound, apperrors.KindOf(repos.Users.SetEmailVerifiedAt(user.ID+100, nil)))
+
+		token, err := repos.Tokens.CreateUserToken(entities.UserToken{
+			UserID:    user.ID,
+			Purpose:   entities.PasswordResetToken,
+			TokenHash: "reset-hash",
+			Email:     user.Email,
+			ExpiresAt: time.Now().Add(time.Hour),
+		})
+		require.NoError(t, err)
+		assert.NotZero(t, token.ID)
+
+		byHash, err := repos.Tokens.UserTokenByHash("reset-hash")
+		require.NoError(t, err)
+		assert.Equal(t, token.ID, byHash.ID)
+		assert.True(t, byHash.IsUsable(entities.PasswordResetToken, time.Now()))
+		assert.False(t, byHash.IsUsable(entities.EmailVerificationToken, time.Now()))
+
+		used, err := repos.Tokens.UseUserToken(token.ID)
+		require.NoError(t, err)
+		assert.True(t, used)
+		used, err = repos.Tokens.UseUserToken(token.ID)
+		require.NoError(t, err)
+		assert.False(t, used, "a token can only be used once")
+		byHash, err = repos.Tokens.UserTokenByHash("reset-hash")
+		require.NoError(t, err)
+		assert.NotNil(t, byHash.UsedAt)
+
+		_, err = repos.Tokens.CreateUserToken(entities.UserToken{
+			UserID:    user.ID,
+			Purpose:   entities.EmailVerificationToken,
+			TokenHash: "verify-hash",
+			Email:     user.Email,
+			ExpiresAt: time.Now().Add(time.Hour),
+		})
+		require.NoError(t, err)
+		require.NoError(t, repos.Tokens.DeleteUserTokens(user.ID, entities.PasswordResetToken))
+		_, err = repos.Tokens.UserTokenByHash("reset-hash")
+		assert.Equal(t, apperrors.KindNotFound, apperrors.KindOf(err))
+		_, err = repos.Tokens.UserTokenByHash("verify-hash")
+		assert.NoError(t, err, "only tokens of the given purpose are deleted")
+	})
+}
@@ -0,0 +1,114 @@
+package contract
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestWebhookRepository_Contract(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repositories.Repositories, _ repositories.UnitOfWork) {
+		created, err := repos.Webhooks.CreateWebhook(entities.Webhook{
+			URL:        "https://partner.example/hooks",
+			Secret:     "first-secret-value",
+			EventTypes: entities.WebhookEventTypes{"DestinationCreated", "LocationDeleted"},
+			Active:     true,
+		})
+		require.NoError(t, err)
+		_, err = repos.Webhooks.CreateWebhook(entities.Webhook{URL: "https://other.example", Secret: "second-secret-value", EventTypes: entities.WebhookEventTypes{"LocationCreated"}})
+		require.NoError(t, err)
+
+		found, err := repos.Webhooks.WebhookByID(created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entities.WebhookEventTypes{"DestinationCreated", "LocationDeleted"}, found.EventTypes)
+		assert.True(t, found.Subscribes("LocationDeleted"))
+
+		active, err := repos.Webhooks.ActiveWebhooks()
+		require.NoError(t, err)
+		require.Len(t, active, 1)
+		assert.Equal(t, created.ID, active[0].ID)
+
+		updated, err := repos.Webhooks.UpdateWebhook(created.ID, entities.Webhook{
+			URL:        "https://partner.example/v2",
+			EventTypes: entities.WebhookEventTypes{"DestinationUpdated"},
+			Active:     false,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "https://partner.example/v2", updated.URL)
+		assert.Equal(t, "first-secret-value", updated.Secret, "an empty secret keeps the current one")
+		assert.False(t, updated.Active)
+
+		_, err = repos.Webhooks.DeleteWebhook(created.ID)
+		require.NoError(t, err)
+		_, err = repos.Webhooks.WebhookByID(created.ID)
+		assert.Equal(t, apperrors.KindNotFound, apperrors.KindOf(err))
+	})
+}
+
+func TestWebhookRepository_DeliveryLogContract(t *testing.T) {
+	forEachBackend(t, func(t *testing.T, repos repositories.Repositories, _ repositories.UnitOfWork) {
+		now := time.Now().UTC().Truncate(time.Millisecond)
+
+		var deliveries []entities.WebhookDelivery
+		for i := 0; i < 3; i++ {
+			delivery, err := repos.Webhooks.CreateDelivery(entities.WebhookDelivery{
+				WebhookID:     1,
+				EventSequence: uint64(i + 1),
+				EventType:     "DestinationCreated",
+				Payload:       `{"seq":1}`,
+				Status:        entities.DeliveryPending,
+				NextAttemptAt: now.Add(time.Duration(i-1) * time.Minute),
+			})
+			require.NoError(t, err)
+			deliveries = append(deliveries, delivery)
+		}
+
+		due, err := repos.Webhooks.DueDeliveries(now, 10)
+		require.NoError(t, err)
+		require.Len(t, due, 2, "the third delivery is not due yet")
+		assert.Equal(t, deliveries[0].ID, due[0].ID)
+
+		claimed, err := repos.Webhooks.ClaimDelivery(deliveries[0].ID, now, now.Add(time.Minute))
+		require.NoError(t, err)
+		assert.True(t, claimed)
+		claimed, err = repos.Webhooks.ClaimDelivery(deliveries[0].ID, now, now.Add(time.Minute))
+		require.NoError(t, err)
+		assert.False(t, claimed, "a claimed delivery is no longer due")
+
+		deliveredAt := now
+		attempt := deliveries[0]
+		attempt.Status = entities.DeliverySucceeded
+		attempt.Attempts = 2
+		attempt.ResponseStatus = 204
+		attempt.DeliveredAt = &deliveredAt
+		require.NoError(t, repos.Webhooks.SaveAttempt(attempt))
+
+		saved, err := repos.Webhooks.DeliveryByID(deliveries[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, entities.DeliverySucceeded, saved.Status)
+		assert.Equal(t, 2, saved.Attempts)
+		assert.Equal(t, 204, saved.ResponseStatus)
+		require.NotNil(t, saved.DeliveredAt)
+		assert.True(t, deliveredAt.Equal(*saved.DeliveredAt))
+
+		page, total, err := repos.Webhooks.DeliveriesForWebhook(1, entities.WebhookDeliveryQuery{Page: 1, Limit: 2})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total)
+		require.Len(t, page, 2)
+		assert.Equal(t, deliveries[2].ID, page[0].ID, "newest first")
+
+		pending, total, err := repos.Webhooks.DeliveriesForWebhook(1, entities.WebhookDeliveryQuery{Page: 1, Limit: 10, Status: entities.DeliveryPending})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		assert.Len(t, pending, 2)
+
+		other, total, err := repos.Webhooks.DeliveriesForWebhook(2, entities.WebhookDeliveryQuery{Page: 1, Limit: 10})
+		require.NoError(t, err)
+		assert.Zero(t, total)
+		assert.Empty(t, other)
+	})
+}
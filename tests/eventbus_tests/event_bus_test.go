@@ -0,0 +1,142 @@
+package eventbus
+
+import (
+	"Trip-Trove-API/database"
+	"Trip-Trove-API/database/migrations"
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/events"
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/infrastructure/dataaccess"
+	"Trip-Trove-API/infrastructure/eventbus"
+	ws "Trip-Trove-API/infrastructure/websocket"
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// instance is one API replica: its own manager, bus and event stream.
+type instance struct {
+	manager *ws.WebSocketManager
+	client  *ws.Client
+	stream  *services.EventStream
+}
+
+func newInstance(t *testing.T, bus events.Bus, db *gorm.DB) instance {
+	manager := ws.NewWebSocketManager()
+	go manager.BroadcastWebSocketMessage()
+	t.Cleanup(manager.Close)
+	bus.Subscribe(manager)
+
+	subscription := ws.Subscription{Topics: []ws.Topic{ws.TopicAllDestinations}}
+	client := manager.AddStreamClient(entities.Anonymous, time.Time{}, subscription)
+
+	var stream services.EventStream
+	if db != nil {
+		stream = services.EventStream{Log: dataaccess.NewGormEventLogRepository(db), Subscribers: []events.Publisher{bus}}
+	} else {
+		stream = services.EventStream{Log: dataaccess.NewMemoryEventLogRepository(dataaccess.NewMemoryStore()), Subscribers: []events.Publisher{bus}}
+	}
+
+	t.Cleanup(stream.Close)
+
+	return instance{manager: manager, client: client, stream: &stream}
+}
+
+func destinationEvent(id uint, description string) events.Event {
+	return events.NewDestinationEvent(events.DestinationCreated, entities.Destination{
+		Model:       gorm.Model{ID: id},
+		Name:        fmt.Sprintf("Destination %d", id),
+		Description: description,
+	}, entities.Anonymous)
+}
+
+func receive(t *testing.T, client *ws.Client) ws.Outbound {
+	select {
+	case message := <-client.Messages():
+		return message
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event received")
+		return ws.Outbound{}
+	}
+}
+
+func TestLocalBus_DeliversToEverySubscriber(t *testing.T) {
+	bus := events.NewLocalBus()
+	first := newInstance(t, bus, nil)
+	second := newInstance(t, bus, nil)
+
+	first.stream.Publish(destinationEvent(1, "Lakeside"))
+
+	for _, client := range []*ws.Client{first.client, second.client} {
+		message := receive(t, client)
+		assert.Equal(t, string(events.DestinationCreated), message.Name)
+		assert.Equal(t, uint64(1), message.Sequence)
+	}
+}
+
+// openPostgres connects one replica to the shared test database.
+func openPostgres(t *testing.T, dsn string) *gorm.DB {
+	db, err := database.OpenPostgres(dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+func startPostgresBus(t *testing.T, db *gorm.DB, dsn string, channel string) *eventbus.PostgresBus {
+	bus := eventbus.NewPostgresBus(db, dsn, dataaccess.NewGormEventLogRepository(db))
+	bus.Channel = channel
+	require.NoError(t, bus.Start(context.Background()))
+	t.Cleanup(func() { bus.Close() })
+	return bus
+}
+
+func TestPostgresBus_FansOutAcrossInstances(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	setup := openPostgres(t, dsn)
+	migrator, err := migrations.NewMigrator(setup)
+	require.NoError(t, err)
+	_, err = migrator.Up()
+	require.NoError(t, err)
+	require.NoError(t, setup.Exec("TRUNCATE event_log RESTART IDENTITY").Error)
+
+	// A channel per run keeps parallel test runs against the same database apart.
+	channel := fmt.Sprintf("test_events_%d", time.Now().UnixNano())
+
+	firstDb, secondDb := openPostgres(t, dsn), openPostgres(t, dsn)
+	first := newInstance(t, startPostgresBus(t, firstDb, dsn, channel), firstDb)
+	second := newInstance(t, startPostgresBus(t, secondDb, dsn, channel), secondDb)
+
+	t.Run("small events travel in the notification", func(t *testing.T) {
+		first.stream.Publish(destinationEvent(1, "Lakeside"))
+
+		for _, client := range []*ws.Client{first.client, second.client} {
+			message := receive(t, client)
+			assert.Equal(t, string(events.DestinationCreated), message.Name)
+			assert.Equal(t, uint64(1), message.Sequence)
+		}
+	})
+
+	t.Run("large events are read back from the event log", func(t *testing.T) {
+		second.stream.Publish(destinationEvent(2, strings.Repeat("a", 10000)))
+
+		for _, client := range []*ws.Client{first.client, second.client} {
+			message := receive(t, client)
+			assert.Equal(t, uint64(2), message.Sequence)
+			assert.Contains(t, string(message.Payload), strings.Repeat("a", 10000))
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/infrastructure/dataaccess"
+	"Trip-Trove-API/infrastructure/middlewares"
+	"Trip-Trove-API/presentation/handlers"
+	"Trip-Trove-API/routes"
+	"Trip-Trove-API/tests/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+)
+
+func TestPasswordReset_OverHTTP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repos := dataaccess.NewMemoryRepositories(dataaccess.NewMemoryStore())
+	mailer := &mocks.RecordingMailer{}
+	router := gin.New()
+	router.Use(middlewares.ErrorMiddleware())
+	userService := &services.UserService{Repo: repos.Users, TokenRepo: repos.Tokens, Mailer: mailer}
+	routes.RegisterUserRoutes(router, &handlers.UserHandler{Service: userService}, mocks.MockAuthMiddleware{Role: entities.NormalUser, UserID: 1, Unverified: true})
+	require.Equal(t, http.StatusCreated, serve(router, "POST", "/users/register", registerBody).Code)
+
+	w := serve(router, "GET", "/users/me", "")
+	assert.Contains(t, w.Body.String(), `"email_verified":false`)
+
+	unknown := serve(router, "POST", "/users/password/forgot", `{"email": "nobody@example.com"}`)
+	known := serve(router, "POST", "/users/password/forgot", `{"email": "traveller@example.com"}`)
+	assert.Equal(t, http.StatusAccepted, unknown.Code)
+	assert.Equal(t, unknown.Body.String(), known.Body.String(), "the answer does not reveal who has an account")
+	token := mailer.LastToken()
+
+	w = serve(router, "POST", "/users/password/reset", `{"token": "`+token+`", "new_password": "weak"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = serve(router, "POST", "/users/password/reset", `{"token": "`+token+`", "new_password": "Brand-new-pass-2!"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	w = serve(router, "POST", "/users/password/reset", `{"token": "`+token+`", "new_password": "Brand-new-pass-2!"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code, "the link works once")
+
+	w = serve(router, "GET", "/users/me", "")
+	assert.Contains(t, w.Body.String(), `"email_verified":true`)
+	assert.Equal(t, http.StatusConflict, serve(router, "POST", "/users/me/verify-email", "").Code)
+}
+
+func TestVerifyEmail_OverHTTP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repos := dataaccess.NewMemoryRepositories(dataaccess.NewMemoryStore())
+	mailer := &mocks.RecordingMailer{}
+	router := gin.New()
+	router.Use(middlewares.ErrorMiddleware())
+	userService := &services.UserService{Repo: repos.Users, TokenRepo: repos.Tokens, Mailer: mailer}
+	routes.RegisterUserRoutes(router, &handlers.UserHandler{Service: userService}, mocks.MockAuthMiddleware{Role: entities.NormalUser, UserID: 1, Unverified: true})
+	require.Equal(t, http.StatusCreated, serve(router, "POST", "/users/register", registerBody).Code)
+
+	assert.Equal(t, http.StatusAccepted, serve(router, "POST", "/users/me/verify-email", "").Code)
+	require.Len(t, mailer.Messages, 2)
+
+	assert.Equal(t, http.StatusBadRequest, serve(router, "POST", "/users/verify-email", `{"token": "made-up"}`).Code)
+	w := serve(router, "POST", "/users/verify-email", `{"token": "`+mailer.LastToken()+`"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+}
+
+func TestCreateTrip_NeedsAVerifiedEmail(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repos := dataaccess.NewMemoryRepositories(dataaccess.NewMemoryStore())
+	router := gin.New()
+	router.Use(middlewares.ErrorMiddleware())
+	tripService := &services.TripService{Repo: repos.Trips, DestinationRepo: repos.Destinations, LocationRepo: repos.Locations}
+	routes.RegisterTripRoutes(router, &handlers.TripHandler{Service: tripService}, mocks.MockAuthMiddleware{Role: entities.NormalUser, UserID: 3, Unverified: true})
+
+	w := serve(router, "POST", "/trips/", `{"name": "Arctic circle", "start_date": "2024-12-20", "end_date": "2024-12-22"}`)
+	assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), "verify your email address")
+}
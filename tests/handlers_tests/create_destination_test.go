@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/infrastructure/middlewares"
 	"Trip-Trove-API/presentation/handlers"
 	"Trip-Trove-API/routes"
 	"Trip-Trove-API/tests/mocks"
@@ -19,6 +20,7 @@ import (
 func TestCreateDestination_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
 
 	_ = &mocks.MockLocationService{
 		LocationByIDFunc: func(idStr string) (*entities.Location, error) {
@@ -32,14 +34,14 @@ func TestCreateDestination_Success(t *testing.T) {
 	}
 
 	mockService := &mocks.MockDestinationService{
-		CreateDestinationFunc: func(destination entities.Destination) (entities.Destination, error) {
+		CreateDestinationFunc: func(destination entities.Destination, actor entities.Actor) (entities.Destination, error) {
 			destination.ID = 1
 			return destination, nil
 		},
 	}
 
 	destinationHandler := &handlers.DestinationHandler{Service: mockService}
-	routes.RegisterDestinationRoutes(router, destinationHandler)
+	routes.RegisterDestinationRoutes(router, destinationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
 
 	newDestination := entities.Destination{
 		Name:             "Lake Retreat",
@@ -65,6 +67,7 @@ func TestCreateDestination_Success(t *testing.T) {
 func TestCreateDestination_NameTooShort(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
 
 	_ = &mocks.MockLocationService{
 		LocationByIDFunc: func(idStr string) (*entities.Location, error) {
@@ -78,14 +81,14 @@ func TestCreateDestination_NameTooShort(t *testing.T) {
 	}
 
 	mockService := &mocks.MockDestinationService{
-		CreateDestinationFunc: func(destination entities.Destination) (entities.Destination, error) {
+		CreateDestinationFunc: func(destination entities.Destination, actor entities.Actor) (entities.Destination, error) {
 			destination.ID = 1
 			return destination, nil
 		},
 	}
 
 	destinationHandler := &handlers.DestinationHandler{Service: mockService}
-	routes.RegisterDestinationRoutes(router, destinationHandler)
+	routes.RegisterDestinationRoutes(router, destinationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
 
 	newDestination := entities.Destination{
 		Name:             "L",
@@ -107,6 +110,7 @@ func TestCreateDestination_NameTooShort(t *testing.T) {
 func TestCreateDestination_DescriptionTooShort(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
 
 	_ = &mocks.MockLocationService{
 		LocationByIDFunc: func(idStr string) (*entities.Location, error) {
@@ -120,14 +124,14 @@ func TestCreateDestination_DescriptionTooShort(t *testing.T) {
 	}
 
 	mockService := &mocks.MockDestinationService{
-		CreateDestinationFunc: func(destination entities.Destination) (entities.Destination, error) {
+		CreateDestinationFunc: func(destination entities.Destination, actor entities.Actor) (entities.Destination, error) {
 			destination.ID = 1
 			return destination, nil
 		},
 	}
 
 	destinationHandler := &handlers.DestinationHandler{Service: mockService}
-	routes.RegisterDestinationRoutes(router, destinationHandler)
+	routes.RegisterDestinationRoutes(router, destinationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
 
 	newDestination := entities.Destination{
 		Name:             "Lapland",
@@ -149,6 +153,7 @@ func TestCreateDestination_DescriptionTooShort(t *testing.T) {
 func TestCreateDestination_VisitorsLastYearNegative(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
 
 	_ = &mocks.MockLocationService{
 		LocationByIDFunc: func(idStr string) (*entities.Location, error) {
@@ -162,14 +167,14 @@ func TestCreateDestination_VisitorsLastYearNegative(t *testing.T) {
 	}
 
 	mockService := &mocks.MockDestinationService{
-		CreateDestinationFunc: func(destination entities.Destination) (entities.Destination, error) {
+		CreateDestinationFunc: func(destination entities.Destination, actor entities.Actor) (entities.Destination, error) {
 			destination.ID = 1
 			return destination, nil
 		},
 	}
 
 	destinationHandler := &handlers.DestinationHandler{Service: mockService}
-	routes.RegisterDestinationRoutes(router, destinationHandler)
+	routes.RegisterDestinationRoutes(router, destinationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
 
 	newDestination := entities.Destination{
 		Name:             "Lapland",
@@ -187,3 +192,33 @@ func TestCreateDestination_VisitorsLastYearNegative(t *testing.T) {
 
 	assert.Equal(t, http.StatusBadRequest, w.Code, fmt.Sprintf("Field %s validation failed", "visitorsLastYear"))
 }
+
+func TestCreateDestination_InvalidCoordinates(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
+
+	mockService := &mocks.MockDestinationService{
+		CreateDestinationFunc: func(destination entities.Destination, actor entities.Actor) (entities.Destination, error) {
+			destination.ID = 1
+			return destination, nil
+		},
+	}
+
+	destinationHandler := &handlers.DestinationHandler{Service: mockService}
+	routes.RegisterDestinationRoutes(router, destinationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
+
+	latitude, longitude := 91.5, 25.7
+	for _, destination := range []entities.Destination{
+		{Name: "Lake Retreat", LocationID: 1, Description: "A serene lake retreat.", Latitude: &latitude, Longitude: &longitude},
+		{Name: "Lake Retreat", LocationID: 1, Description: "A serene lake retreat.", Longitude: &longitude},
+	} {
+		requestBody, _ := json.Marshal(destination)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/destinations/", bytes.NewBuffer(requestBody))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, "Field latitude validation failed")
+	}
+}
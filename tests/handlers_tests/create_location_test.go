@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/infrastructure/middlewares"
 	"Trip-Trove-API/presentation/handlers"
 	"Trip-Trove-API/routes"
 	"Trip-Trove-API/tests/mocks"
@@ -18,16 +19,17 @@ import (
 func TestCreateLocation_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
 
 	mockService := &mocks.MockLocationService{
-		CreateLocationFunc: func(location entities.Location) (entities.Location, error) {
+		CreateLocationFunc: func(location entities.Location, actor entities.Actor) (entities.Location, error) {
 			location.ID = 1
 			return location, nil
 		},
 	}
 
 	locationHandler := &handlers.LocationHandler{Service: mockService}
-	routes.RegisterLocationRoutes(router, locationHandler)
+	routes.RegisterLocationRoutes(router, locationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
 
 	newLocation := entities.Location{
 		Name:        "Finnish Lapland",
@@ -50,15 +52,16 @@ func TestCreateLocation_Success(t *testing.T) {
 func TestCreateLocation_NameTooShort(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
 
 	mockService := &mocks.MockLocationService{
-		CreateLocationFunc: func(location entities.Location) (entities.Location, error) {
+		CreateLocationFunc: func(location entities.Location, actor entities.Actor) (entities.Location, error) {
 			return entities.Location{}, fmt.Errorf("Field 'name' validation failed")
 		},
 	}
 
 	locationHandler := &handlers.LocationHandler{Service: mockService}
-	routes.RegisterLocationRoutes(router, locationHandler)
+	routes.RegisterLocationRoutes(router, locationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
 
 	newLocation := entities.Location{
 		Name:        "Fi",
@@ -77,15 +80,16 @@ func TestCreateLocation_NameTooShort(t *testing.T) {
 func TestCreateLocation_InvalidCountry(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
 
 	mockService := &mocks.MockLocationService{
-		CreateLocationFunc: func(location entities.Location) (entities.Location, error) {
+		CreateLocationFunc: func(location entities.Location, actor entities.Actor) (entities.Location, error) {
 			return entities.Location{}, fmt.Errorf("Field 'country' validation failed")
 		},
 	}
 
 	locationHandler := &handlers.LocationHandler{Service: mockService}
-	routes.RegisterLocationRoutes(router, locationHandler)
+	routes.RegisterLocationRoutes(router, locationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
 
 	newLocation := entities.Location{
 		Name:        "Finnish Lapland",
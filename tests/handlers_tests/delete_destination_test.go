@@ -1,11 +1,12 @@
 package handlers
 
 import (
+	"Trip-Trove-API/domain/apperrors"
 	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/infrastructure/middlewares"
 	"Trip-Trove-API/presentation/handlers"
 	"Trip-Trove-API/routes"
 	"Trip-Trove-API/tests/mocks"
-	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
@@ -17,6 +18,7 @@ import (
 func TestDeleteDestination_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
 
 	_ = &mocks.MockLocationService{
 		LocationByIDFunc: func(idStr string) (*entities.Location, error) {
@@ -30,13 +32,13 @@ func TestDeleteDestination_Success(t *testing.T) {
 	}
 
 	mockService := &mocks.MockDestinationService{
-		DeleteDestinationFunc: func(id string) (entities.Destination, error) {
+		DeleteDestinationFunc: func(id string, actor entities.Actor) (entities.Destination, error) {
 			return entities.Destination{Name: "Deleted Destination"}, nil
 		},
 	}
 
 	destinationHandler := &handlers.DestinationHandler{Service: mockService}
-	routes.RegisterDestinationRoutes(router, destinationHandler)
+	routes.RegisterDestinationRoutes(router, destinationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("DELETE", "/destinations/1", nil)
@@ -48,15 +50,16 @@ func TestDeleteDestination_Success(t *testing.T) {
 func TestDeleteDestination_InvalidIDFormat(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
 
 	mockService := &mocks.MockDestinationService{
-		DeleteDestinationFunc: func(id string) (entities.Destination, error) {
-			return entities.Destination{}, errors.New("invalid ID format")
+		DeleteDestinationFunc: func(id string, actor entities.Actor) (entities.Destination, error) {
+			return entities.Destination{}, apperrors.ErrInvalidID
 		},
 	}
 
 	destinationHandler := &handlers.DestinationHandler{Service: mockService}
-	routes.RegisterDestinationRoutes(router, destinationHandler)
+	routes.RegisterDestinationRoutes(router, destinationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("DELETE", "/destinations/abc", nil)
@@ -68,15 +71,16 @@ func TestDeleteDestination_InvalidIDFormat(t *testing.T) {
 func TestDeleteDestination_NotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
 
 	mockService := &mocks.MockDestinationService{
-		DeleteDestinationFunc: func(id string) (entities.Destination, error) {
-			return entities.Destination{}, errors.New("destination not found")
+		DeleteDestinationFunc: func(id string, actor entities.Actor) (entities.Destination, error) {
+			return entities.Destination{}, apperrors.NotFound("destination not found")
 		},
 	}
 
 	destinationHandler := &handlers.DestinationHandler{Service: mockService}
-	routes.RegisterDestinationRoutes(router, destinationHandler)
+	routes.RegisterDestinationRoutes(router, destinationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("DELETE", "/destinations/9999", nil)
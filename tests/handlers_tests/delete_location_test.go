@@ -1,11 +1,12 @@
 package handlers
 
 import (
+	"Trip-Trove-API/domain/apperrors"
 	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/infrastructure/middlewares"
 	"Trip-Trove-API/presentation/handlers"
 	"Trip-Trove-API/routes"
 	"Trip-Trove-API/tests/mocks"
-	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"net/http"
@@ -16,15 +17,16 @@ import (
 func TestDeleteLocation_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
 
 	mockService := &mocks.MockLocationService{
-		DeleteLocationFunc: func(id string) (entities.Location, error) {
+		DeleteLocationFunc: func(id string, actor entities.Actor) (entities.Location, error) {
 			return entities.Location{Name: "Deleted Location"}, nil
 		},
 	}
 
 	locationHandler := &handlers.LocationHandler{Service: mockService}
-	routes.RegisterLocationRoutes(router, locationHandler)
+	routes.RegisterLocationRoutes(router, locationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("DELETE", "/locations/1", nil)
@@ -36,15 +38,16 @@ func TestDeleteLocation_Success(t *testing.T) {
 func TestDeleteLocation_InvalidIDFormat(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
 
 	mockService := &mocks.MockLocationService{
-		DeleteLocationFunc: func(id string) (entities.Location, error) {
-			return entities.Location{}, errors.New("invalid ID format")
+		DeleteLocationFunc: func(id string, actor entities.Actor) (entities.Location, error) {
+			return entities.Location{}, apperrors.ErrInvalidID
 		},
 	}
 
 	locationHandler := &handlers.LocationHandler{Service: mockService}
-	routes.RegisterLocationRoutes(router, locationHandler)
+	routes.RegisterLocationRoutes(router, locationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("DELETE", "/locations/abc", nil)
@@ -56,15 +59,16 @@ func TestDeleteLocation_InvalidIDFormat(t *testing.T) {
 func TestDeleteLocation_NotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
 
 	mockService := &mocks.MockLocationService{
-		DeleteLocationFunc: func(id string) (entities.Location, error) {
-			return entities.Location{}, errors.New("location not found")
+		DeleteLocationFunc: func(id string, actor entities.Actor) (entities.Location, error) {
+			return entities.Location{}, apperrors.NotFound("location not found")
 		},
 	}
 
 	locationHandler := &handlers.LocationHandler{Service: mockService}
-	routes.RegisterLocationRoutes(router, locationHandler)
+	routes.RegisterLocationRoutes(router, locationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("DELETE", "/locations/9999", nil)
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/events"
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/infrastructure/dataaccess"
+	"Trip-Trove-API/infrastructure/middlewares"
+	ws "Trip-Trove-API/infrastructure/websocket"
+	"Trip-Trove-API/presentation/handlers"
+	"Trip-Trove-API/routes"
+	"Trip-Trove-API/tests/mocks"
+	"Trip-Trove-API/utils"
+	"bufio"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type sseFrame struct {
+	ID    string
+	Event string
+	Data  string
+}
+
+func startEventsServer(t *testing.T) (*httptest.Server, *services.EventStream) {
+	t.Setenv("JWT_SECRET", "test-secret")
+	gin.SetMode(gin.TestMode)
+
+	manager := ws.NewWebSocketManager()
+	go manager.BroadcastWebSocketMessage()
+	t.Cleanup(manager.Close)
+
+	stream := &services.EventStream{
+		Log:         dataaccess.NewMemoryEventLogRepository(dataaccess.NewMemoryStore()),
+		Subscribers: []events.Publisher{manager},
+	}
+	t.Cleanup(stream.Close)
+
+	router := gin.New()
+	router.Use(middlewares.ErrorMiddleware())
+	eventsHandler := &handlers.EventsHandler{Service: &mocks.MockDestinationService{}, WebSocketManager: manager, Events: stream}
+	routes.RegisterEventRoutes(router, eventsHandler, middlewares.AuthMiddleware{})
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return server, stream
+}
+
+func openEventStream(t *testing.T, server *httptest.Server, userID uint, query string, header http.Header) (*http.Response, *bufio.Reader) {
+	jwtWrapper := utils.JwtWrapper{SecretKey: "test-secret", Issuer: "AuthService", ExpirationMinutes: 60}
+	token, _, err := jwtWrapper.GenerateToken(entities.User{Model: gorm.Model{ID: userID}}, "")
+	require.NoError(t, err)
+
+	req, err := http.NewRequest("GET", server.URL+"/events?access_token="+token+query, nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "text/event-stream")
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	response, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { response.Body.Close() })
+	return response, bufio.NewReader(response.Body)
+}
+
+// readFrame returns the next event, skipping heartbeat comments.
+func readFrame(t *testing.T, reader *bufio.Reader) sseFrame {
+	frames := make(chan sseFrame, 1)
+	go func() {
+		var frame sseFrame
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				close(frames)
+				return
+			}
+			line = strings.TrimRight(line, "\n")
+			switch {
+			case line == "" && frame != (sseFrame{}):
+				frames <- frame
+				return
+			case strings.HasPrefix(line, "id:"):
+				frame.ID = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
+			case strings.HasPrefix(line, "event:"):
+				frame.Event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+			case strings.HasPrefix(line, "data:"):
+				frame.Data = strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+			}
+		}
+	}()
+
+	select {
+	case frame, ok := <-frames:
+		require.True(t, ok, "stream closed")
+		return frame
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for an event")
+		return sseFrame{}
+	}
+}
+
+func TestEventStream_RequiresAuthentication(t *testing.T) {
+	server, _ := startEventsServer(t)
+
+	response, err := http.Get(server.URL + "/events")
+
+	require.NoError(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+}
+
+func TestEventStream_DeliversMatchingEvents(t *testing.T) {
+	server, stream := startEventsServer(t)
+	response, reader := openEventStream(t, server, 4, "&topic=location:2", nil)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "text/event-stream", response.Header.Get("Content-Type"))
+
+	ownerID := uint(3)
+	actor := entities.Actor{UserID: 3, Role: entities.Manager, Authenticated: true}
+	stream.Publish(events.NewDestinationEvent(events.DestinationCreated, entities.Destination{
+		Model: gorm.Model{ID: 5}, Name: "Hidden Garden", LocationID: 2, IsPrivate: true, OwnerID: &ownerID,
+	}, actor))
+	stream.Publish(events.NewDestinationEvent(events.DestinationCreated, entities.Destination{
+		Model: gorm.Model{ID: 6}, Name: "Colosseum", LocationID: 8,
+	}, actor))
+	stream.Publish(events.NewDestinationEvent(events.DestinationCreated, entities.Destination{
+		Model: gorm.Model{ID: 7}, Name: "Belem Tower", LocationID: 2,
+	}, actor))
+
+	frame := readFrame(t, reader)
+	assert.Equal(t, "3", frame.ID, "the private and the other location's events are filtered out")
+	assert.Equal(t, "DestinationCreated", frame.Event)
+	assert.Contains(t, frame.Data, `"Belem Tower"`)
+	assert.Contains(t, frame.Data, `"seq":3`)
+}
+
+func TestEventStream_ResumesFromLastEventID(t *testing.T) {
+	server, stream := startEventsServer(t)
+	for _, name := range []string{"Belem Tower", "Colosseum", "Louvre"} {
+		stream.Publish(events.NewDestinationEvent(events.DestinationCreated, entities.Destination{Name: name}, entities.Anonymous))
+	}
+	stream.Flush()
+
+	_, reader := openEventStream(t, server, 4, "", http.Header{"Last-Event-Id": {"1"}})
+
+	assert.Equal(t, "2", readFrame(t, reader).ID)
+	assert.Equal(t, "3", readFrame(t, reader).ID)
+	replayed := readFrame(t, reader)
+	assert.Equal(t, ws.ActionReplayed, replayed.Event)
+	assert.JSONEq(t, `{"action":"replayed","seq":3}`, replayed.Data)
+}
+
+func TestEventStream_RejectsInvalidLastEventID(t *testing.T) {
+	server, _ := startEventsServer(t)
+
+	response, _ := openEventStream(t, server, 4, "", http.Header{"Last-Event-Id": {"latest"}})
+
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+}
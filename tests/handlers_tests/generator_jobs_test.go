@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/infrastructure/dataaccess"
+	"Trip-Trove-API/infrastructure/middlewares"
+	"Trip-Trove-API/presentation/handlers"
+	"Trip-Trove-API/routes"
+	"Trip-Trove-API/tests/mocks"
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func generatorRouter(t *testing.T, role entities.AccessType) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
+
+	store := dataaccess.NewMemoryStore()
+	repos := dataaccess.NewMemoryRepositories(store)
+	destinationService := &services.DestinationService{Repo: repos.Destinations, LocationRepo: repos.Locations, UnitOfWork: dataaccess.NewMemoryUnitOfWork(store)}
+	generatorService := &services.GeneratorService{Destinations: destinationService}
+	t.Cleanup(generatorService.Close)
+
+	routes.RegisterGeneratorRoutes(router, &handlers.GeneratorHandler{Service: generatorService}, mocks.MockAuthMiddleware{Role: role, UserID: 1})
+	return router
+}
+
+func TestGeneratorJobs_StartAndStop(t *testing.T) {
+	router := generatorRouter(t, entities.Admin)
+
+	body, _ := json.Marshal(map[string]interface{}{"interval_ms": 60000, "count": 5, "seed": 3})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/destinations/generator/jobs/", bytes.NewBuffer(body))
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusAccepted, w.Code)
+	var job services.GeneratorJob
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &job))
+	assert.NotEmpty(t, job.ID)
+	assert.Equal(t, int64(3), job.Seed)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/destinations/generator/jobs/"+job.ID+"/stop", nil)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &job))
+	assert.Equal(t, services.JobStopped, job.Status)
+}
+
+func TestGeneratorJobs_RejectInvalidRequest(t *testing.T) {
+	router := generatorRouter(t, entities.Admin)
+
+	body, _ := json.Marshal(map[string]interface{}{"interval_ms": 5, "count": 0})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/destinations/generator/jobs/", bytes.NewBuffer(body))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGeneratorJobs_RequireAdmin(t *testing.T) {
+	router := generatorRouter(t, entities.Manager)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/destinations/generator/jobs/", bytes.NewBufferString(`{"interval_ms":1000,"count":1}`))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -1,7 +1,9 @@
-package handlers_test
+package handlers
 
 import (
 	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/infrastructure/middlewares"
 	"Trip-Trove-API/presentation/handlers"
 	"Trip-Trove-API/routes"
 	"Trip-Trove-API/tests/mocks"
@@ -20,6 +22,7 @@ import (
 func TestAllDestinations_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
 
 	_ = &mocks.MockLocationService{
 		LocationByIDFunc: func(idStr string) (*entities.Location, error) {
@@ -33,15 +36,18 @@ func TestAllDestinations_Success(t *testing.T) {
 	}
 
 	mockService := &mocks.MockDestinationService{
-		AllDestinationsFunc: func() ([]entities.Destination, error) {
-			return []entities.Destination{
-				{Name: "Beach Paradise", LocationID: 1},
+		AllDestinationsFunc: func(query entities.DestinationQuery, actor entities.Actor) (*services.DestinationPage, error) {
+			return &services.DestinationPage{
+				Destinations: []entities.Destination{{Name: "Beach Paradise", LocationID: 1}},
+				Total:        1,
+				Page:         1,
+				Limit:        services.DefaultPageLimit,
 			}, nil
 		},
 	}
 
 	destinationHandler := &handlers.DestinationHandler{Service: mockService}
-	routes.RegisterDestinationRoutes(router, destinationHandler)
+	routes.RegisterDestinationRoutes(router, destinationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("GET", "/destinations/", nil)
@@ -49,46 +55,54 @@ func TestAllDestinations_Success(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, w.Code)
 
-	var destinations []entities.Destination
-	err := json.Unmarshal(w.Body.Bytes(), &destinations)
+	var response destinationPageResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
-	assert.Len(t, destinations, 1)
-	assert.Equal(t, "Beach Paradise", destinations[0].Name)
+	assert.Len(t, response.Data, 1)
+	assert.Equal(t, "Beach Paradise", response.Data[0].Name)
+	assert.Equal(t, int64(1), response.Total)
+	assert.Nil(t, response.NextPage)
 }
 
 func TestAllDestinations_EmptyList(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
 
 	mockService := &mocks.MockDestinationService{
-		AllDestinationsFunc: func() ([]entities.Destination, error) {
-			return []entities.Destination{}, nil
+		AllDestinationsFunc: func(query entities.DestinationQuery, actor entities.Actor) (*services.DestinationPage, error) {
+			return &services.DestinationPage{Destinations: []entities.Destination{}, Page: 1, Limit: services.DefaultPageLimit}, nil
 		},
 	}
 
 	destinationHandler := &handlers.DestinationHandler{Service: mockService}
-	routes.RegisterDestinationRoutes(router, destinationHandler)
+	routes.RegisterDestinationRoutes(router, destinationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("GET", "/destinations/", nil)
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
-	assert.JSONEq(t, "[]", w.Body.String(), "Expected an empty JSON array")
+	var response destinationPageResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Empty(t, response.Data, "Expected an empty data array")
+	assert.Equal(t, int64(0), response.Total)
 }
 
 func TestAllDestinations_InternalServerError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
 
 	mockService := &mocks.MockDestinationService{
-		AllDestinationsFunc: func() ([]entities.Destination, error) {
+		AllDestinationsFunc: func(query entities.DestinationQuery, actor entities.Actor) (*services.DestinationPage, error) {
 			return nil, errors.New("internal server error")
 		},
 	}
 
 	destinationHandler := &handlers.DestinationHandler{Service: mockService}
-	routes.RegisterDestinationRoutes(router, destinationHandler)
+	routes.RegisterDestinationRoutes(router, destinationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("GET", "/destinations/", nil)
@@ -100,6 +114,7 @@ func TestAllDestinations_InternalServerError(t *testing.T) {
 func TestAllDestinations_LargeDataSet(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
 
 	var largeDestinations []entities.Destination
 	for i := 0; i < 1000; i++ {
@@ -118,22 +133,91 @@ func TestAllDestinations_LargeDataSet(t *testing.T) {
 	}
 
 	mockService := &mocks.MockDestinationService{
-		AllDestinationsFunc: func() ([]entities.Destination, error) {
-			return largeDestinations, nil
+		AllDestinationsFunc: func(query entities.DestinationQuery, actor entities.Actor) (*services.DestinationPage, error) {
+			return &services.DestinationPage{
+				Destinations: largeDestinations[query.Offset() : query.Offset()+query.Limit],
+				Total:        int64(len(largeDestinations)),
+				Page:         query.Page,
+				Limit:        query.Limit,
+			}, nil
 		},
 	}
 
 	destinationHandler := &handlers.DestinationHandler{Service: mockService}
-	routes.RegisterDestinationRoutes(router, destinationHandler)
+	routes.RegisterDestinationRoutes(router, destinationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
 
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest("GET", "/destinations/", nil)
+	req, _ := http.NewRequest("GET", "/destinations/?page=2&limit=100", nil)
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 
-	var destinations []entities.Destination
-	err := json.Unmarshal(w.Body.Bytes(), &destinations)
+	var response destinationPageResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
-	assert.Len(t, destinations, 1000, "Expected 1000 destinations in the response")
+	assert.Len(t, response.Data, 100, "Expected 100 destinations in the response")
+	assert.Equal(t, "Destination 100", response.Data[0].Name)
+	assert.Equal(t, int64(1000), response.Total)
+	assert.Equal(t, "/destinations/?limit=100&page=3", *response.NextPage)
+	assert.Equal(t, "/destinations/?limit=100&page=1", *response.PrevPage)
+}
+
+func TestAllDestinations_QueryParameters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
+
+	var receivedQuery entities.DestinationQuery
+	mockService := &mocks.MockDestinationService{
+		AllDestinationsFunc: func(query entities.DestinationQuery, actor entities.Actor) (*services.DestinationPage, error) {
+			receivedQuery = query
+			return &services.DestinationPage{Destinations: []entities.Destination{}, Page: 1, Limit: 10}, nil
+		},
+	}
+
+	destinationHandler := &handlers.DestinationHandler{Service: mockService}
+	routes.RegisterDestinationRoutes(router, destinationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/destinations/?limit=10&sort=visitors_last_year&order=desc&location_id=3&country=Finland&min_visitors=100&max_visitors=5000", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 10, receivedQuery.Limit)
+	assert.Equal(t, entities.SortByVisitorsLastYear, receivedQuery.SortBy)
+	assert.True(t, receivedQuery.SortDesc)
+	assert.Equal(t, uint(3), *receivedQuery.Filter.LocationID)
+	assert.Equal(t, "Finland", receivedQuery.Filter.Country)
+	assert.Equal(t, 100, *receivedQuery.Filter.MinVisitors)
+	assert.Equal(t, 5000, *receivedQuery.Filter.MaxVisitors)
+}
+
+func TestAllDestinations_InvalidQueryParameters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
+
+	mockService := &mocks.MockDestinationService{
+		AllDestinationsFunc: func(query entities.DestinationQuery, actor entities.Actor) (*services.DestinationPage, error) {
+			return nil, services.ErrInvalidQuery
+		},
+	}
+
+	destinationHandler := &handlers.DestinationHandler{Service: mockService}
+	routes.RegisterDestinationRoutes(router, destinationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
+
+	for _, url := range []string{"/destinations/?page=abc", "/destinations/?order=sideways", "/destinations/?sort=unknown"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", url, nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, url)
+	}
+}
+
+type destinationPageResponse struct {
+	Data     []entities.Destination `json:"data"`
+	Total    int64                  `json:"total"`
+	NextPage *string                `json:"next_page"`
+	PrevPage *string                `json:"prev_page"`
 }
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/infrastructure/middlewares"
 	"Trip-Trove-API/presentation/handlers"
 	"Trip-Trove-API/routes"
 	"Trip-Trove-API/tests/mocks"
@@ -18,6 +19,7 @@ import (
 func TestAllLocations_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
 
 	mockService := &mocks.MockLocationService{
 		AllLocationsFunc: func() ([]entities.Location, error) {
@@ -28,7 +30,7 @@ func TestAllLocations_Success(t *testing.T) {
 	}
 
 	locationHandler := &handlers.LocationHandler{Service: mockService}
-	routes.RegisterLocationRoutes(router, locationHandler)
+	routes.RegisterLocationRoutes(router, locationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("GET", "/locations/", nil)
@@ -46,6 +48,7 @@ func TestAllLocations_Success(t *testing.T) {
 func TestAllLocations_EmptyList(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
 
 	mockService := &mocks.MockLocationService{
 		AllLocationsFunc: func() ([]entities.Location, error) {
@@ -54,7 +57,7 @@ func TestAllLocations_EmptyList(t *testing.T) {
 	}
 
 	locationHandler := &handlers.LocationHandler{Service: mockService}
-	routes.RegisterLocationRoutes(router, locationHandler)
+	routes.RegisterLocationRoutes(router, locationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("GET", "/locations/", nil)
@@ -67,6 +70,7 @@ func TestAllLocations_EmptyList(t *testing.T) {
 func TestAllLocations_InternalServerError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
 
 	mockService := &mocks.MockLocationService{
 		AllLocationsFunc: func() ([]entities.Location, error) {
@@ -75,7 +79,7 @@ func TestAllLocations_InternalServerError(t *testing.T) {
 	}
 
 	locationHandler := &handlers.LocationHandler{Service: mockService}
-	routes.RegisterLocationRoutes(router, locationHandler)
+	routes.RegisterLocationRoutes(router, locationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("GET", "/locations/", nil)
@@ -87,6 +91,7 @@ func TestAllLocations_InternalServerError(t *testing.T) {
 func TestAllLocations_LargeDataSet(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
 
 	var largeLocations []entities.Location
 	for i := 0; i < 1000; i++ {
@@ -100,7 +105,7 @@ func TestAllLocations_LargeDataSet(t *testing.T) {
 	}
 
 	locationHandler := &handlers.LocationHandler{Service: mockService}
-	routes.RegisterLocationRoutes(router, locationHandler)
+	routes.RegisterLocationRoutes(router, locationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("GET", "/locations/", nil)
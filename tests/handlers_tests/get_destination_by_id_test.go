@@ -1,12 +1,13 @@
 package handlers
 
 import (
+	"Trip-Trove-API/domain/apperrors"
 	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/infrastructure/middlewares"
 	"Trip-Trove-API/presentation/handlers"
 	"Trip-Trove-API/routes"
 	"Trip-Trove-API/tests/mocks"
 	"encoding/json"
-	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
@@ -18,6 +19,7 @@ import (
 func TestDestinationByID_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
 
 	_ = &mocks.MockLocationService{
 		LocationByIDFunc: func(idStr string) (*entities.Location, error) {
@@ -31,13 +33,13 @@ func TestDestinationByID_Success(t *testing.T) {
 	}
 
 	mockService := &mocks.MockDestinationService{
-		DestinationByIDFunc: func(idStr string) (*entities.Destination, error) {
+		DestinationByIDFunc: func(idStr string, actor entities.Actor) (*entities.Destination, error) {
 			return &entities.Destination{Name: "Beach Paradise", LocationID: 1}, nil
 		},
 	}
 
 	destinationHandler := &handlers.DestinationHandler{Service: mockService}
-	routes.RegisterDestinationRoutes(router, destinationHandler)
+	routes.RegisterDestinationRoutes(router, destinationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("GET", "/destinations/1", nil)
@@ -54,15 +56,16 @@ func TestDestinationByID_Success(t *testing.T) {
 func TestDestinationByID_InvalidIDFormat(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
 
 	mockService := &mocks.MockDestinationService{
-		DestinationByIDFunc: func(idStr string) (*entities.Destination, error) {
-			return nil, errors.New("invalid ID format")
+		DestinationByIDFunc: func(idStr string, actor entities.Actor) (*entities.Destination, error) {
+			return nil, apperrors.ErrInvalidID
 		},
 	}
 
 	destinationHandler := &handlers.DestinationHandler{Service: mockService}
-	routes.RegisterDestinationRoutes(router, destinationHandler)
+	routes.RegisterDestinationRoutes(router, destinationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("GET", "/destinations/abc", nil)
@@ -74,15 +77,16 @@ func TestDestinationByID_InvalidIDFormat(t *testing.T) {
 func TestDestinationByID_NotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
 
 	mockService := &mocks.MockDestinationService{
-		DestinationByIDFunc: func(idStr string) (*entities.Destination, error) {
-			return nil, errors.New("destination not found")
+		DestinationByIDFunc: func(idStr string, actor entities.Actor) (*entities.Destination, error) {
+			return nil, apperrors.NotFound("destination not found")
 		},
 	}
 
 	destinationHandler := &handlers.DestinationHandler{Service: mockService}
-	routes.RegisterDestinationRoutes(router, destinationHandler)
+	routes.RegisterDestinationRoutes(router, destinationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("GET", "/destinations/999", nil)
@@ -90,3 +94,33 @@ func TestDestinationByID_NotFound(t *testing.T) {
 
 	assert.Equal(t, http.StatusNotFound, w.Code)
 }
+
+func TestDestinationByID_UsesThePublicShape(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
+
+	ownerID := uint(4)
+	mockService := &mocks.MockDestinationService{
+		DestinationByIDFunc: func(idStr string, actor entities.Actor) (*entities.Destination, error) {
+			return &entities.Destination{Model: gorm.Model{ID: 1}, Name: "Beach Paradise", LocationID: 1, OwnerID: &ownerID}, nil
+		},
+	}
+
+	destinationHandler := &handlers.DestinationHandler{Service: mockService}
+	routes.RegisterDestinationRoutes(router, destinationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/destinations/1", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var destination map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &destination))
+	assert.Equal(t, float64(1), destination["id"])
+	assert.Equal(t, float64(4), destination["owner_id"])
+	assert.Contains(t, destination, "created_at")
+	assert.NotContains(t, destination, "DeletedAt")
+	assert.NotContains(t, destination, "ID")
+}
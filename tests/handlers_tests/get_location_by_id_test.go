@@ -1,12 +1,13 @@
 package handlers
 
 import (
+	"Trip-Trove-API/domain/apperrors"
 	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/infrastructure/middlewares"
 	"Trip-Trove-API/presentation/handlers"
 	"Trip-Trove-API/routes"
 	"Trip-Trove-API/tests/mocks"
 	"encoding/json"
-	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
@@ -18,6 +19,7 @@ import (
 func TestLocationByID_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
 
 	mockService := &mocks.MockLocationService{
 		LocationByIDFunc: func(idStr string) (*entities.Location, error) {
@@ -31,7 +33,7 @@ func TestLocationByID_Success(t *testing.T) {
 	}
 
 	locationHandler := &handlers.LocationHandler{Service: mockService}
-	routes.RegisterLocationRoutes(router, locationHandler)
+	routes.RegisterLocationRoutes(router, locationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("GET", "/locations/1", nil)
@@ -48,15 +50,16 @@ func TestLocationByID_Success(t *testing.T) {
 func TestLocationByID_InvalidIDFormat(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
 
 	mockService := &mocks.MockLocationService{
 		LocationByIDFunc: func(idStr string) (*entities.Location, error) {
-			return nil, errors.New("invalid ID format")
+			return nil, apperrors.ErrInvalidID
 		},
 	}
 
 	locationHandler := &handlers.LocationHandler{Service: mockService}
-	routes.RegisterLocationRoutes(router, locationHandler)
+	routes.RegisterLocationRoutes(router, locationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("GET", "/locations/abc", nil)
@@ -68,15 +71,16 @@ func TestLocationByID_InvalidIDFormat(t *testing.T) {
 func TestLocationByID_NotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
 
 	mockService := &mocks.MockLocationService{
 		LocationByIDFunc: func(idStr string) (*entities.Location, error) {
-			return nil, errors.New("location not found")
+			return nil, apperrors.NotFound("location not found")
 		},
 	}
 
 	locationHandler := &handlers.LocationHandler{Service: mockService}
-	routes.RegisterLocationRoutes(router, locationHandler)
+	routes.RegisterLocationRoutes(router, locationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("GET", "/locations/9999", nil)
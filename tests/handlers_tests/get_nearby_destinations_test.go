@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/infrastructure/middlewares"
+	"Trip-Trove-API/presentation/handlers"
+	"Trip-Trove-API/routes"
+	"Trip-Trove-API/tests/mocks"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNearbyDestinations_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
+
+	mockService := &mocks.MockDestinationService{
+		NearbyDestinationsFunc: func(latitude float64, longitude float64, radiusKm float64, limit int, actor entities.Actor) ([]entities.DestinationWithDistance, error) {
+			assert.Equal(t, 66.5039, latitude)
+			assert.Equal(t, 25.7294, longitude)
+			assert.Equal(t, 10.0, radiusKm)
+			return []entities.DestinationWithDistance{
+				{Destination: entities.Destination{Name: "Santa Claus Village", LocationID: 1}, DistanceKm: 7.8},
+			}, nil
+		},
+	}
+
+	destinationHandler := &handlers.DestinationHandler{Service: mockService}
+	routes.RegisterDestinationRoutes(router, destinationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/destinations/nearby?lat=66.5039&lng=25.7294&radius_km=10", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var destinations []entities.DestinationWithDistance
+	err := json.Unmarshal(w.Body.Bytes(), &destinations)
+	assert.NoError(t, err)
+	assert.Len(t, destinations, 1)
+	assert.Equal(t, "Santa Claus Village", destinations[0].Name)
+	assert.Equal(t, 7.8, destinations[0].DistanceKm)
+}
+
+func TestNearbyDestinations_MissingCoordinates(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
+
+	destinationHandler := &handlers.DestinationHandler{Service: &mocks.MockDestinationService{}}
+	routes.RegisterDestinationRoutes(router, destinationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/destinations/nearby?lat=66.5039", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestNearbyDestinations_OutOfRange(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
+
+	mockService := &mocks.MockDestinationService{
+		NearbyDestinationsFunc: func(latitude float64, longitude float64, radiusKm float64, limit int, actor entities.Actor) ([]entities.DestinationWithDistance, error) {
+			return nil, services.ErrInvalidQuery
+		},
+	}
+
+	destinationHandler := &handlers.DestinationHandler{Service: mockService}
+	routes.RegisterDestinationRoutes(router, destinationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/destinations/nearby?lat=120&lng=25.7294", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/infrastructure/middlewares"
+	"Trip-Trove-API/presentation/handlers"
+	"Trip-Trove-API/routes"
+	"Trip-Trove-API/tests/mocks"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func importRouter(role entities.AccessType, service *mocks.MockDestinationService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middlewares.ErrorMiddleware())
+	routes.RegisterDestinationRoutes(router, &handlers.DestinationHandler{Service: service}, mocks.MockAuthMiddleware{Role: role, UserID: 3})
+	return router
+}
+
+func TestImportDestinations_ParsesBodyAndReturnsReport(t *testing.T) {
+	var gotRows []services.DestinationImportRow
+	var gotDryRun bool
+	var gotActor entities.Actor
+	service := &mocks.MockDestinationService{
+		ImportDestinationsFunc: func(rows []services.DestinationImportRow, dryRun bool, actor entities.Actor) (*services.ImportReport, error) {
+			gotRows, gotDryRun, gotActor = rows, dryRun, actor
+			return &services.ImportReport{DryRun: dryRun, Created: 1, Rows: []services.ImportRowResult{{Line: 2, Name: "Belem Tower", Status: services.ImportCreated}}}, nil
+		},
+	}
+	router := importRouter(entities.Manager, service)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/destinations/import?dry_run=true", strings.NewReader("name,location,country\nBelem Tower,Lisbon,Portugal\n"))
+	req.Header.Set("Content-Type", "text/csv")
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Len(t, gotRows, 1)
+	assert.Equal(t, "Belem Tower", gotRows[0].Destination.Name)
+	assert.Equal(t, "Lisbon", gotRows[0].Location)
+	assert.True(t, gotDryRun)
+	assert.Equal(t, uint(3), gotActor.UserID)
+
+	var report services.ImportReport
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	assert.True(t, report.DryRun)
+	assert.Equal(t, services.ImportCreated, report.Rows[0].Status)
+}
+
+func TestImportDestinations_RejectsBadRequests(t *testing.T) {
+	service := &mocks.MockDestinationService{
+		ImportDestinationsFunc: func(rows []services.DestinationImportRow, dryRun bool, actor entities.Actor) (*services.ImportReport, error) {
+			t.Fatal("the service must not be called")
+			return nil, nil
+		},
+	}
+
+	for name, test := range map[string]struct {
+		role        entities.AccessType
+		url         string
+		contentType string
+		body        string
+		status      int
+	}{
+		"normal user":    {entities.NormalUser, "/destinations/import?format=csv", "", "name,location,country\n", http.StatusForbidden},
+		"unknown format": {entities.Manager, "/destinations/import?format=xml", "", "<destinations/>", http.StatusBadRequest},
+		"no format":      {entities.Manager, "/destinations/import", "application/json", "{}", http.StatusBadRequest},
+		"bad dry_run":    {entities.Manager, "/destinations/import?format=csv&dry_run=maybe", "", "name,location,country\n", http.StatusBadRequest},
+		"bad header":     {entities.Manager, "/destinations/import?format=csv", "", "title,place\nBelem,Lisbon\n", http.StatusBadRequest},
+		"empty jsonl":    {entities.Manager, "/destinations/import", "application/x-ndjson", "\n\n", http.StatusBadRequest},
+	} {
+		t.Run(name, func(t *testing.T) {
+			router := importRouter(test.role, service)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", test.url, strings.NewReader(test.body))
+			if test.contentType != "" {
+				req.Header.Set("Content-Type", test.contentType)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.status, w.Code, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/infrastructure/middlewares"
+	"Trip-Trove-API/presentation/handlers"
+	"Trip-Trove-API/routes"
+	"Trip-Trove-API/tests/mocks"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newProblemRouter(service *mocks.MockDestinationService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
+
+	destinationHandler := &handlers.DestinationHandler{Service: service}
+	routes.RegisterDestinationRoutes(router, destinationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
+	return router
+}
+
+func decodeProblem(t *testing.T, w *httptest.ResponseRecorder) middlewares.Problem {
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+
+	var problem middlewares.Problem
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	return problem
+}
+
+func validDestinationBody() *bytes.Buffer {
+	body, _ := json.Marshal(entities.Destination{
+		Name:        "Lake Retreat",
+		LocationID:  1,
+		Description: "A serene lake retreat.",
+	})
+	return bytes.NewBuffer(body)
+}
+
+func TestCreateDestination_DuplicateNameIsConflict(t *testing.T) {
+	router := newProblemRouter(&mocks.MockDestinationService{
+		CreateDestinationFunc: func(destination entities.Destination, actor entities.Actor) (entities.Destination, error) {
+			return entities.Destination{}, apperrors.Conflict("a destination with this name already exists")
+		},
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/destinations/", validDestinationBody())
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	problem := decodeProblem(t, w)
+	assert.Equal(t, http.StatusConflict, problem.Status)
+	assert.Equal(t, "Conflict", problem.Title)
+	assert.Equal(t, "a destination with this name already exists", problem.Detail)
+	assert.Equal(t, "/destinations/", problem.Instance)
+}
+
+func TestUpdateDestination_MissingRecordIsNotFound(t *testing.T) {
+	router := newProblemRouter(&mocks.MockDestinationService{
+		UpdateDestinationFunc: func(id string, destination entities.Destination, actor entities.Actor) (entities.Destination, error) {
+			return entities.Destination{}, apperrors.NotFound("destination not found")
+		},
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/destinations/42", validDestinationBody())
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "destination not found", decodeProblem(t, w).Detail)
+}
+
+func TestCreateDestination_ValidationProblemListsFields(t *testing.T) {
+	router := newProblemRouter(&mocks.MockDestinationService{})
+
+	body, _ := json.Marshal(entities.Destination{Name: "A", LocationID: 1, Description: "short", VisitorsLastYear: -1})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/destinations/", bytes.NewBuffer(body))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	problem := decodeProblem(t, w)
+	fields := map[string]string{}
+	for _, fieldErr := range problem.Errors {
+		fields[fieldErr.Field] = fieldErr.Rule
+	}
+	assert.Equal(t, map[string]string{"Name": "min", "Description": "min", "VisitorsLastYear": "gte"}, fields)
+}
+
+func TestUnexpectedErrorsAreNotLeaked(t *testing.T) {
+	router := newProblemRouter(&mocks.MockDestinationService{
+		DestinationByIDFunc: func(idStr string, actor entities.Actor) (*entities.Destination, error) {
+			return nil, errors.New("pq: connection refused")
+		},
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/destinations/1", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "internal server error", decodeProblem(t, w).Detail)
+}
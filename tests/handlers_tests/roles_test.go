@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/infrastructure/dataaccess"
+	"Trip-Trove-API/infrastructure/middlewares"
+	"Trip-Trove-API/presentation/handlers"
+	"Trip-Trove-API/routes"
+	"Trip-Trove-API/tests/mocks"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+)
+
+func roleRouter(t *testing.T, role entities.AccessType) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	store := dataaccess.NewMemoryStore()
+	repos := dataaccess.NewMemoryRepositories(store)
+	_, err := repos.Users.CreateUsers([]entities.User{
+		{Username: "admin1", Email: "admin1@example.com", PhoneNumber: "+40700000001", Role: entities.Admin},
+		{Username: "user1", Email: "user1@example.com", PhoneNumber: "+40700000002", Role: entities.NormalUser},
+	})
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.Use(middlewares.ErrorMiddleware())
+	userService := &services.UserService{Repo: repos.Users, TokenRepo: repos.Tokens, UnitOfWork: dataaccess.NewMemoryUnitOfWork(store)}
+	routes.RegisterUserRoutes(router, &handlers.UserHandler{Service: userService}, mocks.MockAuthMiddleware{Role: role, UserID: 1})
+	return router
+}
+
+func TestRoles_AdminGrantsAndRevokes(t *testing.T) {
+	router := roleRouter(t, entities.Admin)
+
+	w := serve(router, "PUT", "/users/2/role", `{"role": "manager", "reason": "runs the Lisbon office"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"role":"manager"`)
+
+	w = serve(router, "DELETE", "/users/2/role?reason=left", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"role":"user"`)
+
+	w = serve(router, "GET", "/users/2/role-changes", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var changes []map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &changes))
+	require.Len(t, changes, 2)
+	assert.Equal(t, "user", changes[0]["old_role"])
+	assert.Equal(t, "manager", changes[0]["new_role"])
+	assert.Equal(t, float64(1), changes[0]["changed_by_id"])
+	assert.Equal(t, "left", changes[1]["reason"])
+}
+
+func TestRoles_RejectsBadRequests(t *testing.T) {
+	for name, test := range map[string]struct {
+		role   entities.AccessType
+		method string
+		url    string
+		body   string
+		status int
+	}{
+		"manager":      {entities.Manager, "PUT", "/users/2/role", `{"role": "admin"}`, http.StatusForbidden},
+		"unknown role": {entities.Admin, "PUT", "/users/2/role", `{"role": "owner"}`, http.StatusBadRequest},
+		"numeric role": {entities.Admin, "PUT", "/users/2/role", `{"role": 2}`, http.StatusBadRequest},
+		"own role":     {entities.Admin, "DELETE", "/users/1/role", "", http.StatusForbidden},
+		"no user":      {entities.Admin, "PUT", "/users/9/role", `{"role": "manager"}`, http.StatusNotFound},
+		"audit":        {entities.Manager, "GET", "/users/2/role-changes", "", http.StatusForbidden},
+	} {
+		t.Run(name, func(t *testing.T) {
+			w := serve(roleRouter(t, test.role), test.method, test.url, test.body)
+			assert.Equal(t, test.status, w.Code, w.Body.String())
+		})
+	}
+}
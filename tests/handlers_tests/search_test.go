@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/infrastructure/middlewares"
+	"Trip-Trove-API/presentation/handlers"
+	"Trip-Trove-API/routes"
+	"Trip-Trove-API/tests/mocks"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSearch_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
+
+	mockDestinationService := &mocks.MockDestinationService{
+		SearchDestinationsFunc: func(term string, limit int, actor entities.Actor) ([]entities.DestinationSearchResult, error) {
+			return []entities.DestinationSearchResult{
+				{Destination: entities.Destination{Name: "Santa Claus Village"}, Location: "Rovaniemi", Country: "Finland", Rank: 0.8, Snippet: "<mark>Lapland</mark> village"},
+			}, nil
+		},
+	}
+	mockLocationService := &mocks.MockLocationService{
+		SearchLocationsFunc: func(term string, limit int) ([]entities.LocationSearchResult, error) {
+			return []entities.LocationSearchResult{
+				{Location: entities.Location{Name: "Finnish Lapland", Country: "Finland"}, Rank: 0.9},
+			}, nil
+		},
+	}
+
+	searchHandler := &handlers.SearchHandler{DestinationService: mockDestinationService, LocationService: mockLocationService}
+	routes.RegisterSearchRoutes(router, searchHandler, mocks.MockAuthMiddleware{})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/search?q=lapl", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response handlers.SearchResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "lapl", response.Query)
+	assert.Len(t, response.Destinations, 1)
+	assert.Equal(t, "Santa Claus Village", response.Destinations[0].Destination.Name)
+	assert.Len(t, response.Locations, 1)
+	assert.Equal(t, "Finnish Lapland", response.Locations[0].Location.Name)
+}
+
+func TestSearch_OnlyDestinations(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
+
+	mockDestinationService := &mocks.MockDestinationService{
+		SearchDestinationsFunc: func(term string, limit int, actor entities.Actor) ([]entities.DestinationSearchResult, error) {
+			assert.Equal(t, 5, limit)
+			return []entities.DestinationSearchResult{}, nil
+		},
+	}
+
+	searchHandler := &handlers.SearchHandler{DestinationService: mockDestinationService, LocationService: &mocks.MockLocationService{}}
+	routes.RegisterSearchRoutes(router, searchHandler, mocks.MockAuthMiddleware{})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/search?q=beach&type=destinations&limit=5", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestSearch_TermTooShort(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
+
+	mockDestinationService := &mocks.MockDestinationService{
+		SearchDestinationsFunc: func(term string, limit int, actor entities.Actor) ([]entities.DestinationSearchResult, error) {
+			return nil, services.ErrInvalidQuery
+		},
+	}
+
+	searchHandler := &handlers.SearchHandler{DestinationService: mockDestinationService, LocationService: &mocks.MockLocationService{}}
+	routes.RegisterSearchRoutes(router, searchHandler, mocks.MockAuthMiddleware{})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/search?q=a", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSearch_InternalServerError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
+
+	mockDestinationService := &mocks.MockDestinationService{
+		SearchDestinationsFunc: func(term string, limit int, actor entities.Actor) ([]entities.DestinationSearchResult, error) {
+			return nil, errors.New("internal server error")
+		},
+	}
+
+	searchHandler := &handlers.SearchHandler{DestinationService: mockDestinationService, LocationService: &mocks.MockLocationService{}}
+	routes.RegisterSearchRoutes(router, searchHandler, mocks.MockAuthMiddleware{})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/search?q=beach", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
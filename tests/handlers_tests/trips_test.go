@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/infrastructure/middlewares"
+	"Trip-Trove-API/presentation/handlers"
+	"Trip-Trove-API/routes"
+	"Trip-Trove-API/tests/mocks"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateTrip_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
+
+	mockService := &mocks.MockTripService{
+		CreateTripFunc: func(trip entities.Trip, actor entities.Actor) (entities.Trip, error) {
+			trip.ID = 1
+			trip.OwnerID = actor.UserID
+			return trip, nil
+		},
+	}
+
+	tripHandler := &handlers.TripHandler{Service: mockService}
+	routes.RegisterTripRoutes(router, tripHandler, mocks.MockAuthMiddleware{Role: entities.NormalUser, UserID: 3})
+
+	newTrip := entities.Trip{
+		Name:      "Arctic circle",
+		StartDate: "2024-12-20",
+		EndDate:   "2024-12-22",
+		Stops:     []entities.TripStop{{DestinationID: 10, Day: 1}},
+	}
+	requestBody, _ := json.Marshal(newTrip)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/trips/", bytes.NewBuffer(requestBody))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var returnedTrip entities.Trip
+	err := json.Unmarshal(w.Body.Bytes(), &returnedTrip)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), returnedTrip.OwnerID)
+}
+
+func TestCreateTrip_InvalidDate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
+
+	tripHandler := &handlers.TripHandler{Service: &mocks.MockTripService{}}
+	routes.RegisterTripRoutes(router, tripHandler, mocks.MockAuthMiddleware{Role: entities.NormalUser, UserID: 3})
+
+	requestBody, _ := json.Marshal(entities.Trip{Name: "Arctic circle", StartDate: "20/12/2024", EndDate: "2024-12-22"})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/trips/", bytes.NewBuffer(requestBody))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, fmt.Sprintf("Field %s validation failed", "start_date"))
+}
+
+func TestTripByID_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
+
+	mockService := &mocks.MockTripService{
+		TripByIDFunc: func(idStr string, actor entities.Actor) (*entities.Trip, error) {
+			return nil, apperrors.NotFound("trip not found")
+		},
+	}
+
+	tripHandler := &handlers.TripHandler{Service: mockService}
+	routes.RegisterTripRoutes(router, tripHandler, mocks.MockAuthMiddleware{Role: entities.NormalUser, UserID: 3})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/trips/999", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestReorderStops_InvalidOrder(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
+
+	mockService := &mocks.MockTripService{
+		ReorderStopsFunc: func(idStr string, order []entities.TripStopOrder, actor entities.Actor) (*entities.Trip, error) {
+			return nil, fmt.Errorf("%w: the new order must list every stop of the trip exactly once", services.ErrInvalidTrip)
+		},
+	}
+
+	tripHandler := &handlers.TripHandler{Service: mockService}
+	routes.RegisterTripRoutes(router, tripHandler, mocks.MockAuthMiddleware{Role: entities.NormalUser, UserID: 3})
+
+	requestBody, _ := json.Marshal(entities.ReorderStopsRequest{Stops: []entities.TripStopOrder{{StopID: 1, Day: 1}}})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/trips/1/stops/order", bytes.NewBuffer(requestBody))
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestTripSummary_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
+
+	mockService := &mocks.MockTripService{
+		TripSummaryFunc: func(idStr string, actor entities.Actor) (*services.TripSummary, error) {
+			return &services.TripSummary{TripID: 1, Days: 3, StopCount: 2, Countries: []string{"Finland"}}, nil
+		},
+	}
+
+	tripHandler := &handlers.TripHandler{Service: mockService}
+	routes.RegisterTripRoutes(router, tripHandler, mocks.MockAuthMiddleware{Role: entities.NormalUser, UserID: 3})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/trips/1/summary", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var summary services.TripSummary
+	err := json.Unmarshal(w.Body.Bytes(), &summary)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Finland"}, summary.Countries)
+}
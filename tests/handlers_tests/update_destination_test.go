@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/infrastructure/middlewares"
 	"Trip-Trove-API/presentation/handlers"
 	"Trip-Trove-API/routes"
 	"Trip-Trove-API/tests/mocks"
@@ -18,6 +19,7 @@ import (
 func TestUpdateDestination_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
 
 	_ = &mocks.MockLocationService{
 		LocationByIDFunc: func(idStr string) (*entities.Location, error) {
@@ -31,13 +33,13 @@ func TestUpdateDestination_Success(t *testing.T) {
 	}
 
 	mockService := &mocks.MockDestinationService{
-		UpdateDestinationFunc: func(id string, destination entities.Destination) (entities.Destination, error) {
+		UpdateDestinationFunc: func(id string, destination entities.Destination, actor entities.Actor) (entities.Destination, error) {
 			return destination, nil
 		},
 	}
 
 	destinationHandler := &handlers.DestinationHandler{Service: mockService}
-	routes.RegisterDestinationRoutes(router, destinationHandler)
+	routes.RegisterDestinationRoutes(router, destinationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
 
 	updatedDestination := entities.Destination{
 		Name:             "Updated Lake Retreat",
@@ -59,6 +61,7 @@ func TestUpdateDestination_Success(t *testing.T) {
 func TestUpdateDestination_NameTooShort(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
 
 	_ = &mocks.MockLocationService{
 		LocationByIDFunc: func(idStr string) (*entities.Location, error) {
@@ -72,13 +75,13 @@ func TestUpdateDestination_NameTooShort(t *testing.T) {
 	}
 
 	mockService := &mocks.MockDestinationService{
-		UpdateDestinationFunc: func(id string, destination entities.Destination) (entities.Destination, error) {
+		UpdateDestinationFunc: func(id string, destination entities.Destination, actor entities.Actor) (entities.Destination, error) {
 			return destination, nil
 		},
 	}
 
 	destinationHandler := &handlers.DestinationHandler{Service: mockService}
-	routes.RegisterDestinationRoutes(router, destinationHandler)
+	routes.RegisterDestinationRoutes(router, destinationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
 
 	updatedDestination := entities.Destination{
 		Name:             "U",
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/infrastructure/middlewares"
 	"Trip-Trove-API/presentation/handlers"
 	"Trip-Trove-API/routes"
 	"Trip-Trove-API/tests/mocks"
@@ -18,15 +19,16 @@ import (
 func TestUpdateLocation_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
 
 	mockService := &mocks.MockLocationService{
-		UpdateLocationFunc: func(id string, location entities.Location) (entities.Location, error) {
+		UpdateLocationFunc: func(id string, location entities.Location, actor entities.Actor) (entities.Location, error) {
 			return location, nil
 		},
 	}
 
 	locationHandler := &handlers.LocationHandler{Service: mockService}
-	routes.RegisterLocationRoutes(router, locationHandler)
+	routes.RegisterLocationRoutes(router, locationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
 
 	updatedLocation := entities.Location{
 		Name:        "Updated Finnish Lapland",
@@ -45,15 +47,16 @@ func TestUpdateLocation_Success(t *testing.T) {
 func TestUpdateLocation_NameTooShort(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
 
 	mockService := &mocks.MockLocationService{
-		UpdateLocationFunc: func(id string, location entities.Location) (entities.Location, error) {
+		UpdateLocationFunc: func(id string, location entities.Location, actor entities.Actor) (entities.Location, error) {
 			return entities.Location{}, fmt.Errorf("Field 'name' validation failed")
 		},
 	}
 
 	locationHandler := &handlers.LocationHandler{Service: mockService}
-	routes.RegisterLocationRoutes(router, locationHandler)
+	routes.RegisterLocationRoutes(router, locationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
 
 	updatedLocation := entities.Location{
 		Name:        "U",
@@ -72,15 +75,16 @@ func TestUpdateLocation_NameTooShort(t *testing.T) {
 func TestUpdateLocation_InvalidCountry(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
 
 	mockService := &mocks.MockLocationService{
-		UpdateLocationFunc: func(id string, location entities.Location) (entities.Location, error) {
+		UpdateLocationFunc: func(id string, location entities.Location, actor entities.Actor) (entities.Location, error) {
 			return entities.Location{}, fmt.Errorf("Field 'country' validation failed")
 		},
 	}
 
 	locationHandler := &handlers.LocationHandler{Service: mockService}
-	routes.RegisterLocationRoutes(router, locationHandler)
+	routes.RegisterLocationRoutes(router, locationHandler, mocks.MockAuthMiddleware{Role: entities.Admin, UserID: 1})
 
 	updatedLocation := entities.Location{
 		Name:        "Updated Finnish Lapland",
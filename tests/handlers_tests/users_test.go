@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/repositories"
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/infrastructure/dataaccess"
+	"Trip-Trove-API/infrastructure/middlewares"
+	"Trip-Trove-API/presentation/handlers"
+	"Trip-Trove-API/routes"
+	"Trip-Trove-API/tests/mocks"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const registerBody = `{
+	"username": "traveller",
+	"password": "Secret-pass-1!",
+	"email": "traveller@example.com",
+	"first_name": "Ana",
+	"last_name": "Silva",
+	"phone_number": "+40700000001",
+	"date_of_birth": "1990-01-01",
+	"address": "Lisbon",
+	"Role": 2,
+	"role": "admin"
+}`
+
+func userRouter(repos repositories.Repositories, role entities.AccessType) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middlewares.ErrorMiddleware())
+	userService := &services.UserService{Repo: repos.Users, TokenRepo: repos.Tokens}
+	routes.RegisterUserRoutes(router, &handlers.UserHandler{Service: userService}, mocks.MockAuthMiddleware{Role: role, UserID: 1})
+	return router
+}
+
+func serve(router *gin.Engine, method string, url string, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, url, strings.NewReader(body))
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestUsers_ResponsesNeverContainThePassword(t *testing.T) {
+	repos := dataaccess.NewMemoryRepositories(dataaccess.NewMemoryStore())
+	router := userRouter(repos, entities.Admin)
+
+	w := serve(router, "POST", "/users/register", registerBody)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	for _, w := range []*httptest.ResponseRecorder{w, serve(router, "GET", "/users/1", ""), serve(router, "GET", "/users/", "")} {
+		require.Equal(t, http.StatusOK/100, w.Code/100, w.Body.String())
+		assert.NotContains(t, w.Body.String(), "password")
+		assert.NotContains(t, w.Body.String(), "$2a$", "no bcrypt hash")
+		assert.NotContains(t, w.Body.String(), "DeletedAt")
+	}
+
+	var user map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &user))
+	assert.Equal(t, float64(1), user["id"])
+	assert.Equal(t, "user", user["role"], "the role cannot be chosen at registration")
+	assert.Contains(t, user, "created_at")
+}
+
+func TestUsers_UpdateCannotChangeTheRole(t *testing.T) {
+	repos := dataaccess.NewMemoryRepositories(dataaccess.NewMemoryStore())
+	router := userRouter(repos, entities.Admin)
+	require.Equal(t, http.StatusCreated, serve(router, "POST", "/users/register", registerBody).Code)
+
+	w := serve(router, "PUT", "/users/1", registerBody)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	stored, err := repos.Users.UserByID(1)
+	require.NoError(t, err)
+	assert.Equal(t, entities.NormalUser, stored.Role)
+	assert.NotContains(t, w.Body.String(), "password")
+}
+
+func TestUsers_MeReadsAndPatchesTheCallersProfile(t *testing.T) {
+	repos := dataaccess.NewMemoryRepositories(dataaccess.NewMemoryStore())
+	router := userRouter(repos, entities.NormalUser)
+	require.Equal(t, http.StatusCreated, serve(router, "POST", "/users/register", registerBody).Code)
+
+	w := serve(router, "GET", "/users/me", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Contains(t, w.Body.String(), `"username":"traveller"`)
+
+	w = serve(router, "PATCH", "/users/me", `{"address": "Porto", "password": "Other-pass-1", "role": 2}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	stored, err := repos.Users.UserByID(1)
+	require.NoError(t, err)
+	assert.Equal(t, "Porto", stored.Address)
+	assert.Equal(t, "Silva", stored.LastName, "omitted fields are kept")
+	assert.Equal(t, entities.NormalUser, stored.Role)
+	_, err = repos.Users.Authenticate(entities.LoginRequest{Email: "traveller@example.com", Password: "Secret-pass-1!"})
+	assert.NoError(t, err, "a profile edit never changes the password")
+
+	w = serve(router, "PATCH", "/users/me", `{"email": "not-an-email"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestUsers_ChangePassword(t *testing.T) {
+	repos := dataaccess.NewMemoryRepositories(dataaccess.NewMemoryStore())
+	router := userRouter(repos, entities.NormalUser)
+	require.Equal(t, http.StatusCreated, serve(router, "POST", "/users/register", registerBody).Code)
+
+	for name, test := range map[string]struct {
+		body  string
+		field string
+	}{
+		"wrong current password": {`{"current_password": "Wrong-pass-1!", "new_password": "Brand-new-pass-2!"}`, "current_password"},
+		"weak new password":      {`{"current_password": "Secret-pass-1!", "new_password": "password"}`, "NewPassword"},
+		"unchanged":              {`{"current_password": "Secret-pass-1!", "new_password": "Secret-pass-1!"}`, "new_password"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			w := serve(router, "POST", "/users/me/password", test.body)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), test.field)
+		})
+	}
+
+	w := serve(router, "POST", "/users/me/password", `{"current_password": "Secret-pass-1!", "new_password": "Brand-new-pass-2!"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	_, err := repos.Users.Authenticate(entities.LoginRequest{Email: "traveller@example.com", Password: "Brand-new-pass-2!"})
+	assert.NoError(t, err)
+	_, err = repos.Users.Authenticate(entities.LoginRequest{Email: "traveller@example.com", Password: "Secret-pass-1!"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/infrastructure/dataaccess"
+	"Trip-Trove-API/infrastructure/middlewares"
+	"Trip-Trove-API/presentation/handlers"
+	"Trip-Trove-API/routes"
+	"Trip-Trove-API/tests/mocks"
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func webhookRouter(role entities.AccessType) (*gin.Engine, *services.WebhookService) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.Use(middlewares.ErrorMiddleware())
+
+	service := &services.WebhookService{Repo: dataaccess.NewMemoryWebhookRepository(dataaccess.NewMemoryStore())}
+	routes.RegisterWebhookRoutes(router, &handlers.WebhookHandler{Service: service}, mocks.MockAuthMiddleware{Role: role, UserID: 1})
+	return router, service
+}
+
+func postWebhook(router *gin.Engine, request entities.WebhookRequest) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(request)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/webhooks/", bytes.NewBuffer(body))
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCreateWebhook_HidesSecret(t *testing.T) {
+	router, _ := webhookRouter(entities.Admin)
+
+	w := postWebhook(router, entities.WebhookRequest{
+		URL:        "https://partner.example/hooks",
+		Secret:     "a-secret-of-at-least-16-chars",
+		EventTypes: []string{"DestinationCreated"},
+	})
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.NotContains(t, w.Body.String(), "a-secret-of-at-least-16-chars")
+
+	var webhook entities.Webhook
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &webhook))
+	assert.True(t, webhook.Active)
+	assert.Equal(t, entities.WebhookEventTypes{"DestinationCreated"}, webhook.EventTypes)
+}
+
+func TestCreateWebhook_ValidatesRequest(t *testing.T) {
+	router, _ := webhookRouter(entities.Admin)
+
+	invalid := map[string]entities.WebhookRequest{
+		"relative url":       {URL: "/hooks", Secret: "a-secret-of-at-least-16-chars", EventTypes: []string{"DestinationCreated"}},
+		"short secret":       {URL: "https://partner.example", Secret: "short", EventTypes: []string{"DestinationCreated"}},
+		"no event types":     {URL: "https://partner.example", Secret: "a-secret-of-at-least-16-chars"},
+		"unknown event type": {URL: "https://partner.example", Secret: "a-secret-of-at-least-16-chars", EventTypes: []string{"TripCreated"}},
+	}
+	for name, request := range invalid {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, http.StatusBadRequest, postWebhook(router, request).Code)
+		})
+	}
+}
+
+func TestWebhookRoutes_RequireAdmin(t *testing.T) {
+	router, _ := webhookRouter(entities.NormalUser)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/webhooks/", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestWebhookDeliveries_NotFoundForOtherWebhook(t *testing.T) {
+	router, service := webhookRouter(entities.Admin)
+	first, err := service.CreateWebhook(entities.WebhookRequest{URL: "https://one.example", Secret: "a-secret-of-at-least-16-chars", EventTypes: []string{"LocationCreated"}})
+	require.NoError(t, err)
+	_, err = service.CreateWebhook(entities.WebhookRequest{URL: "https://two.example", Secret: "a-secret-of-at-least-16-chars", EventTypes: []string{"LocationCreated"}})
+	require.NoError(t, err)
+	delivery, err := service.Repo.CreateDelivery(entities.WebhookDelivery{WebhookID: first.ID, Status: entities.DeliveryFailed, Payload: "{}"})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/webhooks/2/deliveries/1/replay", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/webhooks/1/deliveries?status=failed", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var page struct {
+		Data  []entities.WebhookDelivery `json:"data"`
+		Total int64                      `json:"total"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &page))
+	assert.Equal(t, int64(1), page.Total)
+	assert.Equal(t, delivery.ID, page.Data[0].ID)
+}
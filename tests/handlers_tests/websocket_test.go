@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/events"
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/infrastructure/dataaccess"
+	"Trip-Trove-API/infrastructure/middlewares"
+	ws "Trip-Trove-API/infrastructure/websocket"
+	"Trip-Trove-API/presentation/handlers"
+	"Trip-Trove-API/routes"
+	"Trip-Trove-API/tests/mocks"
+	"Trip-Trove-API/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func startWebSocketServer(t *testing.T) (*httptest.Server, *ws.WebSocketManager, *services.EventStream) {
+	t.Setenv("JWT_SECRET", "test-secret")
+	gin.SetMode(gin.TestMode)
+
+	manager := ws.NewWebSocketManager()
+	go manager.BroadcastWebSocketMessage()
+	t.Cleanup(manager.Close)
+
+	stream := &services.EventStream{
+		Log:         dataaccess.NewMemoryEventLogRepository(dataaccess.NewMemoryStore()),
+		Subscribers: []events.Publisher{manager},
+	}
+	t.Cleanup(stream.Close)
+
+	service := &mocks.MockDestinationService{
+		DestinationByIDFunc: func(idStr string, actor entities.Actor) (*entities.Destination, error) {
+			if idStr == "9" {
+				return nil, apperrors.NotFound("destination not found")
+			}
+			return &entities.Destination{Name: "Visible"}, nil
+		},
+	}
+
+	router := gin.New()
+	routes.RegisterWebSocketRoutes(router, &handlers.WebSocketHandler{Service: service, WebSocketManager: manager, Events: stream}, middlewares.AuthMiddleware{})
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return server, manager, stream
+}
+
+func dialAs(t *testing.T, server *httptest.Server, userID uint) *websocket.Conn {
+	return dialWithQuery(t, server, userID, "")
+}
+
+func dialWithQuery(t *testing.T, server *httptest.Server, userID uint, query string) *websocket.Conn {
+	jwtWrapper := utils.JwtWrapper{SecretKey: "test-secret", Issuer: "AuthService", ExpirationMinutes: 60}
+	token, _, err := jwtWrapper.GenerateToken(entities.User{Model: gorm.Model{ID: userID}, Role: entities.NormalUser}, "")
+	require.NoError(t, err)
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws?access_token=" + token + query
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func readReply(t *testing.T, conn *websocket.Conn, v interface{}) {
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	require.NoError(t, conn.ReadJSON(v))
+}
+
+func subscribe(t *testing.T, conn *websocket.Conn, topic string) ws.Reply {
+	require.NoError(t, conn.WriteJSON(ws.Message{Action: ws.ActionSubscribe, Topic: topic}))
+	var reply ws.Reply
+	readReply(t, conn, &reply)
+	return reply
+}
+
+func TestWebSocket_RejectsUnauthenticatedHandshake(t *testing.T) {
+	server, _, _ := startWebSocketServer(t)
+
+	_, response, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/ws", nil)
+
+	assert.Error(t, err)
+	require.NotNil(t, response)
+	assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+}
+
+func TestWebSocket_Subscribe_ValidatesTopics(t *testing.T) {
+	server, _, _ := startWebSocketServer(t)
+	conn := dialAs(t, server, 3)
+
+	assert.Equal(t, ws.Reply{Action: ws.ActionSubscribed, Topic: "destination:4"}, subscribe(t, conn, "destination:4"))
+	assert.Equal(t, ws.ActionError, subscribe(t, conn, "destination:9").Action)
+	assert.Equal(t, ws.ActionError, subscribe(t, conn, "trips").Action)
+}
+
+type receivedEvent struct {
+	Type   events.Type          `json:"type"`
+	Entity events.EntityKind    `json:"entity"`
+	Data   entities.Destination `json:"data"`
+	Actor  events.EnvelopeActor `json:"actor"`
+}
+
+func TestWebSocket_RoutesEventsToMatchingSubscribers(t *testing.T) {
+	server, manager, _ := startWebSocketServer(t)
+	owner := dialAs(t, server, 3)
+	stranger := dialAs(t, server, 4)
+
+	assert.Equal(t, ws.ActionSubscribed, subscribe(t, owner, "destinations").Action)
+	assert.Equal(t, ws.ActionSubscribed, subscribe(t, stranger, "location:2").Action)
+
+	ownerID := uint(3)
+	actor := entities.Actor{UserID: 3, Role: entities.Manager, Authenticated: true}
+	manager.Publish(events.NewDestinationEvent(events.DestinationUpdated, entities.Destination{
+		Model: gorm.Model{ID: 5}, Name: "Hidden Garden", LocationID: 2, IsPrivate: true, OwnerID: &ownerID,
+	}, actor))
+	manager.Publish(events.NewDestinationEvent(events.DestinationCreated, entities.Destination{
+		Model: gorm.Model{ID: 6}, Name: "Belem Tower", LocationID: 2,
+	}, actor))
+	manager.Publish(events.NewDestinationEvent(events.DestinationDeleted, entities.Destination{
+		Model: gorm.Model{ID: 7}, Name: "Colosseum", LocationID: 8,
+	}, actor))
+
+	var received receivedEvent
+	for _, expected := range []string{"Hidden Garden", "Belem Tower", "Colosseum"} {
+		readReply(t, owner, &received)
+		assert.Equal(t, expected, received.Data.Name)
+	}
+
+	readReply(t, stranger, &received)
+	assert.Equal(t, "Belem Tower", received.Data.Name, "private destinations never reach other users")
+	assert.Equal(t, events.DestinationCreated, received.Type)
+	assert.Equal(t, events.EntityDestination, received.Entity)
+	assert.Equal(t, uint(3), received.Actor.UserID)
+
+	require.NoError(t, stranger.SetReadDeadline(time.Now().Add(100*time.Millisecond)))
+	assert.Error(t, stranger.ReadJSON(&received), "events outside the subscribed location are not delivered")
+}
+
+func TestWebSocket_DeliversLocationEventsWithEnvelope(t *testing.T) {
+	server, manager, _ := startWebSocketServer(t)
+	conn := dialAs(t, server, 3)
+
+	assert.Equal(t, ws.ActionSubscribed, subscribe(t, conn, "locations").Action)
+
+	manager.Publish(events.NewLocationEvent(events.LocationDeleted, entities.Location{Model: gorm.Model{ID: 2}, Name: "Lisbon"}, entities.Anonymous))
+
+	var received map[string]interface{}
+	readReply(t, conn, &received)
+	assert.Equal(t, "LocationDeleted", received["type"])
+	assert.Equal(t, "location", received["entity"])
+	assert.Equal(t, "Lisbon", received["data"].(map[string]interface{})["name"])
+	assert.Contains(t, received, "actor")
+	assert.Contains(t, received, "timestamp")
+}
+
+func TestWebSocket_ResumesFromSequence(t *testing.T) {
+	server, _, stream := startWebSocketServer(t)
+
+	ownerID := uint(3)
+	actor := entities.Actor{UserID: 3, Role: entities.Manager, Authenticated: true}
+	stream.Publish(events.NewDestinationEvent(events.DestinationCreated, entities.Destination{
+		Model: gorm.Model{ID: 5}, Name: "Belem Tower", LocationID: 2,
+	}, actor))
+	stream.Publish(events.NewDestinationEvent(events.DestinationCreated, entities.Destination{
+		Model: gorm.Model{ID: 6}, Name: "Hidden Garden", LocationID: 2, IsPrivate: true, OwnerID: &ownerID,
+	}, actor))
+	stream.Publish(events.NewDestinationEvent(events.DestinationUpdated, entities.Destination{
+		Model: gorm.Model{ID: 5}, Name: "Belem Tower", LocationID: 2, VisitorsLastYear: 10,
+	}, actor))
+	stream.Flush()
+
+	conn := dialWithQuery(t, server, 4, "&since=0&topic=location:2")
+
+	var replayed events.Envelope
+	readReply(t, conn, &replayed)
+	assert.Equal(t, uint64(1), replayed.Sequence)
+	readReply(t, conn, &replayed)
+	assert.Equal(t, uint64(3), replayed.Sequence, "private destinations are not replayed to other users")
+	assert.Equal(t, events.DestinationUpdated, replayed.Type)
+
+	var marker ws.Reply
+	readReply(t, conn, &marker)
+	assert.Equal(t, ws.Reply{Action: ws.ActionReplayed, Sequence: 3}, marker)
+
+	stream.Publish(events.NewDestinationEvent(events.DestinationDeleted, entities.Destination{
+		Model: gorm.Model{ID: 5}, Name: "Belem Tower", LocationID: 2,
+	}, actor))
+
+	var live events.Envelope
+	readReply(t, conn, &live)
+	assert.Equal(t, uint64(4), live.Sequence)
+	assert.Equal(t, events.DestinationDeleted, live.Type)
+}
+
+func TestWebSocket_AsksForResyncWhenTheGapIsTooOld(t *testing.T) {
+	server, _, _ := startWebSocketServer(t)
+
+	conn := dialWithQuery(t, server, 4, "&since=42&topic=destinations")
+
+	var reply ws.Reply
+	readReply(t, conn, &reply)
+	assert.Equal(t, ws.ActionResync, reply.Action)
+}
+
+func TestWebSocket_RejectsInvalidResumeParameters(t *testing.T) {
+	server, _, _ := startWebSocketServer(t)
+	jwtWrapper := utils.JwtWrapper{SecretKey: "test-secret", Issuer: "AuthService", ExpirationMinutes: 60}
+	token, _, err := jwtWrapper.GenerateToken(entities.User{Model: gorm.Model{ID: 4}}, "")
+	require.NoError(t, err)
+
+	for _, query := range []string{"&since=abc", "&topic=trips", "&topic=destination:9"} {
+		url := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws?access_token=" + token + query
+		_, response, err := websocket.DefaultDialer.Dial(url, nil)
+
+		assert.Error(t, err, query)
+		require.NotNil(t, response, query)
+		assert.NotEqual(t, http.StatusSwitchingProtocols, response.StatusCode, query)
+	}
+}
@@ -0,0 +1,248 @@
+package middlewares
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/infrastructure/middlewares"
+	"Trip-Trove-API/tests/mocks"
+	"Trip-Trove-API/utils"
+	"bytes"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func signedToken(t *testing.T, tokenID string) string {
+	jwtWrapper := utils.JwtWrapper{SecretKey: os.Getenv("JWT_SECRET"), Issuer: "AuthService", ExpirationMinutes: 60}
+	token, _, err := jwtWrapper.GenerateToken(entities.User{Model: gorm.Model{ID: 3}, Role: entities.Manager}, tokenID)
+	assert.NoError(t, err)
+	return token
+}
+
+func TestRequirePermission_RejectsRevokedToken(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+	gin.SetMode(gin.TestMode)
+
+	tokens := mocks.NewFakeTokenRepository()
+	_ = tokens.RevokeAccessTokens([]entities.RevokedToken{{TokenID: "revoked", ExpiresAt: time.Now().Add(time.Hour)}})
+
+	router := gin.New()
+	authMiddleware := middlewares.AuthMiddleware{Revocations: tokens}
+	router.GET("/protected", authMiddleware.RequirePermission(entities.DestinationsWrite), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for tokenID, expected := range map[string]int{"active": http.StatusOK, "revoked": http.StatusUnauthorized} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+signedToken(t, tokenID))
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, expected, w.Code, tokenID)
+	}
+}
+
+func TestOptionalAuth_AllowsAnonymous(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	authMiddleware := middlewares.AuthMiddleware{}
+	router.GET("/public", authMiddleware.OptionalAuth(), func(c *gin.Context) {
+		_, authenticated := c.Get("role")
+		c.JSON(http.StatusOK, gin.H{"authenticated": authenticated})
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/public", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"authenticated": false}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/public", nil)
+	req.Header.Set("Authorization", "Bearer "+signedToken(t, "active"))
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"authenticated": true}`, w.Body.String())
+}
+
+func TestRequireAuth_AcceptsQueryTokenOnlyForWebSocketUpgrades(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	authMiddleware := middlewares.AuthMiddleware{}
+	router.GET("/ws", authMiddleware.RequireAuth(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/ws?access_token="+signedToken(t, "active"), nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/ws?access_token="+signedToken(t, "active"), nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestRequireAuth_ExposesEmailVerification(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	authMiddleware := middlewares.AuthMiddleware{}
+	router.GET("/protected", authMiddleware.RequireAuth(), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"verified": c.GetBool("emailVerified")})
+	})
+
+	jwtWrapper := utils.JwtWrapper{SecretKey: "test-secret", Issuer: "AuthService", ExpirationMinutes: 60}
+	verifiedAt := time.Now()
+	for name, user := range map[string]entities.User{
+		"unverified": {Model: gorm.Model{ID: 3}},
+		"verified":   {Model: gorm.Model{ID: 3}, EmailVerifiedAt: &verifiedAt},
+	} {
+		token, _, err := jwtWrapper.GenerateToken(user, "active")
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code, name)
+		assert.JSONEq(t, fmt.Sprintf(`{"verified": %t}`, user.EmailVerified()), w.Body.String(), name)
+	}
+}
+
+func TestRequirePermission_ChecksThePolicyOfTheRole(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	authMiddleware := middlewares.AuthMiddleware{}
+	router.DELETE("/destinations/1", authMiddleware.RequirePermission(entities.DestinationsDelete), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	jwtWrapper := utils.JwtWrapper{SecretKey: "test-secret", Issuer: "AuthService", ExpirationMinutes: 60}
+	for role, expected := range map[entities.AccessType]int{
+		entities.NormalUser: http.StatusForbidden,
+		entities.Manager:    http.StatusOK,
+		entities.Admin:      http.StatusOK,
+	} {
+		token, _, err := jwtWrapper.GenerateToken(entities.User{Model: gorm.Model{ID: 3}, Role: role}, "active")
+		assert.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/destinations/1", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, expected, w.Code, role.String())
+	}
+}
+
+func TestRequirePermission_AcceptsTokensIssuedBeforePermissions(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+	gin.SetMode(gin.TestMode)
+	t.Cleanup(func() { entities.SetPolicy(entities.DefaultPolicy()) })
+
+	router := gin.New()
+	authMiddleware := middlewares.AuthMiddleware{}
+	router.POST("/locations", authMiddleware.RequirePermission(entities.LocationsWrite), func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+	})
+
+	// The claims of a token from before email verification and token IDs existed.
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"userID": 3,
+		"role":   int(entities.Manager),
+		"exp":    time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("test-secret"))
+	assert.NoError(t, err)
+
+	send := func() int {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/locations", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+	assert.Equal(t, http.StatusCreated, send())
+
+	entities.SetPolicy(entities.NewPolicy(map[entities.AccessType][]entities.Permission{
+		entities.Admin: entities.AllPermissions,
+	}))
+	assert.Equal(t, http.StatusForbidden, send())
+}
+
+func TestRequireAuth_TreatsTokensWithoutTheVerificationClaimAsVerified(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	authMiddleware := middlewares.AuthMiddleware{}
+	router.GET("/protected", authMiddleware.RequireAuth(), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"verified": c.GetBool("emailVerified")})
+	})
+
+	// Tokens issued before email verification existed carry no email_verified claim.
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"email":  "traveller@example.com",
+		"role":   entities.NormalUser,
+		"userID": 3,
+		"jti":    "issued-before-verification",
+		"exp":    time.Now().Add(time.Hour).Unix(),
+		"iss":    "AuthService",
+	}).SignedString([]byte("test-secret"))
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"verified": true}`, w.Body.String())
+}
+
+func TestLoggerMiddleware_RedactsTheQueryToken(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+	gin.SetMode(gin.TestMode)
+	var logged bytes.Buffer
+	defaultWriter := gin.DefaultWriter
+	gin.DefaultWriter = &logged
+	t.Cleanup(func() { gin.DefaultWriter = defaultWriter })
+
+	router := gin.New()
+	router.Use(middlewares.LoggerMiddleware())
+	authMiddleware := middlewares.AuthMiddleware{}
+	var seenByHandler string
+	router.GET("/ws", authMiddleware.RequireAuth(), func(c *gin.Context) {
+		seenByHandler = c.Request.URL.RawQuery
+		c.Status(http.StatusOK)
+	})
+
+	token := signedToken(t, "active")
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/ws?topic=all&access_token="+token, nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "topic=all&access_token=REDACTED", seenByHandler)
+	assert.Contains(t, logged.String(), "/ws?topic=all&access_token=REDACTED")
+	assert.NotContains(t, logged.String(), token)
+}
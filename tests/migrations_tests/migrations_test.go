@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"Trip-Trove-API/database/migrations"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"testing/fstest"
+)
+
+func TestEmbeddedMigrations_AreOrderedAndReversible(t *testing.T) {
+	migrator, err := migrations.NewMigrator(nil)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, migrator.Migrations)
+	for i, migration := range migrator.Migrations {
+		assert.Equal(t, int64(i+1), migration.Version, "migration versions should be contiguous")
+		assert.NotEmpty(t, migration.Up)
+		assert.NotEmpty(t, migration.Down)
+	}
+}
+
+func TestLoad_PairsFilesByVersion(t *testing.T) {
+	fsys := fstest.MapFS{
+		"sql/0002_add_index.up.sql":   {Data: []byte("CREATE INDEX a ON b (c);")},
+		"sql/0002_add_index.down.sql": {Data: []byte("DROP INDEX a;")},
+		"sql/0001_init.up.sql":        {Data: []byte("CREATE TABLE b (c int);")},
+	}
+
+	loaded, err := migrations.Load(fsys)
+
+	assert.NoError(t, err)
+	assert.Len(t, loaded, 2)
+	assert.Equal(t, int64(1), loaded[0].Version)
+	assert.Equal(t, "init", loaded[0].Name)
+	assert.Empty(t, loaded[0].Down)
+	assert.Equal(t, "add_index", loaded[1].Name)
+	assert.Equal(t, "DROP INDEX a;", loaded[1].Down)
+}
+
+func TestLoad_RejectsInvalidFiles(t *testing.T) {
+	cases := map[string]fstest.MapFS{
+		"bad name":       {"sql/init.sql": {Data: []byte("SELECT 1;")}},
+		"missing up":     {"sql/0001_init.down.sql": {Data: []byte("SELECT 1;")}},
+		"name conflicts": {"sql/0001_a.up.sql": {Data: []byte("SELECT 1;")}, "sql/0001_b.down.sql": {Data: []byte("SELECT 1;")}},
+	}
+
+	for name, fsys := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := migrations.Load(fsys)
+			assert.Error(t, err)
+		})
+	}
+}
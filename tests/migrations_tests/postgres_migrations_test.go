@@ -0,0 +1,155 @@
+package migrations
+
+import (
+	"Trip-Trove-API/database"
+	"Trip-Trove-API/database/migrations"
+	"Trip-Trove-API/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"os"
+	"strings"
+	"testing"
+)
+
+// testSchema keeps these tests away from the tables the contract tests share.
+const testSchema = "migrations_test"
+
+// The entities as the baseline release declared them, including the malformed tag on Role.
+type baselineLocation struct {
+	gorm.Model
+	Name        string `gorm:"column:name;not null;unique"`
+	Country     string `gorm:"column:country;not null"`
+	Description string `gorm:"column:description"`
+}
+
+func (baselineLocation) TableName() string { return "locations" }
+
+type baselineDestination struct {
+	gorm.Model
+	Name             string `gorm:"column:name;not null;unique"`
+	LocationID       uint   `gorm:"column:location_id;not null"`
+	ImageUrl         string `gorm:"column:image_url"`
+	Description      string `gorm:"column:description"`
+	VisitorsLastYear int    `gorm:"column:visitors_last_year"`
+	IsPrivate        bool   `gorm:"column:is_private;not null"`
+}
+
+func (baselineDestination) TableName() string { return "destinations" }
+
+type baselineUser struct {
+	gorm.Model
+	Username    string `gorm:"column:username;unique;not null"`
+	Password    string `gorm:"column:password;not null"`
+	Email       string `gorm:"column:email;unique;not null"`
+	FirstName   string `gorm:"column:first_name;not null"`
+	LastName    string `gorm:"column:last_name;not null"`
+	PhoneNumber string `gorm:"column:phone_number;unique;not null"`
+	DateOfBirth string `gorm:"column:date_of_birth"`
+	Address     string `gorm:"column:address"`
+	Role        uint8  `gorm:"column:access_type,type:tinyint;not null"`
+}
+
+func (baselineUser) TableName() string { return "users" }
+
+// openEmptySchema connects to TEST_DATABASE_URL with testSchema, recreated empty, first on
+// the search path of every connection. public stays on it for pg_trgm, which is installed
+// once per database.
+func openEmptySchema(t *testing.T) *gorm.DB {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	admin, err := database.OpenPostgres(dsn)
+	require.NoError(t, err)
+	require.NoError(t, admin.Exec("DROP SCHEMA IF EXISTS "+testSchema+" CASCADE").Error)
+	require.NoError(t, admin.Exec("CREATE SCHEMA "+testSchema).Error)
+	t.Cleanup(func() {
+		admin.Exec("DROP SCHEMA IF EXISTS " + testSchema + " CASCADE")
+		if sqlDB, err := admin.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	if strings.Contains(dsn, "://") {
+		separator := "?"
+		if strings.Contains(dsn, "?") {
+			separator = "&"
+		}
+		dsn += separator + "search_path=" + testSchema + ",public"
+	} else {
+		dsn += " search_path=" + testSchema + ",public"
+	}
+	db, err := database.OpenPostgres(dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+// upAndDown applies every migration, rolls all of them back and applies them again.
+func upAndDown(t *testing.T, db *gorm.DB) {
+	migrator, err := migrations.NewMigrator(db)
+	require.NoError(t, err)
+
+	applied, err := migrator.Up()
+	require.NoError(t, err)
+	assert.Len(t, applied, len(migrator.Migrations))
+	assert.True(t, db.Migrator().HasColumn(&entities.Destination{}, "owner_id"))
+	assert.True(t, db.Migrator().HasIndex(&entities.Destination{}, "idx_destinations_coordinates"))
+	assert.True(t, db.Migrator().HasIndex(&entities.Destination{}, "idx_destinations_search"))
+	assert.True(t, db.Migrator().HasIndex(&entities.Location{}, "idx_locations_search"))
+	assert.True(t, db.Migrator().HasColumn(&entities.User{}, "access_type"))
+
+	for range migrator.Migrations {
+		rolledBack, err := migrator.Down()
+		require.NoError(t, err)
+		require.NotNil(t, rolledBack)
+	}
+	pending, err := migrator.Pending()
+	require.NoError(t, err)
+	assert.Len(t, pending, len(migrator.Migrations))
+
+	_, err = migrator.Up()
+	require.NoError(t, err)
+}
+
+func TestMigrations_UpAndDownFromAnEmptyDatabase(t *testing.T) {
+	db := openEmptySchema(t)
+
+	upAndDown(t, db)
+
+	for _, table := range []string{"users", "destinations", "webhooks", "role_changes"} {
+		assert.True(t, db.Migrator().HasTable(table), table)
+	}
+}
+
+func TestMigrations_UpAndDownFromABaselineAutoMigratedDatabase(t *testing.T) {
+	db := openEmptySchema(t)
+	require.NoError(t, db.AutoMigrate(&baselineLocation{}, &baselineDestination{}, &baselineUser{}))
+	require.NoError(t, db.Create(&baselineUser{
+		Username: "admin", Password: "hash", Email: "admin@example.com", FirstName: "Ada", LastName: "Admin",
+		PhoneNumber: "+40700000001", Role: uint8(entities.Admin),
+	}).Error)
+
+	migrator, err := migrations.NewMigrator(db)
+	require.NoError(t, err)
+	_, err = migrator.Up()
+	require.NoError(t, err)
+
+	var role entities.AccessType
+	require.NoError(t, db.Raw("SELECT access_type FROM users WHERE email = ?", "admin@example.com").Scan(&role).Error)
+	assert.Equal(t, entities.Admin, role)
+
+	for range migrator.Migrations {
+		_, err := migrator.Down()
+		require.NoError(t, err)
+	}
+	assert.False(t, db.Migrator().HasTable("users"))
+
+	upAndDown(t, db)
+}
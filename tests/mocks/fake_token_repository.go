@@ -0,0 +1,119 @@
+package mocks
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"errors"
+	"time"
+)
+
+type FakeTokenRepository struct {
+	RefreshTokens []entities.RefreshToken
+	Revoked       map[string]entities.RevokedToken
+	UserTokens    []entities.UserToken
+}
+
+func NewFakeTokenRepository() *FakeTokenRepository {
+	return &FakeTokenRepository{Revoked: make(map[string]entities.RevokedToken)}
+}
+
+func (r *FakeTokenRepository) CreateRefreshToken(token entities.RefreshToken) (entities.RefreshToken, error) {
+	token.ID = uint(len(r.RefreshTokens) + 1)
+	r.RefreshTokens = append(r.RefreshTokens, token)
+	return token, nil
+}
+
+func (r *FakeTokenRepository) RefreshTokenByHash(hash string) (*entities.RefreshToken, error) {
+	for _, token := range r.RefreshTokens {
+		if token.TokenHash == hash {
+			return &token, nil
+		}
+	}
+	return nil, errors.New("refresh token not found")
+}
+
+func (r *FakeTokenRepository) RevokeRefreshToken(id uint) (bool, error) {
+	now := time.Now()
+	for i := range r.RefreshTokens {
+		if r.RefreshTokens[i].ID == id && r.RefreshTokens[i].RevokedAt == nil {
+			r.RefreshTokens[i].RevokedAt = &now
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *FakeTokenRepository) RevokeRefreshTokenFamily(familyID string) ([]entities.RefreshToken, error) {
+	return r.revoke(func(token entities.RefreshToken) bool { return token.FamilyID == familyID }), nil
+}
+
+func (r *FakeTokenRepository) RevokeUserRefreshTokens(userID uint) ([]entities.RefreshToken, error) {
+	return r.revoke(func(token entities.RefreshToken) bool { return token.UserID == userID }), nil
+}
+
+func (r *FakeTokenRepository) revoke(matches func(token entities.RefreshToken) bool) []entities.RefreshToken {
+	now := time.Now()
+	var revoked []entities.RefreshToken
+	for i := range r.RefreshTokens {
+		if matches(r.RefreshTokens[i]) && r.RefreshTokens[i].RevokedAt == nil {
+			revoked = append(revoked, r.RefreshTokens[i])
+			r.RefreshTokens[i].RevokedAt = &now
+		}
+	}
+	return revoked
+}
+
+func (r *FakeTokenRepository) RevokeAccessTokens(tokens []entities.RevokedToken) error {
+	for _, token := range tokens {
+		r.Revoked[token.TokenID] = token
+	}
+	return nil
+}
+
+func (r *FakeTokenRepository) IsAccessTokenRevoked(tokenID string) (bool, error) {
+	_, ok := r.Revoked[tokenID]
+	return ok, nil
+}
+
+func (r *FakeTokenRepository) DeleteExpiredRevocations(before time.Time) error {
+	for tokenID, token := range r.Revoked {
+		if token.ExpiresAt.Before(before) {
+			delete(r.Revoked, tokenID)
+		}
+	}
+	return nil
+}
+
+func (r *FakeTokenRepository) CreateUserToken(token entities.UserToken) (entities.UserToken, error) {
+	token.ID = uint(len(r.UserTokens) + 1)
+	r.UserTokens = append(r.UserTokens, token)
+	return token, nil
+}
+
+func (r *FakeTokenRepository) UserTokenByHash(hash string) (*entities.UserToken, error) {
+	for _, token := range r.UserTokens {
+		if token.TokenHash == hash && !token.DeletedAt.Valid {
+			return &token, nil
+		}
+	}
+	return nil, errors.New("token not found")
+}
+
+func (r *FakeTokenRepository) UseUserToken(id uint) (bool, error) {
+	for i := range r.UserTokens {
+		if r.UserTokens[i].ID == id && r.UserTokens[i].UsedAt == nil {
+			now := time.Now()
+			r.UserTokens[i].UsedAt = &now
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *FakeTokenRepository) DeleteUserTokens(userID uint, purpose entities.UserTokenPurpose) error {
+	for i := range r.UserTokens {
+		if r.UserTokens[i].UserID == userID && r.UserTokens[i].Purpose == purpose {
+			r.UserTokens[i].DeletedAt.Valid = true
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package mocks
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type MockAuthMiddleware struct {
+	Role   entities.AccessType
+	UserID uint
+	// Unverified makes the caller look like an account whose email is not verified yet.
+	Unverified bool
+}
+
+func (m MockAuthMiddleware) RequireAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		m.setClaims(c)
+		c.Next()
+	}
+}
+
+func (m MockAuthMiddleware) RequirePermission(permission entities.Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !entities.ActivePolicy().Allows(m.Role, permission) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+			c.Abort()
+			return
+		}
+		m.setClaims(c)
+		c.Next()
+	}
+}
+
+func (m MockAuthMiddleware) OptionalAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if m.UserID != 0 {
+			m.setClaims(c)
+		}
+		c.Next()
+	}
+}
+
+func (m MockAuthMiddleware) setClaims(c *gin.Context) {
+	c.Set("userID", float64(m.UserID))
+	c.Set("role", m.Role)
+	c.Set("emailVerified", !m.Unverified)
+}
@@ -0,0 +1,48 @@
+package mocks
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/repositories"
+)
+
+// MockDestinationRepository only implements the methods the service tests need;
+// calling any other method panics through the nil embedded interface.
+type MockDestinationRepository struct {
+	repositories.DestinationRepository
+	QueryDestinationsFunc func(query entities.DestinationQuery) ([]entities.Destination, int64, error)
+	DestinationByIDFunc   func(id uint) (*entities.Destination, error)
+	CreateDestinationFunc func(destination entities.Destination) (entities.Destination, error)
+	UpdateDestinationFunc func(id uint, updatedDestination entities.Destination) (entities.Destination, error)
+	DeleteDestinationFunc func(id uint) (entities.Destination, error)
+
+	DestinationsForLocationFunc        func(locationID uint) ([]entities.Destination, error)
+	DeleteDestinationsByLocationIDFunc func(locationID uint) error
+}
+
+func (m *MockDestinationRepository) QueryDestinations(query entities.DestinationQuery) ([]entities.Destination, int64, error) {
+	return m.QueryDestinationsFunc(query)
+}
+
+func (m *MockDestinationRepository) DestinationByID(id uint) (*entities.Destination, error) {
+	return m.DestinationByIDFunc(id)
+}
+
+func (m *MockDestinationRepository) CreateDestination(destination entities.Destination) (entities.Destination, error) {
+	return m.CreateDestinationFunc(destination)
+}
+
+func (m *MockDestinationRepository) UpdateDestination(id uint, updatedDestination entities.Destination) (entities.Destination, error) {
+	return m.UpdateDestinationFunc(id, updatedDestination)
+}
+
+func (m *MockDestinationRepository) DeleteDestination(id uint) (entities.Destination, error) {
+	return m.DeleteDestinationFunc(id)
+}
+
+func (m *MockDestinationRepository) DestinationsForLocation(locationID uint) ([]entities.Destination, error) {
+	return m.DestinationsForLocationFunc(locationID)
+}
+
+func (m *MockDestinationRepository) DeleteDestinationsByLocationID(locationID uint) error {
+	return m.DeleteDestinationsByLocationIDFunc(locationID)
+}
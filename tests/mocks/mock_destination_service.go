@@ -4,53 +4,57 @@ import (
 	"Trip-Trove-API/domain/entities"
 	"Trip-Trove-API/domain/services"
 	"github.com/jaswdr/faker"
-	"time"
 )
 
 type MockDestinationService struct {
-	AllDestinationsFunc   func() ([]entities.Destination, error)
-	DestinationByIDFunc   func(idStr string) (*entities.Destination, error)
-	CreateDestinationFunc func(destination entities.Destination) (entities.Destination, error)
-	UpdateDestinationFunc func(idStr string, updatedDestination entities.Destination) (entities.Destination, error)
-	DeleteDestinationFunc func(idStr string) (entities.Destination, error)
+	AllDestinationsFunc    func(query entities.DestinationQuery, actor entities.Actor) (*services.DestinationPage, error)
+	SearchDestinationsFunc func(term string, limit int, actor entities.Actor) ([]entities.DestinationSearchResult, error)
+	NearbyDestinationsFunc func(latitude float64, longitude float64, radiusKm float64, limit int, actor entities.Actor) ([]entities.DestinationWithDistance, error)
+	DestinationByIDFunc    func(idStr string, actor entities.Actor) (*entities.Destination, error)
+	CreateDestinationFunc  func(destination entities.Destination, actor entities.Actor) (entities.Destination, error)
+	UpdateDestinationFunc  func(idStr string, updatedDestination entities.Destination, actor entities.Actor) (entities.Destination, error)
+	DeleteDestinationFunc  func(idStr string, actor entities.Actor) (entities.Destination, error)
+	ImportDestinationsFunc func(rows []services.DestinationImportRow, dryRun bool, actor entities.Actor) (*services.ImportReport, error)
 }
 
-func (m *MockDestinationService) DestinationsByLocationID(locationIDStr string) (*services.DestinationsByLocation, error) {
+func (m *MockDestinationService) DestinationsByLocationID(locationIDStr string, actor entities.Actor) (*services.DestinationsByLocation, error) {
 	//TODO implement me
 	panic("implement me")
 }
 
-func (m *MockDestinationService) StartGeneratingDestinations(interval time.Duration, f faker.Faker) {
+func (m *MockDestinationService) GenerateFakeDestination(f faker.Faker, actor entities.Actor) (entities.Destination, error) {
 	//TODO implement me
 	panic("implement me")
 }
 
-func (m *MockDestinationService) StopGeneratingDestinations() {
-	//TODO implement me
-	panic("implement me")
+func (m *MockDestinationService) AllDestinations(query entities.DestinationQuery, actor entities.Actor) (*services.DestinationPage, error) {
+	return m.AllDestinationsFunc(query, actor)
 }
 
-func (m *MockDestinationService) GenerateFakeDestination(f faker.Faker) (entities.Destination, error) {
-	//TODO implement me
-	panic("implement me")
+func (m *MockDestinationService) SearchDestinations(term string, limit int, actor entities.Actor) ([]entities.DestinationSearchResult, error) {
+	return m.SearchDestinationsFunc(term, limit, actor)
+}
+
+func (m *MockDestinationService) NearbyDestinations(latitude float64, longitude float64, radiusKm float64, limit int, actor entities.Actor) ([]entities.DestinationWithDistance, error) {
+	return m.NearbyDestinationsFunc(latitude, longitude, radiusKm, limit, actor)
 }
 
-func (m *MockDestinationService) AllDestinations() ([]entities.Destination, error) {
-	return m.AllDestinationsFunc()
+func (m *MockDestinationService) DestinationByID(idStr string, actor entities.Actor) (*entities.Destination, error) {
+	return m.DestinationByIDFunc(idStr, actor)
 }
 
-func (m *MockDestinationService) DestinationByID(idStr string) (*entities.Destination, error) {
-	return m.DestinationByIDFunc(idStr)
+func (m *MockDestinationService) CreateDestination(destination entities.Destination, actor entities.Actor) (entities.Destination, error) {
+	return m.CreateDestinationFunc(destination, actor)
 }
 
-func (m *MockDestinationService) CreateDestination(destination entities.Destination) (entities.Destination, error) {
-	return m.CreateDestinationFunc(destination)
+func (m *MockDestinationService) UpdateDestination(idStr string, updatedDestination entities.Destination, actor entities.Actor) (entities.Destination, error) {
+	return m.UpdateDestinationFunc(idStr, updatedDestination, actor)
 }
 
-func (m *MockDestinationService) UpdateDestination(idStr string, updatedDestination entities.Destination) (entities.Destination, error) {
-	return m.UpdateDestinationFunc(idStr, updatedDestination)
+func (m *MockDestinationService) DeleteDestination(idStr string, actor entities.Actor) (entities.Destination, error) {
+	return m.DeleteDestinationFunc(idStr, actor)
 }
 
-func (m *MockDestinationService) DeleteDestination(idStr string) (entities.Destination, error) {
-	return m.DeleteDestinationFunc(idStr)
+func (m *MockDestinationService) ImportDestinations(rows []services.DestinationImportRow, dryRun bool, actor entities.Actor) (*services.ImportReport, error) {
+	return m.ImportDestinationsFunc(rows, dryRun, actor)
 }
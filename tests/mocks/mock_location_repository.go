@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/repositories"
+)
+
+// MockLocationRepository only implements the methods the service tests need;
+// calling any other method panics through the nil embedded interface.
+type MockLocationRepository struct {
+	repositories.LocationRepository
+	LocationByIDFunc   func(id uint) (*entities.Location, error)
+	CreateLocationFunc func(location entities.Location) (entities.Location, error)
+	DeleteLocationFunc func(id uint) (entities.Location, error)
+}
+
+func (m *MockLocationRepository) LocationByID(id uint) (*entities.Location, error) {
+	return m.LocationByIDFunc(id)
+}
+
+func (m *MockLocationRepository) CreateLocation(location entities.Location) (entities.Location, error) {
+	return m.CreateLocationFunc(location)
+}
+
+func (m *MockLocationRepository) DeleteLocation(id uint) (entities.Location, error) {
+	return m.DeleteLocationFunc(id)
+}
@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/repositories"
+)
+
+// MockTripRepository only implements the methods the service tests need;
+// calling any other method panics through the nil embedded interface.
+type MockTripRepository struct {
+	repositories.TripRepository
+	TripByIDFunc        func(id uint) (*entities.Trip, error)
+	CreateTripFunc      func(trip entities.Trip) (entities.Trip, error)
+	UpdateStopOrderFunc func(tripID uint, stops []entities.TripStop) error
+}
+
+func (m *MockTripRepository) TripByID(id uint) (*entities.Trip, error) {
+	return m.TripByIDFunc(id)
+}
+
+func (m *MockTripRepository) CreateTrip(trip entities.Trip) (entities.Trip, error) {
+	return m.CreateTripFunc(trip)
+}
+
+func (m *MockTripRepository) UpdateStopOrder(tripID uint, stops []entities.TripStop) error {
+	return m.UpdateStopOrderFunc(tripID, stops)
+}
@@ -0,0 +1,54 @@
+package mocks
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/services"
+)
+
+type MockTripService struct {
+	AllTripsFunc     func(actor entities.Actor) ([]entities.Trip, error)
+	TripByIDFunc     func(idStr string, actor entities.Actor) (*entities.Trip, error)
+	CreateTripFunc   func(trip entities.Trip, actor entities.Actor) (entities.Trip, error)
+	UpdateTripFunc   func(idStr string, trip entities.Trip, actor entities.Actor) (entities.Trip, error)
+	DeleteTripFunc   func(idStr string, actor entities.Actor) (entities.Trip, error)
+	AddStopFunc      func(idStr string, stop entities.TripStop, actor entities.Actor) (entities.TripStop, error)
+	DeleteStopFunc   func(idStr string, stopIDStr string, actor entities.Actor) (entities.TripStop, error)
+	ReorderStopsFunc func(idStr string, order []entities.TripStopOrder, actor entities.Actor) (*entities.Trip, error)
+	TripSummaryFunc  func(idStr string, actor entities.Actor) (*services.TripSummary, error)
+}
+
+func (m *MockTripService) AllTrips(actor entities.Actor) ([]entities.Trip, error) {
+	return m.AllTripsFunc(actor)
+}
+
+func (m *MockTripService) TripByID(idStr string, actor entities.Actor) (*entities.Trip, error) {
+	return m.TripByIDFunc(idStr, actor)
+}
+
+func (m *MockTripService) CreateTrip(trip entities.Trip, actor entities.Actor) (entities.Trip, error) {
+	return m.CreateTripFunc(trip, actor)
+}
+
+func (m *MockTripService) UpdateTrip(idStr string, trip entities.Trip, actor entities.Actor) (entities.Trip, error) {
+	return m.UpdateTripFunc(idStr, trip, actor)
+}
+
+func (m *MockTripService) DeleteTrip(idStr string, actor entities.Actor) (entities.Trip, error) {
+	return m.DeleteTripFunc(idStr, actor)
+}
+
+func (m *MockTripService) AddStop(idStr string, stop entities.TripStop, actor entities.Actor) (entities.TripStop, error) {
+	return m.AddStopFunc(idStr, stop, actor)
+}
+
+func (m *MockTripService) DeleteStop(idStr string, stopIDStr string, actor entities.Actor) (entities.TripStop, error) {
+	return m.DeleteStopFunc(idStr, stopIDStr, actor)
+}
+
+func (m *MockTripService) ReorderStops(idStr string, order []entities.TripStopOrder, actor entities.Actor) (*entities.Trip, error) {
+	return m.ReorderStopsFunc(idStr, order, actor)
+}
+
+func (m *MockTripService) TripSummary(idStr string, actor entities.Actor) (*services.TripSummary, error) {
+	return m.TripSummaryFunc(idStr, actor)
+}
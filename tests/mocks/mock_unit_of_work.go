@@ -0,0 +1,20 @@
+package mocks
+
+import "Trip-Trove-API/domain/repositories"
+
+// MockUnitOfWork hands Repos to the callback and records whether the unit
+// would have been committed or rolled back.
+type MockUnitOfWork struct {
+	Repos      repositories.Repositories
+	Committed  bool
+	RolledBack bool
+}
+
+func (m *MockUnitOfWork) Do(fn func(repos repositories.Repositories) error) error {
+	if err := fn(m.Repos); err != nil {
+		m.RolledBack = true
+		return err
+	}
+	m.Committed = true
+	return nil
+}
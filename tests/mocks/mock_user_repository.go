@@ -0,0 +1,22 @@
+package mocks
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/repositories"
+)
+
+// MockUserRepository only implements the methods the service tests need;
+// calling any other method panics through the nil embedded interface.
+type MockUserRepository struct {
+	repositories.UserRepository
+	UserByIDFunc     func(id uint) (*entities.User, error)
+	AuthenticateFunc func(loginData entities.LoginRequest) (*entities.User, error)
+}
+
+func (m *MockUserRepository) UserByID(id uint) (*entities.User, error) {
+	return m.UserByIDFunc(id)
+}
+
+func (m *MockUserRepository) Authenticate(loginData entities.LoginRequest) (*entities.User, error) {
+	return m.AuthenticateFunc(loginData)
+}
@@ -0,0 +1,40 @@
+package mocks
+
+import (
+	"Trip-Trove-API/domain/mail"
+	"regexp"
+	"sync"
+)
+
+type RecordingMailer struct {
+	mu       sync.Mutex
+	Messages []mail.Message
+	Err      error
+}
+
+func (m *RecordingMailer) Send(message mail.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Err != nil {
+		return m.Err
+	}
+	m.Messages = append(m.Messages, message)
+	return nil
+}
+
+var linkToken = regexp.MustCompile(`[?&]token=([0-9a-f]+)`)
+
+// LastToken returns the token in the link of the last message sent, or "".
+func (m *RecordingMailer) LastToken() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.Messages) == 0 {
+		return ""
+	}
+	match := linkToken.FindStringSubmatch(m.Messages[len(m.Messages)-1].Text)
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
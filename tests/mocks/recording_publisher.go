@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"Trip-Trove-API/domain/events"
+	"sync"
+)
+
+type RecordingPublisher struct {
+	mu     sync.Mutex
+	Events []events.Event
+}
+
+func (p *RecordingPublisher) Publish(event events.Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.Events = append(p.Events, event)
+}
+
+func (p *RecordingPublisher) Types() []events.Type {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	types := make([]events.Type, 0, len(p.Events))
+	for _, event := range p.Events {
+		types = append(types, event.Type)
+	}
+	return types
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/repositories"
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/infrastructure/dataaccess"
+	"Trip-Trove-API/tests/mocks"
+	"Trip-Trove-API/utils"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func newAccountService() (*services.UserService, repositories.Repositories, *mocks.RecordingMailer) {
+	repos := dataaccess.NewMemoryRepositories(dataaccess.NewMemoryStore())
+	mailer := &mocks.RecordingMailer{}
+	return &services.UserService{
+		Repo:        repos.Users,
+		TokenRepo:   repos.Tokens,
+		Jwt:         utils.JwtWrapper{SecretKey: "test-secret", Issuer: "AuthService", ExpirationMinutes: 60, ExpirationHours: 24},
+		Mailer:      mailer,
+		LinkBaseURL: "https://triptrove.example/",
+	}, repos, mailer
+}
+
+func registerTraveller(t *testing.T, service *services.UserService) entities.User {
+	user, err := service.Register(entities.User{
+		Username:    "traveller",
+		Password:    "Secret-pass-1!",
+		Email:       "traveller@example.com",
+		FirstName:   "Ana",
+		LastName:    "Silva",
+		PhoneNumber: "+40700000001",
+	})
+	require.NoError(t, err)
+	return user
+}
+
+func TestRegister_SendsAVerificationLinkThatWorksOnce(t *testing.T) {
+	service, repos, mailer := newAccountService()
+	user := registerTraveller(t, service)
+	assert.False(t, user.EmailVerified())
+
+	require.Len(t, mailer.Messages, 1)
+	message := mailer.Messages[0]
+	assert.Equal(t, "traveller@example.com", message.To)
+	assert.Equal(t, "Confirm your email address", message.Subject)
+	assert.Contains(t, message.Text, "Hi Ana,")
+	assert.Contains(t, message.Text, "https://triptrove.example/verify-email?token=")
+	assert.Contains(t, message.Text, "48 hours")
+
+	token := mailer.LastToken()
+	assert.True(t, errors.Is(service.ResetPassword(token, "Brand-new-pass-2!"), services.ErrInvalidAccountToken), "a verification token cannot reset the password")
+
+	require.NoError(t, service.VerifyEmail(token))
+	stored, err := repos.Users.UserByID(user.ID)
+	require.NoError(t, err)
+	assert.True(t, stored.EmailVerified())
+
+	assert.True(t, errors.Is(service.VerifyEmail(token), services.ErrInvalidAccountToken), "tokens are single-use")
+	assert.True(t, errors.Is(service.SendEmailVerification(user.ID), services.ErrEmailAlreadyVerified))
+}
+
+func TestRegister_SucceedsWhenTheMailCannotBeSent(t *testing.T) {
+	service, _, mailer := newAccountService()
+	mailer.Err = errors.New("smtp is down")
+
+	registerTraveller(t, service)
+}
+
+func TestSendEmailVerification_ReplacesEarlierLinks(t *testing.T) {
+	service, _, mailer := newAccountService()
+	user := registerTraveller(t, service)
+	first := mailer.LastToken()
+
+	require.NoError(t, service.SendEmailVerification(user.ID))
+	second := mailer.LastToken()
+	require.NotEqual(t, first, second)
+
+	assert.True(t, errors.Is(service.VerifyEmail(first), services.ErrInvalidAccountToken))
+	assert.NoError(t, service.VerifyEmail(second))
+}
+
+func TestUpdateProfile_ChangingTheEmailNeedsANewVerification(t *testing.T) {
+	service, repos, mailer := newAccountService()
+	user := registerTraveller(t, service)
+	oldToken := mailer.LastToken()
+
+	updated, err := service.UpdateProfile(user.ID, entities.User{Email: "new@example.com"})
+	require.NoError(t, err)
+	assert.False(t, updated.EmailVerified())
+	require.Len(t, mailer.Messages, 2)
+	assert.Equal(t, "new@example.com", mailer.Messages[1].To)
+
+	assert.True(t, errors.Is(service.VerifyEmail(oldToken), services.ErrInvalidAccountToken))
+
+	require.NoError(t, service.VerifyEmail(mailer.LastToken()))
+	_, err = service.UpdateProfile(user.ID, entities.User{Address: "Porto"})
+	require.NoError(t, err)
+	stored, err := repos.Users.UserByID(user.ID)
+	require.NoError(t, err)
+	assert.True(t, stored.EmailVerified(), "other edits keep the verification")
+}
+
+func TestResetPassword(t *testing.T) {
+	service, repos, mailer := newAccountService()
+	user := registerTraveller(t, service)
+	login, err := service.Login(entities.LoginRequest{Email: "traveller@example.com", Password: "Secret-pass-1!"})
+	require.NoError(t, err)
+
+	require.NoError(t, service.RequestPasswordReset("nobody@example.com"))
+	assert.Len(t, mailer.Messages, 1, "unknown addresses get no mail and no error")
+
+	require.NoError(t, service.RequestPasswordReset("traveller@example.com"))
+	require.Len(t, mailer.Messages, 2)
+	assert.Equal(t, "Reset your Trip Trove password", mailer.Messages[1].Subject)
+	assert.Contains(t, mailer.Messages[1].Text, "https://triptrove.example/reset-password?token=")
+	token := mailer.LastToken()
+
+	require.NoError(t, service.ResetPassword(token, "Brand-new-pass-2!"))
+
+	_, err = repos.Users.Authenticate(entities.LoginRequest{Email: "traveller@example.com", Password: "Brand-new-pass-2!"})
+	assert.NoError(t, err)
+	_, err = service.Refresh(login.RefreshToken)
+	assert.True(t, errors.Is(err, services.ErrInvalidRefreshToken), "a reset ends every session")
+	stored, err := repos.Users.UserByID(user.ID)
+	require.NoError(t, err)
+	assert.True(t, stored.EmailVerified(), "receiving the reset link proves the address")
+
+	err = service.ResetPassword(token, "Another-pass-3!")
+	assert.Equal(t, apperrors.KindValidation, apperrors.KindOf(err))
+	assert.Equal(t, "token", apperrors.FieldsOf(err)[0].Field)
+}
+
+func TestChangePassword_EndsEverySession(t *testing.T) {
+	service, repos, _ := newAccountService()
+	user := registerTraveller(t, service)
+	here, err := service.Login(entities.LoginRequest{Email: "traveller@example.com", Password: "Secret-pass-1!"})
+	require.NoError(t, err)
+	elsewhere, err := service.Login(entities.LoginRequest{Email: "traveller@example.com", Password: "Secret-pass-1!"})
+	require.NoError(t, err)
+
+	require.NoError(t, service.ChangePassword(user.ID, "Secret-pass-1!", "Brand-new-pass-2!"))
+
+	for _, login := range []entities.LoginResponse{here, elsewhere} {
+		_, err = service.Refresh(login.RefreshToken)
+		assert.True(t, errors.Is(err, services.ErrInvalidRefreshToken), "a leaked refresh token stops working")
+
+		claims, err := service.Jwt.ValidateToken(login.Jwt)
+		require.NoError(t, err)
+		revoked, err := repos.Tokens.IsAccessTokenRevoked(claims.Id)
+		require.NoError(t, err)
+		assert.True(t, revoked)
+	}
+}
+
+func TestResetPassword_RejectsExpiredTokens(t *testing.T) {
+	service, repos, _ := newAccountService()
+	user := registerTraveller(t, service)
+
+	_, err := repos.Tokens.CreateUserToken(entities.UserToken{
+		UserID:    user.ID,
+		Purpose:   entities.PasswordResetToken,
+		TokenHash: utils.HashToken("expired"),
+		Email:     user.Email,
+		ExpiresAt: time.Now().Add(-time.Minute),
+	})
+	require.NoError(t, err)
+
+	assert.True(t, errors.Is(service.ResetPassword("expired", "Brand-new-pass-2!"), services.ErrInvalidAccountToken))
+	assert.True(t, errors.Is(service.ResetPassword("never-issued", "Brand-new-pass-2!"), services.ErrInvalidAccountToken))
+}
+
+func TestRequireVerifiedEmail_GuardsTripCreation(t *testing.T) {
+	service := newTripTestService(&mocks.MockTripRepository{})
+	unverified := tripOwner
+	unverified.EmailVerified = false
+
+	_, err := service.CreateTrip(*lappishTrip(), unverified)
+	assert.True(t, errors.Is(err, services.ErrEmailNotVerified))
+	_, err = service.AddStop("5", entities.TripStop{DestinationID: 10, Day: 1}, unverified)
+	assert.True(t, errors.Is(err, services.ErrEmailNotVerified))
+}
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/events"
+	"Trip-Trove-API/domain/repositories"
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/infrastructure/dataaccess"
+	"Trip-Trove-API/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+var importManager = entities.Actor{UserID: 7, Role: entities.Manager, Authenticated: true}
+
+func newImportService() (*services.DestinationService, repositories.Repositories, *mocks.RecordingPublisher) {
+	store := dataaccess.NewMemoryStore()
+	repos := dataaccess.NewMemoryRepositories(store)
+	publisher := &mocks.RecordingPublisher{}
+	return &services.DestinationService{
+		Repo:         repos.Destinations,
+		LocationRepo: repos.Locations,
+		UnitOfWork:   dataaccess.NewMemoryUnitOfWork(store),
+		Events:       publisher,
+	}, repos, publisher
+}
+
+func parseImport(t *testing.T, format services.ImportFormat, body string) []services.DestinationImportRow {
+	rows, err := services.ParseDestinationImport(strings.NewReader(body), format)
+	require.NoError(t, err)
+	return rows
+}
+
+func rowStatuses(report *services.ImportReport) []services.ImportRowStatus {
+	statuses := make([]services.ImportRowStatus, 0, len(report.Rows))
+	for _, row := range report.Rows {
+		statuses = append(statuses, row.Status)
+	}
+	return statuses
+}
+
+const importCSV = `name,location,country,description,visitors_last_year,is_private,latitude,longitude
+Belem Tower,Lisbon,Portugal,A fortified tower on the Tagus,1200,false,38.6916,-9.2160
+Livraria Lello,Porto,Portugal,One of the oldest bookshops,abc,false,,
+Jeronimos,Lisbon,Portugal,short,10,false,,
+Belem Tower,Lisbon,Portugal,The same name a second time,10,false,,
+Sagrada Familia,Barcelona,Spain,Gaudi's unfinished basilica,4500,true,,
+`
+
+func TestImportDestinations_ReportsEveryRow(t *testing.T) {
+	service, repos, publisher := newImportService()
+
+	report, err := service.ImportDestinations(parseImport(t, services.ImportCSV, importCSV), false, importManager)
+	require.NoError(t, err)
+
+	assert.Equal(t, []services.ImportRowStatus{
+		services.ImportCreated, services.ImportRejected, services.ImportRejected, services.ImportRejected, services.ImportCreated,
+	}, rowStatuses(report))
+	assert.Equal(t, 2, report.Created)
+	assert.Equal(t, 3, report.Rejected)
+	assert.Equal(t, 2, report.LocationsCreated)
+	assert.Equal(t, 2, report.Rows[0].Line)
+
+	assert.Equal(t, "visitors_last_year", report.Rows[1].Errors[0].Field)
+	assert.Equal(t, "description", report.Rows[2].Errors[0].Field)
+	assert.Contains(t, report.Rows[3].Errors[0].Message, "line 2")
+
+	belem, err := repos.Destinations.DestinationByName("Belem Tower")
+	require.NoError(t, err)
+	assert.Equal(t, report.Rows[0].DestinationID, belem.ID)
+	require.NotNil(t, belem.OwnerID)
+	assert.Equal(t, uint(7), *belem.OwnerID)
+	require.NotNil(t, belem.Latitude)
+	assert.Equal(t, 38.6916, *belem.Latitude)
+
+	_, err = repos.Locations.LocationByName("Porto")
+	assert.Equal(t, apperrors.KindNotFound, apperrors.KindOf(err), "rejected rows create no location")
+
+	assert.Equal(t, []events.Type{
+		events.LocationCreated, events.DestinationCreated, events.LocationCreated, events.DestinationCreated,
+	}, publisher.Types())
+}
+
+func TestImportDestinations_UpdatesExistingDestinations(t *testing.T) {
+	service, repos, publisher := newImportService()
+	lisbon, err := repos.Locations.CreateLocation(entities.Location{Name: "Lisbon", Country: "Portugal"})
+	require.NoError(t, err)
+	owner := uint(7)
+	_, err = repos.Destinations.CreateDestination(entities.Destination{Name: "Belem Tower", LocationID: lisbon.ID, Description: "Old description", OwnerID: &owner})
+	require.NoError(t, err)
+	other := uint(8)
+	_, err = repos.Destinations.CreateDestination(entities.Destination{Name: "Jeronimos", LocationID: lisbon.ID, Description: "Not ours to change", OwnerID: &other})
+	require.NoError(t, err)
+
+	rows := parseImport(t, services.ImportJSONL, `
+{"name": "Belem Tower", "location": "Lisbon", "country": "portugal", "description": "A fortified tower on the Tagus", "visitors_last_year": 1500}
+{"name": "Jeronimos", "location": "Lisbon", "country": "Portugal", "description": "A monastery next door"}
+{"name": "Oceanario", "location": "Lisbon", "country": "Spain", "description": "A large aquarium"}
+{"name": "Cristo Rei", "location": "Almada", "country": "Portugal", "description": "A statue", "height": 110}
+`)
+	require.Len(t, rows, 4)
+	assert.Equal(t, 5, rows[3].Line, "blank lines are counted")
+
+	report, err := service.ImportDestinations(rows, false, importManager)
+	require.NoError(t, err)
+	assert.Equal(t, []services.ImportRowStatus{
+		services.ImportUpdated, services.ImportRejected, services.ImportRejected, services.ImportRejected,
+	}, rowStatuses(report))
+	assert.Equal(t, "forbidden", report.Rows[1].Errors[0].Rule)
+	assert.Equal(t, "country", report.Rows[2].Errors[0].Field)
+	assert.Contains(t, report.Rows[3].Errors[0].Message, "height")
+
+	belem, err := repos.Destinations.DestinationByName("Belem Tower")
+	require.NoError(t, err)
+	assert.Equal(t, 1500, belem.VisitorsLastYear)
+	assert.Equal(t, "A fortified tower on the Tagus", belem.Description)
+	assert.Equal(t, []events.Type{events.DestinationUpdated}, publisher.Types())
+}
+
+func TestImportDestinations_UpdateCanMakeAPrivateDestinationPublic(t *testing.T) {
+	service, repos, _ := newImportService()
+	lisbon, err := repos.Locations.CreateLocation(entities.Location{Name: "Lisbon", Country: "Portugal"})
+	require.NoError(t, err)
+	owner := importManager.UserID
+	_, err = repos.Destinations.CreateDestination(entities.Destination{
+		Name: "Hidden Garden", LocationID: lisbon.ID, Description: "A quiet garden near the river",
+		ImageUrl: "https://example.com/garden.jpg", VisitorsLastYear: 20, IsPrivate: true, OwnerID: &owner,
+	})
+	require.NoError(t, err)
+
+	rows := parseImport(t, services.ImportCSV, `name,location,country,description,visitors_last_year,is_private
+Hidden Garden,Lisbon,Portugal,A garden open to everyone,0,false
+`)
+	report, err := service.ImportDestinations(rows, false, importManager)
+	require.NoError(t, err)
+	assert.Equal(t, []services.ImportRowStatus{services.ImportUpdated}, rowStatuses(report))
+
+	garden, err := repos.Destinations.DestinationByName("Hidden Garden")
+	require.NoError(t, err)
+	assert.False(t, garden.IsPrivate)
+	assert.Zero(t, garden.VisitorsLastYear)
+	assert.Empty(t, garden.ImageUrl, "the row replaces the whole destination")
+	assert.Equal(t, "A garden open to everyone", garden.Description)
+	require.NotNil(t, garden.OwnerID)
+	assert.Equal(t, owner, *garden.OwnerID)
+}
+
+func TestImportDestinations_DryRunKeepsNothing(t *testing.T) {
+	service, repos, publisher := newImportService()
+
+	report, err := service.ImportDestinations(parseImport(t, services.ImportCSV, importCSV), true, importManager)
+	require.NoError(t, err)
+
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 2, report.Created)
+	assert.Equal(t, 2, report.LocationsCreated)
+	assert.Zero(t, report.Rows[0].DestinationID)
+
+	destinations, err := repos.Destinations.AllDestinations()
+	require.NoError(t, err)
+	assert.Empty(t, destinations)
+	locations, err := repos.Locations.AllLocations()
+	require.NoError(t, err)
+	assert.Empty(t, locations)
+	assert.Empty(t, publisher.Events)
+}
+
+func TestParseDestinationImport_RejectsBadFiles(t *testing.T) {
+	for name, body := range map[string]string{
+		"empty":          "",
+		"header only":    "name,location,country\n",
+		"unknown column": "name,location,country,rating\nBelem Tower,Lisbon,Portugal,5\n",
+		"missing column": "name,location\nBelem Tower,Lisbon\n",
+		"bare quote":     "name,location,country\nBelem \"Tower,Lisbon,Portugal\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := services.ParseDestinationImport(strings.NewReader(body), services.ImportCSV)
+			assert.Equal(t, apperrors.KindValidation, apperrors.KindOf(err))
+		})
+	}
+
+	rows, err := services.ParseDestinationImport(strings.NewReader("name,location,country\nBelem Tower,Lisbon\n"), services.ImportCSV)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "columns", rows[0].Errors[0].Rule, "a short row is rejected on its own")
+
+	_, err = services.ParseImportFormat("xml")
+	assert.Equal(t, apperrors.KindValidation, apperrors.KindOf(err))
+}
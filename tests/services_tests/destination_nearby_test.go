@@ -0,0 +1,27 @@
+package services
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestNearbyDestinations_RejectsNonFiniteNumbers(t *testing.T) {
+	// The mock panics if a query reaches the repository.
+	service := services.DestinationService{Repo: &mocks.MockDestinationRepository{}}
+
+	for name, query := range map[string][3]float64{
+		"NaN latitude":       {math.NaN(), 25.7, 10},
+		"NaN longitude":      {66.5, math.NaN(), 10},
+		"NaN radius":         {66.5, 25.7, math.NaN()},
+		"infinite latitude":  {math.Inf(1), 25.7, 10},
+		"infinite longitude": {66.5, math.Inf(-1), 10},
+		"infinite radius":    {66.5, 25.7, math.Inf(1)},
+	} {
+		_, err := service.NearbyDestinations(query[0], query[1], query[2], 10, entities.Anonymous)
+		assert.ErrorIs(t, err, services.ErrInvalidQuery, name)
+	}
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/tests/mocks"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func ownedDestination(ownerID uint, isPrivate bool) *entities.Destination {
+	return &entities.Destination{
+		Model:       gorm.Model{ID: 1},
+		Name:        "Hidden Lagoon",
+		LocationID:  1,
+		Description: "A lagoon only the owner knows about.",
+		IsPrivate:   isPrivate,
+		OwnerID:     &ownerID,
+	}
+}
+
+func TestDestinationByID_PrivateHiddenFromOthers(t *testing.T) {
+	service := services.DestinationService{Repo: &mocks.MockDestinationRepository{
+		DestinationByIDFunc: func(id uint) (*entities.Destination, error) {
+			return ownedDestination(7, true), nil
+		},
+	}}
+
+	_, err := service.DestinationByID("1", entities.Anonymous)
+	assert.EqualError(t, err, "destination not found")
+
+	_, err = service.DestinationByID("1", entities.Actor{UserID: 8, Role: entities.Manager, Authenticated: true})
+	assert.EqualError(t, err, "destination not found")
+
+	destination, err := service.DestinationByID("1", entities.Actor{UserID: 7, Role: entities.NormalUser, Authenticated: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "Hidden Lagoon", destination.Name)
+
+	destination, err = service.DestinationByID("1", entities.Actor{UserID: 1, Role: entities.Admin, Authenticated: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "Hidden Lagoon", destination.Name)
+}
+
+func TestAllDestinations_AppliesVisibility(t *testing.T) {
+	var received entities.DestinationQuery
+	service := services.DestinationService{Repo: &mocks.MockDestinationRepository{
+		QueryDestinationsFunc: func(query entities.DestinationQuery) ([]entities.Destination, int64, error) {
+			received = query
+			return nil, 0, nil
+		},
+	}}
+
+	_, err := service.AllDestinations(entities.DestinationQuery{}, entities.Anonymous)
+	assert.NoError(t, err)
+	assert.False(t, received.Filter.Visibility.IncludePrivate)
+	assert.Nil(t, received.Filter.Visibility.OwnerID)
+
+	_, err = service.AllDestinations(entities.DestinationQuery{}, entities.Actor{UserID: 7, Role: entities.Manager, Authenticated: true})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), *received.Filter.Visibility.OwnerID)
+
+	_, err = service.AllDestinations(entities.DestinationQuery{}, entities.Actor{UserID: 1, Role: entities.Admin, Authenticated: true})
+	assert.NoError(t, err)
+	assert.True(t, received.Filter.Visibility.IncludePrivate)
+}
+
+func TestUpdateDestination_ManagerMustOwnDestination(t *testing.T) {
+	updated := false
+	service := services.DestinationService{Repo: &mocks.MockDestinationRepository{
+		DestinationByIDFunc: func(id uint) (*entities.Destination, error) {
+			return ownedDestination(7, false), nil
+		},
+		UpdateDestinationFunc: func(id uint, destination entities.Destination) (entities.Destination, error) {
+			updated = true
+			assert.Nil(t, destination.OwnerID, "owner must not be changed through updates")
+			return destination, nil
+		},
+	}}
+
+	_, err := service.UpdateDestination("1", entities.Destination{Name: "Renamed", OwnerID: new(uint)}, entities.Actor{UserID: 8, Role: entities.Manager, Authenticated: true})
+	assert.True(t, errors.Is(err, services.ErrForbidden))
+	assert.False(t, updated)
+
+	_, err = service.UpdateDestination("1", entities.Destination{Name: "Renamed", OwnerID: new(uint)}, entities.Actor{UserID: 7, Role: entities.Manager, Authenticated: true})
+	assert.NoError(t, err)
+	assert.True(t, updated)
+}
+
+func TestDeleteDestination_AdminCanDeleteAnyDestination(t *testing.T) {
+	service := services.DestinationService{Repo: &mocks.MockDestinationRepository{
+		DestinationByIDFunc: func(id uint) (*entities.Destination, error) {
+			return ownedDestination(7, true), nil
+		},
+		DeleteDestinationFunc: func(id uint) (entities.Destination, error) {
+			return *ownedDestination(7, true), nil
+		},
+	}}
+
+	_, err := service.DeleteDestination("1", entities.Actor{UserID: 8, Role: entities.Manager, Authenticated: true})
+	assert.EqualError(t, err, "destination not found")
+
+	_, err = service.DeleteDestination("1", entities.Actor{UserID: 1, Role: entities.Admin, Authenticated: true})
+	assert.NoError(t, err)
+}
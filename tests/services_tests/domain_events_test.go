@@ -0,0 +1,150 @@
+package services
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/events"
+	"Trip-Trove-API/domain/repositories"
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/presentation/dto"
+	"Trip-Trove-API/tests/mocks"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+func TestDestinationService_PublishesEventsForEveryMutation(t *testing.T) {
+	publisher := &mocks.RecordingPublisher{}
+	manager := entities.Actor{UserID: 7, Role: entities.Manager, Authenticated: true}
+	service := services.DestinationService{
+		Repo: &mocks.MockDestinationRepository{
+			DestinationByIDFunc: func(id uint) (*entities.Destination, error) {
+				return ownedDestination(7, false), nil
+			},
+			CreateDestinationFunc: func(destination entities.Destination) (entities.Destination, error) {
+				destination.ID = 1
+				return destination, nil
+			},
+			UpdateDestinationFunc: func(id uint, updatedDestination entities.Destination) (entities.Destination, error) {
+				return *ownedDestination(7, false), nil
+			},
+			DeleteDestinationFunc: func(id uint) (entities.Destination, error) {
+				return *ownedDestination(7, false), nil
+			},
+		},
+		LocationRepo: &mocks.MockLocationRepository{
+			LocationByIDFunc: func(id uint) (*entities.Location, error) {
+				return &entities.Location{Model: gorm.Model{ID: id}}, nil
+			},
+		},
+		Events: publisher,
+	}
+
+	_, err := service.CreateDestination(entities.Destination{Name: "Hidden Lagoon", LocationID: 1}, manager)
+	require.NoError(t, err)
+	_, err = service.UpdateDestination("1", entities.Destination{Name: "Lagoon"}, manager)
+	require.NoError(t, err)
+	_, err = service.DeleteDestination("1", manager)
+	require.NoError(t, err)
+
+	assert.Equal(t, []events.Type{events.DestinationCreated, events.DestinationUpdated, events.DestinationDeleted}, publisher.Types())
+	for _, event := range publisher.Events {
+		assert.Equal(t, events.EntityDestination, event.Entity)
+		assert.Equal(t, manager, event.Actor)
+		assert.Equal(t, uint(1), event.Destination.ID)
+		assert.False(t, event.OccurredAt.IsZero())
+	}
+}
+
+func TestDestinationService_DoesNotPublishFailedMutations(t *testing.T) {
+	publisher := &mocks.RecordingPublisher{}
+	service := services.DestinationService{
+		Repo: &mocks.MockDestinationRepository{
+			DestinationByIDFunc: func(id uint) (*entities.Destination, error) {
+				return ownedDestination(7, false), nil
+			},
+		},
+		Events: publisher,
+	}
+
+	_, err := service.DeleteDestination("1", entities.Actor{UserID: 8, Role: entities.Manager, Authenticated: true})
+
+	assert.ErrorIs(t, err, services.ErrForbidden)
+	assert.Empty(t, publisher.Events)
+}
+
+func TestLocationService_PublishesEventsAfterCommit(t *testing.T) {
+	publisher := &mocks.RecordingPublisher{}
+	admin := entities.Actor{UserID: 1, Role: entities.Admin, Authenticated: true}
+	locations := &mocks.MockLocationRepository{
+		CreateLocationFunc: func(location entities.Location) (entities.Location, error) {
+			location.ID = 3
+			return location, nil
+		},
+		DeleteLocationFunc: func(id uint) (entities.Location, error) {
+			return entities.Location{Model: gorm.Model{ID: id}, Name: "Lisbon"}, nil
+		},
+	}
+	unitOfWork := &mocks.MockUnitOfWork{Repos: repositories.Repositories{
+		Destinations: &mocks.MockDestinationRepository{
+			DestinationsForLocationFunc: func(locationID uint) ([]entities.Destination, error) {
+				return []entities.Destination{{Model: gorm.Model{ID: 5}, Name: "Belem Tower", LocationID: locationID}}, nil
+			},
+			DeleteDestinationsByLocationIDFunc: func(locationID uint) error {
+				return nil
+			},
+		},
+		Locations: locations,
+	}}
+	service := services.LocationService{Repo: locations, UnitOfWork: unitOfWork, Events: publisher}
+
+	_, err := service.CreateLocation(entities.Location{Name: "Lisbon", Country: "Portugal"}, admin)
+	require.NoError(t, err)
+	_, err = service.DeleteLocation("3", admin)
+	require.NoError(t, err)
+
+	assert.Equal(t, []events.Type{events.LocationCreated, events.DestinationDeleted, events.LocationDeleted}, publisher.Types())
+	assert.Equal(t, "Belem Tower", publisher.Events[1].Destination.Name)
+	assert.Equal(t, "Lisbon", publisher.Events[2].Location.Name)
+	assert.Equal(t, admin, publisher.Events[2].Actor)
+}
+
+func TestLocationService_RolledBackDeletePublishesNothing(t *testing.T) {
+	publisher := &mocks.RecordingPublisher{}
+	unitOfWork := &mocks.MockUnitOfWork{Repos: repositories.Repositories{
+		Destinations: &mocks.MockDestinationRepository{
+			DestinationsForLocationFunc: noDestinations,
+			DeleteDestinationsByLocationIDFunc: func(locationID uint) error {
+				return errors.New("connection reset")
+			},
+		},
+	}}
+	service := services.LocationService{UnitOfWork: unitOfWork, Events: publisher}
+
+	_, err := service.DeleteLocation("3", locationManager)
+
+	assert.Error(t, err)
+	assert.Empty(t, publisher.Events)
+}
+
+func TestEnvelope_DataHasTheShapeOfTheResponseDTOs(t *testing.T) {
+	latitude, longitude, ownerID := 38.69, -9.21, uint(7)
+	model := gorm.Model{ID: 5, CreatedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), UpdatedAt: time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC), DeletedAt: gorm.DeletedAt{Time: time.Now(), Valid: true}}
+	destination := entities.Destination{Model: model, Name: "Belem Tower", LocationID: 3, Description: "A fortified tower", IsPrivate: true, Latitude: &latitude, Longitude: &longitude, OwnerID: &ownerID}
+	location := entities.Location{Model: model, Name: "Lisbon", Country: "Portugal", Latitude: &latitude, Longitude: &longitude}
+
+	for name, pair := range map[string][2]interface{}{
+		"destination": {events.NewDestinationEvent(events.DestinationCreated, destination, entities.Anonymous).Envelope().Data, dto.NewDestinationResponse(destination)},
+		"location":    {events.NewLocationEvent(events.LocationCreated, location, entities.Anonymous).Envelope().Data, dto.NewLocationResponse(location)},
+	} {
+		envelopeData, err := json.Marshal(pair[0])
+		require.NoError(t, err)
+		response, err := json.Marshal(pair[1])
+		require.NoError(t, err)
+		assert.JSONEq(t, string(response), string(envelopeData), name)
+		assert.NotContains(t, string(envelopeData), "DeletedAt", name)
+	}
+}
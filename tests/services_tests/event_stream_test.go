@@ -0,0 +1,125 @@
+package services
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/events"
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/infrastructure/dataaccess"
+	"Trip-Trove-API/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+func newEventStream(t *testing.T, retention uint64, subscribers ...events.Publisher) (*services.EventStream, *mocks.RecordingPublisher) {
+	subscriber := &mocks.RecordingPublisher{}
+	stream := &services.EventStream{
+		Log:         dataaccess.NewMemoryEventLogRepository(dataaccess.NewMemoryStore()),
+		Subscribers: append([]events.Publisher{subscriber}, subscribers...),
+		Retention:   retention,
+	}
+	t.Cleanup(stream.Close)
+	return stream, subscriber
+}
+
+func destinationCreated(id uint) events.Event {
+	return events.NewDestinationEvent(events.DestinationCreated, entities.Destination{
+		Model: gorm.Model{ID: id},
+		Name:  "Destination",
+	}, entities.Anonymous)
+}
+
+// publishDestinations publishes count events and waits until they were delivered.
+func publishDestinations(stream *services.EventStream, count int) {
+	for i := 1; i <= count; i++ {
+		stream.Publish(destinationCreated(uint(i)))
+	}
+	stream.Flush()
+}
+
+func TestEventStream_NumbersEventsBeforeDelivering(t *testing.T) {
+	stream, subscriber := newEventStream(t, 0)
+
+	publishDestinations(stream, 3)
+
+	require.Len(t, subscriber.Events, 3)
+	for i, event := range subscriber.Events {
+		assert.Equal(t, uint64(i+1), event.Sequence)
+	}
+}
+
+func TestEventStream_ReplaysMissedEvents(t *testing.T) {
+	stream, _ := newEventStream(t, 0)
+	publishDestinations(stream, 5)
+
+	missed, err := stream.EventsSince(3)
+
+	require.NoError(t, err)
+	require.Len(t, missed, 2)
+	assert.Equal(t, uint64(4), missed[0].Sequence)
+	assert.Equal(t, uint(4), missed[0].Destination.ID)
+	assert.Equal(t, events.DestinationCreated, missed[0].Type)
+
+	upToDate, err := stream.EventsSince(5)
+	require.NoError(t, err)
+	assert.Empty(t, upToDate)
+}
+
+func TestEventStream_RequiresResyncOutsideTheLog(t *testing.T) {
+	stream, _ := newEventStream(t, 50)
+	publishDestinations(stream, 250)
+
+	_, err := stream.EventsSince(10)
+	assert.ErrorIs(t, err, events.ErrResyncRequired, "trimmed events cannot be replayed")
+
+	_, err = stream.EventsSince(300)
+	assert.ErrorIs(t, err, events.ErrResyncRequired, "a client ahead of the log must resync")
+
+	missed, err := stream.EventsSince(200)
+	require.NoError(t, err)
+	assert.Len(t, missed, 50)
+}
+
+// blockingPublisher holds up delivery until release is closed.
+type blockingPublisher struct {
+	release chan struct{}
+}
+
+func (p blockingPublisher) Publish(event events.Event) {
+	<-p.release
+}
+
+func TestEventStream_PublishDoesNotWaitForSubscribers(t *testing.T) {
+	release := make(chan struct{})
+	stream, subscriber := newEventStream(t, 0, blockingPublisher{release: release})
+
+	published := make(chan struct{})
+	go func() {
+		stream.Publish(destinationCreated(1))
+		stream.Publish(destinationCreated(2))
+		close(published)
+	}()
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("Publish waited for a blocked subscriber")
+	}
+
+	close(release)
+	stream.Flush()
+	require.Len(t, subscriber.Events, 2)
+	assert.Equal(t, uint64(2), subscriber.Events[1].Sequence)
+}
+
+func TestEventStream_CloseDeliversQueuedEvents(t *testing.T) {
+	stream, subscriber := newEventStream(t, 0)
+
+	stream.Publish(destinationCreated(1))
+	stream.Close()
+	stream.Publish(destinationCreated(2))
+
+	require.Len(t, subscriber.Events, 1, "events published after Close are dropped")
+	assert.Equal(t, uint64(1), subscriber.Events[0].Sequence)
+}
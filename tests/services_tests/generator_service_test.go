@@ -0,0 +1,126 @@
+package services
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/events"
+	"Trip-Trove-API/domain/repositories"
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/infrastructure/dataaccess"
+	"Trip-Trove-API/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+var generatorAdmin = entities.Actor{UserID: 1, Role: entities.Admin, Authenticated: true}
+
+func newGeneratorService(t *testing.T) (*services.GeneratorService, repositories.Repositories, *mocks.RecordingPublisher) {
+	store := dataaccess.NewMemoryStore()
+	repos := dataaccess.NewMemoryRepositories(store)
+	publisher := &mocks.RecordingPublisher{}
+	destinationService := &services.DestinationService{
+		Repo:         repos.Destinations,
+		LocationRepo: repos.Locations,
+		UnitOfWork:   dataaccess.NewMemoryUnitOfWork(store),
+		Events:       publisher,
+	}
+
+	service := &services.GeneratorService{Destinations: destinationService}
+	t.Cleanup(service.Close)
+	return service, repos, publisher
+}
+
+func waitForJob(t *testing.T, service *services.GeneratorService, id string) services.GeneratorJob {
+	var job services.GeneratorJob
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = service.JobByID(id)
+		return err == nil && job.Status != services.JobRunning
+	}, 5*time.Second, 10*time.Millisecond)
+	return job
+}
+
+func TestGeneratorService_StopsAfterCount(t *testing.T) {
+	service, repos, publisher := newGeneratorService(t)
+	seed := int64(42)
+
+	started, err := service.StartJob(services.GeneratorRequest{IntervalMs: 100, Count: 3, Seed: &seed}, generatorAdmin)
+	require.NoError(t, err)
+	assert.Equal(t, services.JobRunning, started.Status)
+	assert.Equal(t, seed, started.Seed)
+
+	job := waitForJob(t, service, started.ID)
+	assert.Equal(t, services.JobCompleted, job.Status)
+	assert.Equal(t, 3, job.Generated+job.Skipped)
+	assert.NotNil(t, job.FinishedAt)
+
+	destinations, err := repos.Destinations.AllDestinations()
+	require.NoError(t, err)
+	assert.Len(t, destinations, job.Generated)
+
+	require.Len(t, publisher.Events, 2*job.Generated)
+	assert.Equal(t, events.LocationCreated, publisher.Events[0].Type)
+	assert.Equal(t, events.DestinationCreated, publisher.Events[1].Type)
+	assert.Equal(t, generatorAdmin, publisher.Events[1].Actor)
+}
+
+func TestGeneratorService_SameSeedGeneratesSameDestinations(t *testing.T) {
+	seed := int64(7)
+	var names [2][]string
+
+	for i := range names {
+		service, repos, _ := newGeneratorService(t)
+		started, err := service.StartJob(services.GeneratorRequest{IntervalMs: 100, Count: 2, Seed: &seed}, generatorAdmin)
+		require.NoError(t, err)
+		waitForJob(t, service, started.ID)
+
+		destinations, err := repos.Destinations.AllDestinations()
+		require.NoError(t, err)
+		for _, destination := range destinations {
+			names[i] = append(names[i], destination.Name)
+		}
+	}
+
+	assert.NotEmpty(t, names[0])
+	assert.Equal(t, names[0], names[1])
+}
+
+func TestGeneratorService_StopCancelsRunningJob(t *testing.T) {
+	service, repos, _ := newGeneratorService(t)
+
+	started, err := service.StartJob(services.GeneratorRequest{IntervalMs: 60000, Count: 10}, generatorAdmin)
+	require.NoError(t, err)
+
+	stopped, err := service.StopJob(started.ID)
+	require.NoError(t, err)
+	assert.Equal(t, services.JobStopped, stopped.Status)
+	assert.Zero(t, stopped.Generated)
+
+	destinations, err := repos.Destinations.AllDestinations()
+	require.NoError(t, err)
+	assert.Empty(t, destinations)
+
+	again, err := service.StopJob(started.ID)
+	require.NoError(t, err, "stopping a finished job is harmless")
+	assert.Equal(t, services.JobStopped, again.Status)
+}
+
+func TestGeneratorService_UnknownJobAndLimits(t *testing.T) {
+	service, _, _ := newGeneratorService(t)
+
+	_, err := service.StopJob("missing")
+	assert.Equal(t, apperrors.KindNotFound, apperrors.KindOf(err))
+
+	_, err = service.StartJob(services.GeneratorRequest{IntervalMs: 10, Count: 1}, generatorAdmin)
+	assert.Equal(t, apperrors.KindValidation, apperrors.KindOf(err))
+
+	for i := 0; i < services.MaxRunningGenerators; i++ {
+		_, err := service.StartJob(services.GeneratorRequest{IntervalMs: 60000, Count: 1}, generatorAdmin)
+		require.NoError(t, err)
+	}
+	_, err = service.StartJob(services.GeneratorRequest{IntervalMs: 60000, Count: 1}, generatorAdmin)
+	assert.Equal(t, apperrors.KindConflict, apperrors.KindOf(err))
+	assert.Len(t, service.Jobs(), services.MaxRunningGenerators)
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/tests/mocks"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+// usePolicy makes policy the active one for the rest of the test.
+func usePolicy(t *testing.T, policy entities.Policy) {
+	entities.SetPolicy(policy)
+	t.Cleanup(func() { entities.SetPolicy(entities.DefaultPolicy()) })
+}
+
+func TestDefaultPolicy_KeepsWhatTheOrderedRolesAllowed(t *testing.T) {
+	policy := entities.DefaultPolicy()
+
+	assert.True(t, policy.Allows(entities.NormalUser, entities.TripsWrite))
+	assert.False(t, policy.Allows(entities.NormalUser, entities.DestinationsWrite))
+	assert.True(t, policy.Allows(entities.Manager, entities.DestinationsImport))
+	assert.False(t, policy.Allows(entities.Manager, entities.DestinationsManageAny))
+	assert.False(t, policy.Allows(entities.Manager, entities.UsersRead))
+	for _, permission := range entities.AllPermissions {
+		assert.True(t, policy.Allows(entities.Admin, permission), permission)
+	}
+	assert.Equal(t, []entities.Permission{entities.EventsRead, entities.TripsWrite}, policy.Permissions(entities.NormalUser))
+}
+
+func TestLoadPolicy_ReplacesOnlyTheListedRoles(t *testing.T) {
+	policy, err := entities.LoadPolicy(strings.NewReader(`{"manager": ["locations:write", "users:read"]}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, []entities.Permission{entities.LocationsWrite, entities.UsersRead}, policy.Permissions(entities.Manager))
+	assert.Equal(t, entities.DefaultPolicy().Permissions(entities.NormalUser), policy.Permissions(entities.NormalUser))
+	assert.True(t, policy.Allows(entities.Admin, entities.UsersRoles))
+}
+
+func TestLoadPolicy_RejectsInvalidConfigurations(t *testing.T) {
+	for name, config := range map[string]string{
+		"malformed":          `["manager"]`,
+		"unknown role":       `{"owner": ["trips:write"]}`,
+		"unknown permission": `{"user": ["trips:delete_all"]}`,
+		"admin locked out":   `{"admin": ["users:read"]}`,
+	} {
+		_, err := entities.LoadPolicy(strings.NewReader(config))
+		assert.Error(t, err, name)
+	}
+}
+
+func TestLocationService_ChecksThePolicy(t *testing.T) {
+	repo := &mocks.MockLocationRepository{
+		CreateLocationFunc: func(location entities.Location) (entities.Location, error) {
+			return location, nil
+		},
+	}
+	service := services.LocationService{Repo: repo}
+	traveller := entities.Actor{UserID: 9, Role: entities.NormalUser, Authenticated: true}
+
+	_, err := service.CreateLocation(entities.Location{Name: "Lisbon", Country: "Portugal"}, traveller)
+	assert.ErrorIs(t, err, services.ErrForbidden)
+	_, err = service.DeleteLocation("3", traveller)
+	assert.ErrorIs(t, err, services.ErrForbidden)
+
+	usePolicy(t, entities.NewPolicy(map[entities.AccessType][]entities.Permission{
+		entities.NormalUser: {entities.LocationsWrite},
+		entities.Admin:      entities.AllPermissions,
+	}))
+	_, err = service.CreateLocation(entities.Location{Name: "Lisbon", Country: "Portugal"}, traveller)
+	assert.NoError(t, err)
+}
+
+func TestChangeRole_CannotGrantPermissionsTheActorLacks(t *testing.T) {
+	service, repos, admin, user := newRoleService(t)
+	manager, err := repos.Users.Register(entities.User{Username: "manager1", Password: "Secret-pass-1!", Email: "manager1@example.com", PhoneNumber: "+40700000003"})
+	require.NoError(t, err)
+	_, err = service.ChangeRole(fmt.Sprint(manager.ID), entities.Manager, "", actorFor(admin))
+	require.NoError(t, err)
+	manager.Role = entities.Manager
+
+	usePolicy(t, entities.NewPolicy(map[entities.AccessType][]entities.Permission{
+		entities.Manager: {entities.UsersRoles},
+		entities.Admin:   entities.AllPermissions,
+	}))
+
+	_, err = service.ChangeRole(fmt.Sprint(user.ID), entities.Manager, "", actorFor(manager))
+	assert.NoError(t, err)
+	_, err = service.ChangeRole(fmt.Sprint(user.ID), entities.Admin, "", actorFor(manager))
+	assert.ErrorIs(t, err, services.ErrForbidden)
+	_, err = service.ChangeRole(fmt.Sprint(admin.ID), entities.NormalUser, "", actorFor(manager))
+	assert.ErrorIs(t, err, services.ErrForbidden)
+
+	// A lower role is no use when it carries a permission the manager does not have.
+	usePolicy(t, entities.NewPolicy(map[entities.AccessType][]entities.Permission{
+		entities.NormalUser: {entities.TripsWrite},
+		entities.Manager:    {entities.UsersRoles},
+		entities.Admin:      entities.AllPermissions,
+	}))
+	_, err = service.ChangeRole(fmt.Sprint(user.ID), entities.NormalUser, "", actorFor(manager))
+	assert.ErrorIs(t, err, services.ErrForbidden)
+}
+
+func TestChangeRole_EndsTheSessionsWhenTheNewRoleLosesAPermission(t *testing.T) {
+	service, _, admin, user := newRoleService(t)
+	usePolicy(t, entities.NewPolicy(map[entities.AccessType][]entities.Permission{
+		entities.NormalUser: {entities.TripsWrite, entities.EventsRead},
+		entities.Manager:    {entities.DestinationsWrite},
+		entities.Admin:      entities.AllPermissions,
+	}))
+	login, err := service.Login(entities.LoginRequest{Email: "user1@example.com", Password: "Secret-pass-1!"})
+	require.NoError(t, err)
+
+	_, err = service.ChangeRole(fmt.Sprint(user.ID), entities.Manager, "", actorFor(admin))
+	require.NoError(t, err)
+	_, err = service.Refresh(login.RefreshToken)
+	assert.ErrorIs(t, err, services.ErrInvalidRefreshToken, "the manager role does not keep trips:write")
+}
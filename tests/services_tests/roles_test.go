@@ -0,0 +1,141 @@
+package services
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/repositories"
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/infrastructure/dataaccess"
+	"Trip-Trove-API/utils"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// newRoleService starts with an Admin and a normal user whose password is "Secret-pass-1!".
+func newRoleService(t *testing.T) (*services.UserService, repositories.Repositories, entities.User, entities.User) {
+	store := dataaccess.NewMemoryStore()
+	repos := dataaccess.NewMemoryRepositories(store)
+	admins, err := repos.Users.CreateUsers([]entities.User{{Username: "admin1", Email: "admin1@example.com", PhoneNumber: "+40700000001", Role: entities.Admin}})
+	require.NoError(t, err)
+	user, err := repos.Users.Register(entities.User{Username: "user1", Password: "Secret-pass-1!", Email: "user1@example.com", PhoneNumber: "+40700000002"})
+	require.NoError(t, err)
+
+	return &services.UserService{
+		Repo:       repos.Users,
+		TokenRepo:  repos.Tokens,
+		Jwt:        utils.JwtWrapper{SecretKey: "test-secret", Issuer: "AuthService", ExpirationMinutes: 60, ExpirationHours: 24},
+		UnitOfWork: dataaccess.NewMemoryUnitOfWork(store),
+	}, repos, admins[0], user
+}
+
+func actorFor(user entities.User) entities.Actor {
+	return entities.Actor{UserID: user.ID, Role: user.Role, Authenticated: true}
+}
+
+func TestChangeRole_GrantsAndRevokesWithAnAuditTrail(t *testing.T) {
+	service, repos, admin, user := newRoleService(t)
+	target := fmt.Sprint(user.ID)
+
+	promoted, err := service.ChangeRole(target, entities.Manager, "runs the Lisbon office", actorFor(admin))
+	require.NoError(t, err)
+	assert.Equal(t, entities.Manager, promoted.Role)
+
+	_, err = service.ChangeRole(target, entities.Manager, "again", actorFor(admin))
+	require.NoError(t, err)
+
+	_, err = service.ChangeRole(target, entities.NormalUser, "left the office", actorFor(admin))
+	require.NoError(t, err)
+	stored, err := repos.Users.UserByID(user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.NormalUser, stored.Role)
+
+	changes, err := service.RoleChanges(target)
+	require.NoError(t, err)
+	require.Len(t, changes, 2, "setting the role a user already has is not recorded")
+	assert.Equal(t, entities.NormalUser, changes[0].OldRole)
+	assert.Equal(t, entities.Manager, changes[0].NewRole)
+	assert.Equal(t, "runs the Lisbon office", changes[0].Reason)
+	require.NotNil(t, changes[0].ChangedByID)
+	assert.Equal(t, admin.ID, *changes[0].ChangedByID)
+	assert.Equal(t, entities.Manager, changes[1].OldRole)
+	assert.Equal(t, entities.NormalUser, changes[1].NewRole)
+}
+
+func TestChangeRole_Guards(t *testing.T) {
+	service, repos, admin, user := newRoleService(t)
+
+	_, err := service.ChangeRole(fmt.Sprint(admin.ID), entities.NormalUser, "", actorFor(admin))
+	assert.True(t, errors.Is(err, services.ErrOwnRoleChange), "an Admin cannot remove the last Admin")
+
+	_, err = service.ChangeRole(fmt.Sprint(user.ID), entities.Admin, "", actorFor(user))
+	assert.True(t, errors.Is(err, services.ErrForbidden))
+
+	_, err = service.ChangeRole("999", entities.Manager, "", actorFor(admin))
+	assert.Equal(t, apperrors.KindNotFound, apperrors.KindOf(err))
+	_, err = service.ChangeRole("abc", entities.Manager, "", actorFor(admin))
+	assert.True(t, errors.Is(err, apperrors.ErrInvalidID))
+
+	changes, err := repos.Users.RoleChanges(user.ID)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestChangeRole_OnlyADemotionEndsTheSessions(t *testing.T) {
+	service, _, admin, user := newRoleService(t)
+	login, err := service.Login(entities.LoginRequest{Email: "user1@example.com", Password: "Secret-pass-1!"})
+	require.NoError(t, err)
+
+	_, err = service.ChangeRole(fmt.Sprint(user.ID), entities.Manager, "", actorFor(admin))
+	require.NoError(t, err)
+	login, err = service.Refresh(login.RefreshToken)
+	require.NoError(t, err, "a promotion keeps the session; refreshing picks up the new role")
+
+	_, err = service.ChangeRole(fmt.Sprint(user.ID), entities.NormalUser, "", actorFor(admin))
+	require.NoError(t, err)
+	_, err = service.Refresh(login.RefreshToken)
+	assert.True(t, errors.Is(err, services.ErrInvalidRefreshToken))
+}
+
+func TestBootstrapAdmin(t *testing.T) {
+	store := dataaccess.NewMemoryStore()
+	repos := dataaccess.NewMemoryRepositories(store)
+	service := &services.UserService{Repo: repos.Users, TokenRepo: repos.Tokens, UnitOfWork: dataaccess.NewMemoryUnitOfWork(store)}
+	existing, err := repos.Users.Register(entities.User{Username: "ana", Password: "Secret-pass-1!", Email: "ana@example.com", PhoneNumber: "+40700000001"})
+	require.NoError(t, err)
+
+	admin, created, err := service.BootstrapAdmin(entities.User{Email: "ana@example.com"})
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, existing.ID, admin.ID)
+	assert.Equal(t, entities.Admin, admin.Role)
+
+	changes, err := repos.Users.RoleChanges(existing.ID)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Nil(t, changes[0].ChangedByID)
+	assert.Equal(t, "bootstrap", changes[0].Reason)
+
+	_, _, err = service.BootstrapAdmin(entities.User{Username: "root", Password: "Secret-pass-1!", Email: "root@example.com", PhoneNumber: "+40700000002"})
+	assert.True(t, errors.Is(err, services.ErrAdminAlreadyExists))
+	_, err = repos.Users.UserByEmail("root@example.com")
+	assert.Equal(t, apperrors.KindNotFound, apperrors.KindOf(err), "nothing is kept when bootstrapping fails")
+}
+
+func TestBootstrapAdmin_RegistersANewVerifiedAdmin(t *testing.T) {
+	store := dataaccess.NewMemoryStore()
+	repos := dataaccess.NewMemoryRepositories(store)
+	service := &services.UserService{Repo: repos.Users, TokenRepo: repos.Tokens, UnitOfWork: dataaccess.NewMemoryUnitOfWork(store)}
+
+	admin, created, err := service.BootstrapAdmin(entities.User{Username: "root", Password: "Secret-pass-1!", Email: "root@example.com", PhoneNumber: "+40700000002"})
+	require.NoError(t, err)
+	assert.True(t, created)
+
+	stored, err := repos.Users.Authenticate(entities.LoginRequest{Email: "root@example.com", Password: "Secret-pass-1!"})
+	require.NoError(t, err)
+	assert.Equal(t, admin.ID, stored.ID)
+	assert.Equal(t, entities.Admin, stored.Role)
+	assert.True(t, stored.EmailVerified())
+}
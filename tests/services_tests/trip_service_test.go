@@ -0,0 +1,203 @@
+package services
+
+import (
+	"Trip-Trove-API/domain/apperrors"
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/tests/mocks"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"testing"
+)
+
+var tripLocations = map[uint]entities.Location{
+	1: {Model: gorm.Model{ID: 1}, Name: "Rovaniemi", Country: "Finland"},
+	2: {Model: gorm.Model{ID: 2}, Name: "Tromso", Country: "Norway"},
+}
+
+var tripDestinations = map[uint]entities.Destination{
+	10: {Model: gorm.Model{ID: 10}, Name: "Santa Claus Village", LocationID: 1},
+	11: {Model: gorm.Model{ID: 11}, Name: "Arktikum", LocationID: 1},
+	12: {Model: gorm.Model{ID: 12}, Name: "Fjellheisen", LocationID: 2},
+}
+
+func newTripTestService(tripRepo *mocks.MockTripRepository) *services.TripService {
+	return &services.TripService{
+		Repo: tripRepo,
+		DestinationRepo: &mocks.MockDestinationRepository{
+			DestinationByIDFunc: func(id uint) (*entities.Destination, error) {
+				destination, ok := tripDestinations[id]
+				if !ok {
+					return nil, apperrors.NotFound("destination not found")
+				}
+				return &destination, nil
+			},
+		},
+		LocationRepo: &mocks.MockLocationRepository{
+			LocationByIDFunc: func(id uint) (*entities.Location, error) {
+				location := tripLocations[id]
+				return &location, nil
+			},
+		},
+	}
+}
+
+func lappishTrip() *entities.Trip {
+	return &entities.Trip{
+		Model:     gorm.Model{ID: 5},
+		OwnerID:   3,
+		Name:      "Arctic circle",
+		StartDate: "2024-12-20",
+		EndDate:   "2024-12-22",
+		Stops: []entities.TripStop{
+			{Model: gorm.Model{ID: 1}, TripID: 5, DestinationID: 10, Day: 1, Position: 0},
+			{Model: gorm.Model{ID: 2}, TripID: 5, DestinationID: 11, Day: 1, Position: 1},
+			{Model: gorm.Model{ID: 3}, TripID: 5, DestinationID: 12, Day: 3, Position: 0},
+		},
+	}
+}
+
+var tripOwner = entities.Actor{UserID: 3, Role: entities.NormalUser, Authenticated: true, EmailVerified: true}
+
+func TestCreateTrip_AssignsOwnerAndPositions(t *testing.T) {
+	service := newTripTestService(&mocks.MockTripRepository{
+		CreateTripFunc: func(trip entities.Trip) (entities.Trip, error) {
+			return trip, nil
+		},
+	})
+
+	trip, err := service.CreateTrip(entities.Trip{
+		Name:      "Arctic circle",
+		OwnerID:   99,
+		StartDate: "2024-12-20",
+		EndDate:   "2024-12-22",
+		Stops: []entities.TripStop{
+			{DestinationID: 10, Day: 1},
+			{DestinationID: 11, Day: 1},
+			{DestinationID: 12, Day: 2},
+		},
+	}, tripOwner)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), trip.OwnerID)
+	assert.Equal(t, []int{0, 1, 0}, []int{trip.Stops[0].Position, trip.Stops[1].Position, trip.Stops[2].Position})
+}
+
+func TestCreateTrip_RejectsInvalidStops(t *testing.T) {
+	service := newTripTestService(&mocks.MockTripRepository{})
+
+	invalidTrips := []entities.Trip{
+		{Name: "Backwards", StartDate: "2024-12-22", EndDate: "2024-12-20"},
+		{Name: "Too long", StartDate: "2024-12-20", EndDate: "2024-12-21", Stops: []entities.TripStop{{DestinationID: 10, Day: 3}}},
+		{Name: "Nowhere", StartDate: "2024-12-20", EndDate: "2024-12-21", Stops: []entities.TripStop{{DestinationID: 404, Day: 1}}},
+	}
+
+	for _, trip := range invalidTrips {
+		_, err := service.CreateTrip(trip, tripOwner)
+		assert.True(t, errors.Is(err, services.ErrInvalidTrip), trip.Name)
+	}
+}
+
+func TestTripByID_HiddenFromOtherUsers(t *testing.T) {
+	service := newTripTestService(&mocks.MockTripRepository{
+		TripByIDFunc: func(id uint) (*entities.Trip, error) {
+			return lappishTrip(), nil
+		},
+	})
+
+	_, err := service.TripByID("5", entities.Actor{UserID: 4, Role: entities.Manager, Authenticated: true})
+	assert.EqualError(t, err, "trip not found")
+
+	_, err = service.TripByID("5", entities.Actor{UserID: 1, Role: entities.Admin, Authenticated: true})
+	assert.NoError(t, err)
+}
+
+func TestReorderStops(t *testing.T) {
+	var saved []entities.TripStop
+	service := newTripTestService(&mocks.MockTripRepository{
+		TripByIDFunc: func(id uint) (*entities.Trip, error) {
+			return lappishTrip(), nil
+		},
+		UpdateStopOrderFunc: func(tripID uint, stops []entities.TripStop) error {
+			saved = stops
+			return nil
+		},
+	})
+
+	_, err := service.ReorderStops("5", []entities.TripStopOrder{{StopID: 3, Day: 2}, {StopID: 2, Day: 1}, {StopID: 1, Day: 2}}, tripOwner)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), saved[0].ID)
+	assert.Equal(t, 0, saved[0].Position)
+	assert.Equal(t, uint(1), saved[2].ID)
+	assert.Equal(t, 2, saved[2].Day)
+	assert.Equal(t, 1, saved[2].Position)
+
+	_, err = service.ReorderStops("5", []entities.TripStopOrder{{StopID: 3, Day: 1}, {StopID: 3, Day: 1}, {StopID: 1, Day: 1}}, tripOwner)
+	assert.True(t, errors.Is(err, services.ErrInvalidTrip), "duplicate stops must be rejected")
+}
+
+func TestTripSummary(t *testing.T) {
+	service := newTripTestService(&mocks.MockTripRepository{
+		TripByIDFunc: func(id uint) (*entities.Trip, error) {
+			return lappishTrip(), nil
+		},
+	})
+
+	summary, err := service.TripSummary("5", tripOwner)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, summary.Days)
+	assert.Equal(t, 3, summary.StopCount)
+	assert.Equal(t, []string{"Finland", "Norway"}, summary.Countries)
+	assert.Len(t, summary.Locations, 2)
+	assert.Equal(t, []uint{10, 11, 12}, summary.Destinations)
+}
+
+func TestTripSummary_SkipsDeletedAndHiddenDestinations(t *testing.T) {
+	trip := lappishTrip()
+	trip.Stops = append(trip.Stops,
+		entities.TripStop{Model: gorm.Model{ID: 4}, TripID: 5, DestinationID: 99, Day: 2, Position: 0},
+		entities.TripStop{Model: gorm.Model{ID: 5}, TripID: 5, DestinationID: 13, Day: 2, Position: 1},
+	)
+	service := newTripTestService(&mocks.MockTripRepository{
+		TripByIDFunc: func(id uint) (*entities.Trip, error) {
+			return trip, nil
+		},
+	})
+	stranger := uint(8)
+	service.DestinationRepo = &mocks.MockDestinationRepository{
+		DestinationByIDFunc: func(id uint) (*entities.Destination, error) {
+			if id == 13 {
+				return &entities.Destination{Model: gorm.Model{ID: 13}, Name: "Secret sauna", LocationID: 2, IsPrivate: true, OwnerID: &stranger}, nil
+			}
+			destination, ok := tripDestinations[id]
+			if !ok {
+				return nil, apperrors.NotFound("destination not found")
+			}
+			return &destination, nil
+		},
+	}
+
+	summary, err := service.TripSummary("5", tripOwner)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, summary.StopCount)
+	assert.Equal(t, []uint{10, 11, 12}, summary.Destinations, "deleted and private destinations are left out")
+}
+
+func TestTripSummary_ReturnsRepositoryErrors(t *testing.T) {
+	service := newTripTestService(&mocks.MockTripRepository{
+		TripByIDFunc: func(id uint) (*entities.Trip, error) {
+			return lappishTrip(), nil
+		},
+	})
+	failure := errors.New("connection reset")
+	service.DestinationRepo = &mocks.MockDestinationRepository{
+		DestinationByIDFunc: func(id uint) (*entities.Destination, error) {
+			return nil, failure
+		},
+	}
+
+	summary, err := service.TripSummary("5", tripOwner)
+	assert.ErrorIs(t, err, failure)
+	assert.Nil(t, summary)
+}
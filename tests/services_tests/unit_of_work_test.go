@@ -0,0 +1,118 @@
+package services
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/repositories"
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/tests/mocks"
+	"errors"
+	"github.com/jaswdr/faker"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"testing"
+)
+
+var locationManager = entities.Actor{UserID: 7, Role: entities.Manager, Authenticated: true}
+
+func noDestinations(locationID uint) ([]entities.Destination, error) {
+	return nil, nil
+}
+
+func TestDeleteLocation_RunsInOneUnitOfWork(t *testing.T) {
+	var deletedFor uint
+	unitOfWork := &mocks.MockUnitOfWork{Repos: repositories.Repositories{
+		Destinations: &mocks.MockDestinationRepository{
+			DestinationsForLocationFunc: noDestinations,
+			DeleteDestinationsByLocationIDFunc: func(locationID uint) error {
+				deletedFor = locationID
+				return nil
+			},
+		},
+		Locations: &mocks.MockLocationRepository{
+			DeleteLocationFunc: func(id uint) (entities.Location, error) {
+				return entities.Location{Model: gorm.Model{ID: id}, Name: "Lisbon"}, nil
+			},
+		},
+	}}
+	service := services.LocationService{UnitOfWork: unitOfWork}
+
+	location, err := service.DeleteLocation("3", locationManager)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Lisbon", location.Name)
+	assert.Equal(t, uint(3), deletedFor)
+	assert.True(t, unitOfWork.Committed)
+}
+
+func TestDeleteLocation_RollsBackWhenLocationDeleteFails(t *testing.T) {
+	unitOfWork := &mocks.MockUnitOfWork{Repos: repositories.Repositories{
+		Destinations: &mocks.MockDestinationRepository{
+			DestinationsForLocationFunc: noDestinations,
+			DeleteDestinationsByLocationIDFunc: func(locationID uint) error {
+				return nil
+			},
+		},
+		Locations: &mocks.MockLocationRepository{
+			DeleteLocationFunc: func(id uint) (entities.Location, error) {
+				return entities.Location{}, errors.New("location not found")
+			},
+		},
+	}}
+	service := services.LocationService{UnitOfWork: unitOfWork}
+
+	_, err := service.DeleteLocation("3", locationManager)
+
+	assert.EqualError(t, err, "location not found")
+	assert.True(t, unitOfWork.RolledBack)
+	assert.False(t, unitOfWork.Committed)
+}
+
+func TestGenerateFakeDestination_RollsBackWhenDestinationCreateFails(t *testing.T) {
+	unitOfWork := &mocks.MockUnitOfWork{Repos: repositories.Repositories{
+		Locations: &mocks.MockLocationRepository{
+			CreateLocationFunc: func(location entities.Location) (entities.Location, error) {
+				location.ID = 9
+				return location, nil
+			},
+		},
+		Destinations: &mocks.MockDestinationRepository{
+			CreateDestinationFunc: func(destination entities.Destination) (entities.Destination, error) {
+				assert.Equal(t, uint(9), destination.LocationID)
+				return entities.Destination{}, errors.New("duplicate destination name")
+			},
+		},
+	}}
+	service := services.DestinationService{UnitOfWork: unitOfWork}
+
+	_, err := service.GenerateFakeDestination(faker.New(), entities.Anonymous)
+
+	assert.EqualError(t, err, "duplicate destination name")
+	assert.True(t, unitOfWork.RolledBack)
+}
+
+func TestDeleteLocation_RefusesToCascadeOverDestinationsOfOthers(t *testing.T) {
+	otherOwner := uint(9)
+	ownOwner := locationManager.UserID
+	deleted := false
+	unitOfWork := &mocks.MockUnitOfWork{Repos: repositories.Repositories{
+		Destinations: &mocks.MockDestinationRepository{
+			DestinationsForLocationFunc: func(locationID uint) ([]entities.Destination, error) {
+				return []entities.Destination{
+					{Model: gorm.Model{ID: 1}, LocationID: locationID, OwnerID: &ownOwner},
+					{Model: gorm.Model{ID: 2}, LocationID: locationID, OwnerID: &otherOwner, IsPrivate: true},
+				}, nil
+			},
+			DeleteDestinationsByLocationIDFunc: func(locationID uint) error {
+				deleted = true
+				return nil
+			},
+		},
+	}}
+	service := services.LocationService{UnitOfWork: unitOfWork}
+
+	_, err := service.DeleteLocation("3", locationManager)
+
+	assert.ErrorIs(t, err, services.ErrLocationHasOthersDestinations)
+	assert.False(t, deleted)
+	assert.True(t, unitOfWork.RolledBack)
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/tests/mocks"
+	"Trip-Trove-API/utils"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func newTokenTestService(tokens *mocks.FakeTokenRepository) *services.UserService {
+	user := &entities.User{Model: gorm.Model{ID: 3}, Email: "traveller@example.com", Role: entities.NormalUser}
+	return &services.UserService{
+		Repo: &mocks.MockUserRepository{
+			AuthenticateFunc: func(loginData entities.LoginRequest) (*entities.User, error) {
+				return user, nil
+			},
+			UserByIDFunc: func(id uint) (*entities.User, error) {
+				return user, nil
+			},
+		},
+		TokenRepo: tokens,
+		Jwt:       utils.JwtWrapper{SecretKey: "test-secret", Issuer: "AuthService", ExpirationMinutes: 60, ExpirationHours: 24},
+	}
+}
+
+func TestRefresh_RotatesRefreshToken(t *testing.T) {
+	tokens := mocks.NewFakeTokenRepository()
+	service := newTokenTestService(tokens)
+
+	login, err := service.Login(entities.LoginRequest{Email: "traveller@example.com", Password: "Secret1!"})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, login.RefreshToken)
+
+	refreshed, err := service.Refresh(login.RefreshToken)
+	assert.NoError(t, err)
+	assert.NotEqual(t, login.RefreshToken, refreshed.RefreshToken)
+	assert.NotEqual(t, login.Jwt, refreshed.Jwt)
+	assert.Equal(t, tokens.RefreshTokens[0].FamilyID, tokens.RefreshTokens[1].FamilyID)
+	assert.NotNil(t, tokens.RefreshTokens[0].RevokedAt)
+}
+
+func TestRefresh_ReuseRevokesWholeFamily(t *testing.T) {
+	tokens := mocks.NewFakeTokenRepository()
+	service := newTokenTestService(tokens)
+
+	login, _ := service.Login(entities.LoginRequest{})
+	refreshed, err := service.Refresh(login.RefreshToken)
+	assert.NoError(t, err)
+
+	_, err = service.Refresh(login.RefreshToken)
+	assert.True(t, errors.Is(err, services.ErrInvalidRefreshToken))
+
+	_, err = service.Refresh(refreshed.RefreshToken)
+	assert.True(t, errors.Is(err, services.ErrInvalidRefreshToken), "rotated descendant must be revoked after reuse")
+
+	revoked, _ := tokens.IsAccessTokenRevoked(tokens.RefreshTokens[1].AccessTokenID)
+	assert.True(t, revoked)
+}
+
+// racedTokenRepository lets a concurrent refresh rotate each token right after it was read.
+type racedTokenRepository struct {
+	*mocks.FakeTokenRepository
+}
+
+func (r racedTokenRepository) RefreshTokenByHash(hash string) (*entities.RefreshToken, error) {
+	token, err := r.FakeTokenRepository.RefreshTokenByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.FakeTokenRepository.RevokeRefreshToken(token.ID); err != nil {
+		return nil, err
+	}
+	_, err = r.FakeTokenRepository.CreateRefreshToken(entities.RefreshToken{
+		UserID:          token.UserID,
+		TokenHash:       "rotated-by-the-other-request",
+		FamilyID:        token.FamilyID,
+		AccessTokenID:   "other-access-token",
+		AccessExpiresAt: token.AccessExpiresAt,
+		ExpiresAt:       token.ExpiresAt,
+	})
+	return token, err
+}
+
+func TestRefresh_LosingTheRotationRaceCountsAsReuse(t *testing.T) {
+	tokens := mocks.NewFakeTokenRepository()
+	service := newTokenTestService(tokens)
+	login, _ := service.Login(entities.LoginRequest{})
+	service.TokenRepo = racedTokenRepository{tokens}
+
+	_, err := service.Refresh(login.RefreshToken)
+
+	assert.True(t, errors.Is(err, services.ErrInvalidRefreshToken))
+	assert.Len(t, tokens.RefreshTokens, 2, "the losing request gets no token pair")
+	assert.NotNil(t, tokens.RefreshTokens[1].RevokedAt, "the winner's token is revoked with its family")
+	revoked, _ := tokens.IsAccessTokenRevoked("other-access-token")
+	assert.True(t, revoked)
+}
+
+func TestRefresh_UnknownToken(t *testing.T) {
+	service := newTokenTestService(mocks.NewFakeTokenRepository())
+
+	_, err := service.Refresh("not-a-token")
+	assert.True(t, errors.Is(err, services.ErrInvalidRefreshToken))
+}
+
+func TestLogout_RevokesAccessAndRefreshToken(t *testing.T) {
+	tokens := mocks.NewFakeTokenRepository()
+	service := newTokenTestService(tokens)
+
+	login, _ := service.Login(entities.LoginRequest{})
+	accessTokenID := tokens.RefreshTokens[0].AccessTokenID
+
+	err := service.Logout(3, accessTokenID, login.ExpiresAt, login.RefreshToken)
+	assert.NoError(t, err)
+
+	revoked, _ := tokens.IsAccessTokenRevoked(accessTokenID)
+	assert.True(t, revoked)
+
+	_, err = service.Refresh(login.RefreshToken)
+	assert.True(t, errors.Is(err, services.ErrInvalidRefreshToken))
+}
+
+func TestRevokeAllSessions(t *testing.T) {
+	tokens := mocks.NewFakeTokenRepository()
+	service := newTokenTestService(tokens)
+
+	first, _ := service.Login(entities.LoginRequest{})
+	second, _ := service.Login(entities.LoginRequest{})
+
+	assert.NoError(t, service.RevokeAllSessions("3"))
+
+	for _, token := range tokens.RefreshTokens {
+		revoked, _ := tokens.IsAccessTokenRevoked(token.AccessTokenID)
+		assert.True(t, revoked)
+	}
+	_, err := service.Refresh(first.RefreshToken)
+	assert.Error(t, err)
+	_, err = service.Refresh(second.RefreshToken)
+	assert.Error(t, err)
+}
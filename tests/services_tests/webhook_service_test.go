@@ -0,0 +1,220 @@
+package services
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/events"
+	"Trip-Trove-API/domain/repositories"
+	"Trip-Trove-API/domain/services"
+	"Trip-Trove-API/infrastructure/dataaccess"
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const webhookSecret = "a-secret-of-at-least-16-chars"
+
+type receivedDelivery struct {
+	header http.Header
+	body   []byte
+}
+
+// webhookReceiver fails the first failures requests with a 503 and accepts the rest.
+type webhookReceiver struct {
+	mu       sync.Mutex
+	failures int
+	received []receivedDelivery
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.received = append(r.received, receivedDelivery{header: req.Header.Clone(), body: body})
+	if len(r.received) <= r.failures {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (r *webhookReceiver) requests() []receivedDelivery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]receivedDelivery(nil), r.received...)
+}
+
+func newWebhookService(t *testing.T, receiver *webhookReceiver, eventTypes ...string) (*services.WebhookService, *services.EventStream, entities.Webhook) {
+	server := httptest.NewServer(receiver)
+	t.Cleanup(server.Close)
+
+	repos := dataaccess.NewMemoryRepositories(dataaccess.NewMemoryStore())
+	service := &services.WebhookService{
+		Repo:         repos.Webhooks,
+		MaxAttempts:  3,
+		BaseDelay:    10 * time.Millisecond,
+		PollInterval: 10 * time.Millisecond,
+	}
+
+	webhook, err := service.CreateWebhook(entities.WebhookRequest{URL: server.URL, Secret: webhookSecret, EventTypes: eventTypes})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go service.Run(ctx)
+
+	stream := &services.EventStream{Log: repos.Events, Subscribers: []events.Publisher{service}}
+	t.Cleanup(stream.Close)
+	return service, stream, webhook
+}
+
+func publicDestinationEvent(id uint) events.Event {
+	return events.NewDestinationEvent(events.DestinationCreated, entities.Destination{
+		Model: gorm.Model{ID: id},
+		Name:  "Belem Tower",
+	}, entities.Anonymous)
+}
+
+func waitForDelivery(t *testing.T, service *services.WebhookService, webhookID uint, status entities.WebhookDeliveryStatus) entities.WebhookDelivery {
+	var delivery entities.WebhookDelivery
+	require.Eventually(t, func() bool {
+		page, err := service.Deliveries(strconv.Itoa(int(webhookID)), entities.WebhookDeliveryQuery{Status: status})
+		if err != nil || len(page.Deliveries) == 0 {
+			return false
+		}
+		delivery = page.Deliveries[0]
+		return true
+	}, 2*time.Second, 10*time.Millisecond)
+	return delivery
+}
+
+func TestWebhookService_DeliversSignedPayloadAfterRetries(t *testing.T) {
+	receiver := &webhookReceiver{failures: 2}
+	service, stream, webhook := newWebhookService(t, receiver, string(events.DestinationCreated))
+
+	stream.Publish(publicDestinationEvent(7))
+
+	delivery := waitForDelivery(t, service, webhook.ID, entities.DeliverySucceeded)
+	assert.Equal(t, 3, delivery.Attempts)
+	assert.Equal(t, http.StatusNoContent, delivery.ResponseStatus)
+	assert.Equal(t, uint64(1), delivery.EventSequence)
+	assert.NotNil(t, delivery.DeliveredAt)
+
+	requests := receiver.requests()
+	require.Len(t, requests, 3)
+	last := requests[2]
+	assert.Equal(t, "DestinationCreated", last.header.Get(services.WebhookEventHeader))
+	assert.Equal(t, strconv.Itoa(int(delivery.ID)), last.header.Get(services.WebhookDeliveryHeader))
+
+	timestamp, err := strconv.ParseInt(last.header.Get(services.WebhookTimestampHeader), 10, 64)
+	require.NoError(t, err)
+	assert.Equal(t, services.SignWebhookPayload(webhookSecret, timestamp, last.body), last.header.Get(services.WebhookSignatureHeader))
+	assert.NotEqual(t, services.SignWebhookPayload("another-secret-value", timestamp, last.body), last.header.Get(services.WebhookSignatureHeader))
+
+	var envelope events.Envelope
+	require.NoError(t, json.Unmarshal(last.body, &envelope))
+	assert.Equal(t, events.DestinationCreated, envelope.Type)
+	assert.Equal(t, uint64(1), envelope.Sequence)
+}
+
+func TestWebhookService_GivesUpAfterMaxAttemptsAndReplays(t *testing.T) {
+	receiver := &webhookReceiver{failures: 3}
+	service, stream, webhook := newWebhookService(t, receiver, string(events.DestinationCreated))
+
+	stream.Publish(publicDestinationEvent(7))
+
+	failed := waitForDelivery(t, service, webhook.ID, entities.DeliveryFailed)
+	assert.Equal(t, 3, failed.Attempts)
+	assert.Equal(t, http.StatusServiceUnavailable, failed.ResponseStatus)
+	assert.Contains(t, failed.LastError, "503")
+
+	replay, err := service.ReplayDelivery(strconv.Itoa(int(webhook.ID)), strconv.Itoa(int(failed.ID)))
+	require.NoError(t, err)
+	require.NotNil(t, replay.ReplayOf)
+	assert.Equal(t, failed.ID, *replay.ReplayOf)
+
+	succeeded := waitForDelivery(t, service, webhook.ID, entities.DeliverySucceeded)
+	assert.Equal(t, replay.ID, succeeded.ID)
+	assert.Equal(t, failed.Payload, succeeded.Payload)
+
+	requests := receiver.requests()
+	require.Len(t, requests, 4)
+	assert.Equal(t, requests[0].body, requests[3].body)
+}
+
+func TestWebhookService_OnlyQueuesSubscribedPublicEvents(t *testing.T) {
+	receiver := &webhookReceiver{}
+	service, stream, webhook := newWebhookService(t, receiver, string(events.LocationDeleted))
+
+	ownerID := uint(5)
+	stream.Publish(publicDestinationEvent(1))
+	stream.Publish(events.NewDestinationEvent(events.DestinationCreated, entities.Destination{
+		Model: gorm.Model{ID: 2}, Name: "Private", IsPrivate: true, OwnerID: &ownerID,
+	}, entities.Actor{UserID: ownerID, Role: entities.NormalUser, Authenticated: true}))
+	stream.Publish(events.NewLocationEvent(events.LocationDeleted, entities.Location{Model: gorm.Model{ID: 3}, Name: "Lisbon"}, entities.Anonymous))
+
+	delivery := waitForDelivery(t, service, webhook.ID, entities.DeliverySucceeded)
+	assert.Equal(t, "LocationDeleted", delivery.EventType)
+
+	page, err := service.Deliveries(strconv.Itoa(int(webhook.ID)), entities.WebhookDeliveryQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), page.Total)
+}
+
+func TestWebhookService_RejectsUnknownEventTypesAndMissingSecret(t *testing.T) {
+	service := &services.WebhookService{Repo: dataaccess.NewMemoryWebhookRepository(dataaccess.NewMemoryStore())}
+
+	_, err := service.CreateWebhook(entities.WebhookRequest{URL: "https://partner.example", Secret: webhookSecret, EventTypes: []string{"TripCreated"}})
+	assert.Error(t, err)
+
+	_, err = service.CreateWebhook(entities.WebhookRequest{URL: "https://partner.example", EventTypes: []string{"LocationCreated"}})
+	assert.Error(t, err)
+}
+
+// countingWebhookRepository counts the lookups of active webhooks.
+type countingWebhookRepository struct {
+	repositories.WebhookRepository
+	lookups atomic.Int32
+}
+
+func (r *countingWebhookRepository) ActiveWebhooks() ([]entities.Webhook, error) {
+	r.lookups.Add(1)
+	return r.WebhookRepository.ActiveWebhooks()
+}
+
+func TestWebhookService_RecordsDeliveriesOnTheDispatcher(t *testing.T) {
+	repo := &countingWebhookRepository{WebhookRepository: dataaccess.NewMemoryWebhookRepository(dataaccess.NewMemoryStore())}
+	server := httptest.NewServer(&webhookReceiver{})
+	t.Cleanup(server.Close)
+	service := &services.WebhookService{Repo: repo, PollInterval: 10 * time.Millisecond}
+	webhook, err := service.CreateWebhook(entities.WebhookRequest{URL: server.URL, Secret: webhookSecret, EventTypes: []string{string(events.DestinationCreated)}})
+	require.NoError(t, err)
+
+	service.Publish(publicDestinationEvent(1))
+	assert.Zero(t, repo.lookups.Load())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		service.Run(ctx)
+		close(stopped)
+	}()
+	waitForDelivery(t, service, webhook.ID, "")
+
+	cancel()
+	<-stopped
+	service.Publish(publicDestinationEvent(2))
+	page, err := service.Deliveries(strconv.Itoa(int(webhook.ID)), entities.WebhookDeliveryQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), page.Total)
+}
@@ -0,0 +1,213 @@
+package websocket
+
+import (
+	"Trip-Trove-API/domain/entities"
+	"Trip-Trove-API/domain/events"
+	ws "Trip-Trove-API/infrastructure/websocket"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// startServer accepts sockets that are subscribed to every destination and only read to
+// keep the connection alive, like HandleConnections does.
+func startServer(t *testing.T, config ws.Config) (*ws.WebSocketManager, string) {
+	manager := ws.NewWebSocketManagerWithConfig(config)
+	go manager.BroadcastWebSocketMessage()
+	t.Cleanup(manager.Close)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := ws.Upgrade(w, r)
+		if err != nil {
+			return
+		}
+		subscription := ws.Subscription{Topics: []ws.Topic{ws.TopicAllDestinations}}
+		client := manager.AddWebSocketClient(conn, entities.Anonymous, time.Time{}, subscription)
+		defer manager.RemoveWebSocketClient(client)
+
+		for {
+			var msg ws.Message
+			if err := client.ReadJSON(&msg); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return manager, "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func dial(t *testing.T, url string) *websocket.Conn {
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func destinationEvent(id uint, description string) events.Event {
+	return events.NewDestinationEvent(events.DestinationCreated, entities.Destination{
+		Model:       gorm.Model{ID: id},
+		Name:        "Destination",
+		Description: description,
+	}, entities.Anonymous)
+}
+
+func waitForClients(t *testing.T, manager *ws.WebSocketManager, expected int64) {
+	require.Eventually(t, func() bool {
+		return manager.Metrics().ConnectedClients == expected
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+func TestManager_DisconnectsSlowConsumerWithoutBlockingOthers(t *testing.T) {
+	config := ws.DefaultConfig()
+	config.QueueSize = 4
+	manager, url := startServer(t, config)
+
+	dial(t, url)
+	healthy := dial(t, url)
+	waitForClients(t, manager, 2)
+
+	var received atomic.Int64
+	go func() {
+		for {
+			if _, _, err := healthy.ReadMessage(); err != nil {
+				return
+			}
+			received.Add(1)
+		}
+	}()
+
+	// The stalled socket never reads, so once the kernel buffers fill up its queue overflows.
+	payload := strings.Repeat("x", 512*1024)
+	for i := 0; i < 64; i++ {
+		manager.Publish(destinationEvent(uint(i), payload))
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	require.Eventually(t, func() bool {
+		return manager.Metrics().SlowConsumerDisconnects >= 1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.GreaterOrEqual(t, manager.Metrics().DroppedMessages, int64(1))
+
+	assert.Eventually(t, func() bool {
+		return received.Load() == 64
+	}, 5*time.Second, 10*time.Millisecond, "the healthy client receives everything")
+}
+
+func TestManager_DropsClientsThatStopAnsweringPings(t *testing.T) {
+	config := ws.DefaultConfig()
+	config.PingPeriod = 20 * time.Millisecond
+	config.PongWait = 100 * time.Millisecond
+	manager, url := startServer(t, config)
+
+	responsive := dial(t, url)
+	go func() {
+		// Reading lets the client answer pings with pongs.
+		for {
+			if _, _, err := responsive.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+	dial(t, url)
+	waitForClients(t, manager, 2)
+
+	waitForClients(t, manager, 1)
+	time.Sleep(3 * config.PongWait)
+	assert.Equal(t, int64(1), manager.Metrics().ConnectedClients, "a client answering pings stays connected")
+}
+
+func TestManager_CloseSendsGoingAwayAndDropsLaterEvents(t *testing.T) {
+	manager, url := startServer(t, ws.DefaultConfig())
+	conn := dial(t, url)
+	waitForClients(t, manager, 1)
+
+	manager.Close()
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	_, _, err := conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseGoingAway), "got %v", err)
+
+	manager.Publish(destinationEvent(1, "too late"))
+	assert.Equal(t, int64(1), manager.Metrics().DroppedEvents)
+	assert.Equal(t, int64(0), manager.Metrics().ConnectedClients)
+}
+
+func TestManager_PublishNeverBlocks(t *testing.T) {
+	config := ws.DefaultConfig()
+	config.BroadcastSize = 1
+	manager := ws.NewWebSocketManagerWithConfig(config)
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 10; i++ {
+			manager.Publish(destinationEvent(uint(i), "nobody routes these"))
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Publish blocked with a full broadcast queue")
+	}
+	assert.Equal(t, int64(1), manager.Metrics().Published)
+	assert.Equal(t, int64(9), manager.Metrics().DroppedEvents)
+}
+
+type replayFunc func(sequence uint64) ([]events.Event, error)
+
+func (f replayFunc) EventsSince(sequence uint64) ([]events.Event, error) {
+	return f(sequence)
+}
+
+func sequenced(event events.Event, sequence uint64) events.Event {
+	event.Sequence = sequence
+	return event
+}
+
+func TestManager_ReplaysWithoutTheLockAndKeepsEventsLoggedMeanwhile(t *testing.T) {
+	manager := ws.NewWebSocketManager()
+	go manager.BroadcastWebSocketMessage()
+	t.Cleanup(manager.Close)
+
+	topics := []ws.Topic{ws.TopicAllDestinations}
+	live := manager.AddStreamClient(entities.Anonymous, time.Time{}, ws.Subscription{Topics: topics})
+
+	since := uint64(0)
+	replay := replayFunc(func(uint64) ([]events.Event, error) {
+		// Both events are logged while the replay is read; the second one is not part of it.
+		manager.Publish(sequenced(destinationEvent(2, ""), 2))
+		manager.Publish(sequenced(destinationEvent(3, ""), 3))
+		for _, expected := range []uint64{2, 3} {
+			select {
+			case message := <-live.Messages():
+				assert.Equal(t, expected, message.Sequence)
+			case <-time.After(2 * time.Second):
+				t.Fatal("live delivery waited for the replay")
+			}
+		}
+		return []events.Event{sequenced(destinationEvent(1, ""), 1), sequenced(destinationEvent(2, ""), 2)}, nil
+	})
+	resumed := manager.AddStreamClient(entities.Anonymous, time.Time{}, ws.Subscription{Topics: topics, Since: &since, Replay: replay})
+
+	var received []string
+	for len(received) < 4 {
+		select {
+		case message := <-resumed.Messages():
+			received = append(received, message.Name+":"+strconv.FormatUint(message.Sequence, 10))
+		case <-time.After(2 * time.Second):
+			t.Fatalf("only received %v", received)
+		}
+	}
+	assert.Equal(t, []string{"DestinationCreated:1", "DestinationCreated:2", ws.ActionReplayed + ":0", "DestinationCreated:3"}, received)
+	assert.Empty(t, resumed.Messages())
+}
@@ -15,19 +15,26 @@ type JwtWrapper struct {
 }
 
 type JwtClaim struct {
-	Email  string              `json:"email"`
-	Role   entities.AccessType `json:"role"`
-	UserID uint                `json:"userID"`
+	Email         string              `json:"email"`
+	Role          entities.AccessType `json:"role"`
+	UserID        uint                `json:"userID"`
+	EmailVerified bool                `json:"email_verified"`
 	jwt.StandardClaims
 }
 
-func (j *JwtWrapper) GenerateToken(user entities.User) (signedToken string, err error) {
+func (j *JwtWrapper) GenerateToken(user entities.User, tokenID string) (signedToken string, expiresAt int64, err error) {
+	now := time.Now()
+	expiresAt = now.Add(time.Duration(j.ExpirationMinutes) * time.Minute).Unix()
+
 	claims := &JwtClaim{
-		Email:  user.Email,
-		Role:   user.Role,
-		UserID: user.ID,
+		Email:         user.Email,
+		Role:          user.Role,
+		UserID:        user.ID,
+		EmailVerified: user.EmailVerified(),
 		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: time.Now().Add(time.Duration(j.ExpirationMinutes) * time.Minute).Unix(),
+			Id:        tokenID,
+			IssuedAt:  now.Unix(),
+			ExpiresAt: expiresAt,
 			Issuer:    j.Issuer,
 		},
 	}
@@ -35,27 +42,13 @@ func (j *JwtWrapper) GenerateToken(user entities.User) (signedToken string, err
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	signedToken, err = token.SignedString([]byte(j.SecretKey))
 	if err != nil {
-		return "", err
+		return "", 0, err
 	}
-	return signedToken, nil
+	return signedToken, expiresAt, nil
 }
 
-func (j *JwtWrapper) RefreshToken(user entities.User) (signedToken string, err error) {
-	claims := &JwtClaim{
-		Email:  user.Email,
-		Role:   user.Role,
-		UserID: user.ID,
-		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: time.Now().Local().Add(time.Hour * time.Duration(j.ExpirationHours)).Unix(),
-			Issuer:    j.Issuer,
-		},
-	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signedToken, err = token.SignedString([]byte(j.SecretKey))
-	if err != nil {
-		return
-	}
-	return
+func (j *JwtWrapper) RefreshTokenExpiry() time.Time {
+	return time.Now().Add(time.Hour * time.Duration(j.ExpirationHours))
 }
 
 func (j *JwtWrapper) ValidateToken(signedToken string) (claims *JwtClaim, err error) {
@@ -82,12 +82,12 @@ func UsernameValidator(fl validator.FieldLevel) bool {
 
 func PasswordValidator(fl validator.FieldLevel) bool {
 	if password, ok := fl.Field().Interface().(string); ok {
-		return isComplexPassword(password)
+		return IsComplexPassword(password)
 	}
 	return false
 }
 
-func isComplexPassword(password string) bool {
+func IsComplexPassword(password string) bool {
 	var (
 		hasMinLen  = len(password) >= 8
 		hasMaxLen  = len(password) <= 40
@@ -98,7 +98,6 @@ func isComplexPassword(password string) bool {
 	)
 
 	for _, char := range password {
-		print(char)
 		switch {
 		case 'a' <= char && char <= 'z':
 			hasLower = true
@@ -107,12 +106,9 @@ func isComplexPassword(password string) bool {
 		case '0' <= char && char <= '9':
 			hasNumber = true
 		case strings.ContainsRune("@$!%*?&", char):
-
-			print("Special")
 			hasSpecial = true
 		}
 	}
-	print(hasMinLen, hasMaxLen, hasUpper, hasLower, hasNumber, hasSpecial)
 
 	return hasMinLen && hasMaxLen && hasUpper && hasLower && hasNumber && hasSpecial
 }